@@ -0,0 +1,79 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// render generates the fluentd or syslog-ng configuration from logging resource manifests without a cluster.
+//
+// Usage:
+//
+//	go run ./cmd/render [--logging name] [--namespace ns] [--secrets-dir dir] [file...]
+//
+// Manifests are read from the given files, or from stdin if no file (or "-") is given.
+// Secrets referenced by the resources are read from <secrets-dir>/<namespace>/<secret name>/<key>.
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.LoggingName, "logging", "", "Name of the Logging resource to render the configuration for, required if the manifests contain multiple Logging resources")
+	flag.StringVar(&opts.Namespace, "namespace", "default", "Namespace of the namespaced resources that do not set one")
+	flag.StringVar(&opts.SecretsDir, "secrets-dir", "", "Directory to load the referenced secrets from, laid out as <namespace>/<secret name>/<key>")
+	flag.Parse()
+
+	logger := zap.New(zap.UseDevMode(true), zap.WriteTo(os.Stderr))
+
+	if err := run(flag.Args(), os.Stdin, os.Stdout, opts, logger); err != nil {
+		logger.Error(err, "failed to render configuration")
+		os.Exit(1)
+	}
+}
+
+func run(files []string, stdin io.Reader, out io.Writer, opts options, logger logr.Logger) error {
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	var objects []client.Object
+	for _, file := range files {
+		objs, err := decodeManifestFile(file, stdin, logger)
+		if err != nil {
+			return errors.WrapIff(err, "decoding manifests from %s", file)
+		}
+		objects = append(objects, objs...)
+	}
+
+	return renderConfig(objects, opts, out, logger)
+}
+
+// decodeManifestFile decodes the manifests of a file, or of the standard input if the file is "-"
+func decodeManifestFile(file string, stdin io.Reader, logger logr.Logger) ([]client.Object, error) {
+	if file == "-" {
+		return decodeManifests(stdin, logger)
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errors.WrapIf(err, "opening manifest file")
+	}
+	defer f.Close()
+	return decodeManifests(f, logger)
+}
@@ -0,0 +1,77 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	_ = corev1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+}
+
+// decodeManifests decodes every document of a multi-document YAML or JSON stream.
+// Documents of kinds unknown to the renderer are skipped.
+func decodeManifests(in io.Reader, logger logr.Logger) ([]client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := yaml.NewYAMLReader(bufio.NewReader(in))
+
+	var objects []client.Object
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, errors.WrapIf(err, "reading manifest")
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, gvk, err := decoder.Decode(doc, nil, nil)
+		switch {
+		case runtime.IsMissingKind(err):
+			continue
+		case runtime.IsNotRegisteredError(err):
+			logger.Info("skipping unsupported manifest", "kind", gvk)
+			continue
+		case err != nil:
+			return nil, errors.WrapIf(err, "decoding manifest")
+		}
+
+		o, ok := obj.(client.Object)
+		if !ok {
+			logger.Info("skipping unsupported manifest", "kind", gvk)
+			continue
+		}
+		objects = append(objects, o)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/fluentd"
+	"github.com/kube-logging/logging-operator/pkg/resources/model"
+	"github.com/kube-logging/logging-operator/pkg/resources/syslogng"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/render"
+	syslogngconfig "github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config"
+)
+
+type options struct {
+	LoggingName string
+	Namespace   string
+	SecretsDir  string
+}
+
+// renderConfig writes the aggregator configuration of the selected logging to out.
+// The objects are served from an in-memory client so that resources are selected for the logging
+// the same way as in the operator.
+func renderConfig(objects []client.Object, opts options, out io.Writer, logger logr.Logger) error {
+	logging, err := selectLogging(objects, opts.LoggingName)
+	if err != nil {
+		return err
+	}
+	if err := logging.SetDefaults(); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to set defaults", "logging", logging.Name)
+	}
+	if logging.AreMultipleAggregatorsSet() {
+		return errors.New("fluentd and syslogNG cannot be enabled simultaneously")
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(withNamespaces(objects, opts.Namespace)...).
+		Build()
+
+	resources, err := model.NewLoggingResourceRepository(c, logger).LoggingResourcesFor(context.Background(), *logging)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to get logging resources", "logging", logging.Name)
+	}
+	if len(resources.Fluentd.ExcessFluentds) > 0 {
+		return errors.New("multiple fluentd configurations found, couldn't associate it with logging")
+	}
+	if len(resources.SyslogNG.ExcessSyslogNGs) > 0 {
+		return errors.New("multiple syslog-ng configurations found, couldn't associate it with logging")
+	}
+
+	if cfg := logging.Spec.FlowConfigOverride; cfg != "" {
+		_, err := io.WriteString(out, cfg)
+		return err
+	}
+
+	if _, fluentdSpec := resources.GetFluentd(); fluentdSpec != nil {
+		return renderFluentd(resources, opts, out, logger)
+	}
+	if _, syslogNGSpec := resources.GetSyslogNGSpec(); syslogNGSpec != nil {
+		return renderSyslogNG(resources, opts, out)
+	}
+	return errors.Errorf("logging %s has neither fluentd nor syslog-ng configured", logging.Name)
+}
+
+func renderFluentd(resources model.LoggingResources, opts options, out io.Writer, logger logr.Logger) error {
+	slf := fileSecretLoaderFactory{
+		Dir:       opts.SecretsDir,
+		MountPath: fluentd.OutputSecretPath,
+		Logging:   resources.Logging,
+	}
+
+	fluentConfig, err := model.CreateSystem(resources, &slf, logger)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to build model", "logging", resources.Logging.Name)
+	}
+	model.ApplyFluentdBufferLimits(fluentConfig, resources)
+
+	renderer := render.FluentRender{
+		Out:    out,
+		Indent: 2,
+	}
+	return errors.WrapIfWithDetails(renderer.Render(fluentConfig), "failed to render fluentd config", "logging", resources.Logging.Name)
+}
+
+func renderSyslogNG(resources model.LoggingResources, opts options, out io.Writer) error {
+	slf := fileSecretLoaderFactory{
+		Dir:       opts.SecretsDir,
+		MountPath: syslogng.OutputSecretPath,
+		Logging:   resources.Logging,
+	}
+
+	_, syslogNGSpec := resources.GetSyslogNGSpec()
+	_, clusterOutputs, outputs := model.SyslogNGBufferLimits(resources)
+	in := syslogngconfig.Input{
+		Name:                resources.Logging.Name,
+		Namespace:           resources.Logging.Namespace,
+		ClusterOutputs:      clusterOutputs,
+		Outputs:             outputs,
+		ClusterFlows:        resources.SyslogNG.ClusterFlows,
+		Flows:               resources.SyslogNG.Flows,
+		SecretLoaderFactory: &slf,
+		SourcePort:          syslogng.ServicePort,
+		ReservedPorts:       syslogng.ReservedPorts(syslogNGSpec),
+		SyslogNGSpec:        syslogNGSpec,
+	}
+	return errors.WrapIfWithDetails(syslogngconfig.RenderConfigInto(in, out), "failed to render syslog-ng config", "logging", resources.Logging.Name)
+}
+
+func selectLogging(objects []client.Object, name string) (*v1beta1.Logging, error) {
+	var loggings []*v1beta1.Logging
+	for _, obj := range objects {
+		if l, ok := obj.(*v1beta1.Logging); ok && (name == "" || l.Name == name) {
+			loggings = append(loggings, l)
+		}
+	}
+
+	switch {
+	case len(loggings) == 1:
+		return loggings[0].DeepCopy(), nil
+	case len(loggings) == 0 && name != "":
+		return nil, errors.Errorf("logging %s not found in the manifests", name)
+	case len(loggings) == 0:
+		return nil, errors.New("no logging found in the manifests")
+	default:
+		return nil, errors.New("multiple loggings found in the manifests, select one with --logging")
+	}
+}
+
+// withNamespaces sets the default namespace on namespaced objects that don't have one and
+// adds the namespaces that are referenced, but not defined in the manifests.
+func withNamespaces(objects []client.Object, defaultNamespace string) []client.Object {
+	namespaces := map[string]bool{}
+	for _, obj := range objects {
+		if ns, ok := obj.(*corev1.Namespace); ok {
+			namespaces[ns.Name] = true
+		}
+	}
+
+	res := make([]client.Object, 0, len(objects))
+	for _, obj := range objects {
+		switch obj.(type) {
+		case *corev1.Namespace, *v1beta1.Logging, *v1beta1.FluentbitAgent, *v1beta1.LoggingRoute:
+		default:
+			if obj.GetNamespace() == "" {
+				obj.SetNamespace(defaultNamespace)
+			}
+			if !namespaces[obj.GetNamespace()] {
+				namespaces[obj.GetNamespace()] = true
+				res = append(res, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: obj.GetNamespace()}})
+			}
+		}
+		res = append(res, obj)
+	}
+	return res
+}
@@ -0,0 +1,105 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fluentdManifests = `
+apiVersion: logging.banzaicloud.io/v1beta1
+kind: Logging
+metadata:
+  name: test
+spec:
+  controlNamespace: logging
+  fluentd: {}
+---
+apiVersion: logging.banzaicloud.io/v1beta1
+kind: Output
+metadata:
+  name: http
+spec:
+  http:
+    endpoint: http://example.com
+    auth:
+      username:
+        valueFrom:
+          secretKeyRef:
+            name: http-auth
+            key: username
+---
+apiVersion: logging.banzaicloud.io/v1beta1
+kind: Flow
+metadata:
+  name: flow
+spec:
+  localOutputRefs:
+  - http
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+`
+
+func TestRunFluentd(t *testing.T) {
+	secretsDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(secretsDir, "app", "http-auth"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(secretsDir, "app", "http-auth", "username"), []byte("admin"), 0o600))
+
+	var out strings.Builder
+	err := run(nil, strings.NewReader(fluentdManifests), &out, options{Namespace: "app", SecretsDir: secretsDir}, logr.Discard())
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "@id flow:app:flow:output:app:http")
+	assert.Contains(t, out.String(), "username admin")
+}
+
+func TestRunMissingSecretsDir(t *testing.T) {
+	var out strings.Builder
+	err := run(nil, strings.NewReader(fluentdManifests), &out, options{Namespace: "app"}, logr.Discard())
+	assert.ErrorContains(t, err, "secret app/http-auth is referenced, but no secrets directory is set")
+}
+
+func TestSelectLoggingMultiple(t *testing.T) {
+	manifests := `
+apiVersion: logging.banzaicloud.io/v1beta1
+kind: Logging
+metadata:
+  name: a
+---
+apiVersion: logging.banzaicloud.io/v1beta1
+kind: Logging
+metadata:
+  name: b
+`
+	objects, err := decodeManifests(strings.NewReader(manifests), logr.Discard())
+	require.NoError(t, err)
+
+	_, err = selectLogging(objects, "")
+	assert.ErrorContains(t, err, "multiple loggings found")
+
+	logging, err := selectLogging(objects, "b")
+	require.NoError(t, err)
+	assert.Equal(t, "b", logging.Name)
+}
@@ -0,0 +1,99 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	loggingmodeltypes "github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+// fileSecretLoaderFactory loads secrets from the local filesystem instead of the Kubernetes API.
+// The value of a secret key is read from <Dir>/<namespace>/<secret name>/<key>.
+type fileSecretLoaderFactory struct {
+	Dir       string
+	MountPath string
+	Logging   v1beta1.Logging
+}
+
+// Deprecated: use SecretLoaderForNamespace instead
+func (f *fileSecretLoaderFactory) OutputSecretLoaderForNamespace(namespace string) secret.SecretLoader {
+	return f.SecretLoaderForNamespace(namespace)
+}
+
+func (f *fileSecretLoaderFactory) SecretLoaderForNamespace(namespace string) secret.SecretLoader {
+	return &fileSecretLoader{
+		dir:       f.Dir,
+		namespace: namespace,
+		mountPath: f.MountPath,
+		logging:   f.Logging,
+	}
+}
+
+type fileSecretLoader struct {
+	dir       string
+	namespace string
+	mountPath string
+	logging   v1beta1.Logging
+}
+
+func (l *fileSecretLoader) Load(s *secret.Secret) (string, error) {
+	if s.Value != "" {
+		return s.Value, nil
+	}
+
+	if s.MountFrom != nil && s.MountFrom.SecretKeyRef != nil {
+		if _, err := l.read(s.MountFrom.SecretKeyRef); err != nil {
+			return "", err
+		}
+		mappedKey := fmt.Sprintf("%s-%s-%s", l.namespace, s.MountFrom.SecretKeyRef.Name, s.MountFrom.SecretKeyRef.Key)
+		return l.mountPath + "/" + mappedKey, nil
+	}
+
+	if s.ValueFrom != nil && s.ValueFrom.SecretKeyRef != nil {
+		value, err := l.read(s.ValueFrom.SecretKeyRef)
+		if err != nil {
+			return "", err
+		}
+		return string(value), nil
+	}
+
+	return "", errors.New("No secret Value or ValueFrom defined for field")
+}
+
+func (l *fileSecretLoader) GetLogKey() string {
+	if l.logging.Spec.EnableDockerParserCompatibilityForCRI {
+		return "log"
+	}
+	return loggingmodeltypes.GetLogKey()
+}
+
+func (l *fileSecretLoader) read(ref *corev1.SecretKeySelector) ([]byte, error) {
+	if l.dir == "" {
+		return nil, errors.Errorf("secret %s/%s is referenced, but no secrets directory is set", l.namespace, ref.Name)
+	}
+	value, err := os.ReadFile(filepath.Join(l.dir, l.namespace, ref.Name, ref.Key))
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to load secret", "secret", ref.Name, "namespace", l.namespace, "key", ref.Key)
+	}
+	return value, nil
+}
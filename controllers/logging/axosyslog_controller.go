@@ -17,10 +17,12 @@ package controllers
 import (
 	"context"
 
+	"github.com/cisco-open/operator-tools/pkg/reconciler"
 	"github.com/go-logr/logr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/kube-logging/logging-operator/pkg/resources/axosyslog"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 )
 
@@ -50,21 +52,36 @@ func (r *AxoSyslogReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	log.V(1).Info("Reconciling AxoSyslog")
 
-	var axosyslog v1beta1.AxoSyslog
-	if err := r.Get(ctx, req.NamespacedName, &axosyslog); err != nil {
+	var axoSyslog v1beta1.AxoSyslog
+	if err := r.Get(ctx, req.NamespacedName, &axoSyslog); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	if err := axosyslog.SetDefaults(); err != nil {
+	if err := axoSyslog.SetDefaults(); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	reconcilerOpts := reconciler.ReconcilerOpts{
+		RecreateErrorMessageCondition: reconciler.MatchImmutableErrorMessages,
+	}
+
+	result, err := axosyslog.New(r.Client, log, &axoSyslog, reconcilerOpts).Reconcile(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if result != nil {
+		return *result, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
 func SetupAxoSyslogWithManager(mgr ctrl.Manager, logger logr.Logger) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.AxoSyslog{}).
-		Named("AxoSyslogController").
-		Complete(NewAxoSyslogReconciler(mgr.GetClient(), logger))
+		Named("AxoSyslogController")
+
+	axosyslog.RegisterWatches(builder)
+
+	return builder.Complete(NewAxoSyslogReconciler(mgr.GetClient(), logger))
 }
@@ -0,0 +1,114 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/dryrun"
+	loggingv1beta1 "github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+const (
+	// DryRunConfigMapName is the name of the ConfigMap that lists the changes found in dry-run mode
+	DryRunConfigMapName = "dry-run"
+	// EventReasonDryRun is the reason of the event emitted when the changes found in dry-run mode change
+	EventReasonDryRun = "DryRun"
+)
+
+// dryRun runs the reconciliation with a client that doesn't persist anything and reports what would have been changed.
+// The events of the reconciliation are collected as well, they would report actions that never happened.
+func (r *LoggingReconciler) dryRun(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	dryRunClient := dryrun.NewClient(r.Client)
+	dryRunRecorder := dryrun.NewRecorder(r.Scheme())
+	dryRunReconciler := *r
+	dryRunReconciler.Client = dryRunClient
+	dryRunReconciler.EventRecorder = dryRunRecorder
+
+	_, reconcileErr := dryRunReconciler.reconcile(ctx, req)
+	if err := r.reportDryRun(ctx, req, dryRunClient.Actions(), dryRunRecorder.Events(), reconcileErr); err != nil {
+		return ctrl.Result{}, errors.Combine(reconcileErr, err)
+	}
+	// the cluster doesn't change, so there is nothing to requeue for until one of the watched resources changes
+	return ctrl.Result{}, reconcileErr
+}
+
+// reportDryRun stores the changes and the collected events in a ConfigMap in the control namespace and emits an event when they differ from the last report
+func (r *LoggingReconciler) reportDryRun(ctx context.Context, req ctrl.Request, actions []dryrun.Action, events []dryrun.Event, reconcileErr error) error {
+	var logging loggingv1beta1.Logging
+	if err := r.Get(ctx, req.NamespacedName, &logging); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	counts := make(map[string]int)
+	var summary strings.Builder
+	for _, action := range actions {
+		counts[action.Verb]++
+		summary.WriteString(action.String())
+		summary.WriteByte('\n')
+	}
+	for _, event := range events {
+		summary.WriteString(event.String())
+		summary.WriteByte('\n')
+	}
+	if reconcileErr != nil {
+		summary.WriteString(fmt.Sprintf("reconciliation failed: %s\n", reconcileErr))
+	}
+	details, err := json.MarshalIndent(actions, "", "  ")
+	if err != nil {
+		return errors.WrapIf(err, "failed to marshal dry-run actions")
+	}
+	eventDetails, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return errors.WrapIf(err, "failed to marshal dry-run events")
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      logging.QualifiedName(DryRunConfigMapName),
+			Namespace: logging.Spec.ControlNamespace,
+		},
+	}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Labels = map[string]string{"app.kubernetes.io/managed-by": logging.GetName()}
+		cm.Data = map[string]string{
+			"summary":      summary.String(),
+			"actions.json": string(details),
+			"events.json":  string(eventDetails),
+		}
+		return controllerutil.SetOwnerReference(&logging, cm, r.Scheme())
+	})
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to report dry-run actions", "logging", logging.GetName())
+	}
+
+	if result != controllerutil.OperationResultNone && r.EventRecorder != nil {
+		r.EventRecorder.Event(&logging, corev1.EventTypeNormal, EventReasonDryRun,
+			fmt.Sprintf("dry-run would create %d, update %d, patch %d and delete %d objects, see ConfigMap %s/%s",
+				counts[dryrun.VerbCreate], counts[dryrun.VerbUpdate], counts[dryrun.VerbPatch],
+				counts[dryrun.VerbDelete]+counts[dryrun.VerbDeleteAllOf], cm.Namespace, cm.Name))
+	}
+	return nil
+}
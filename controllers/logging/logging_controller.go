@@ -81,6 +81,8 @@ type LoggingReconciler struct {
 	client.Client
 	EventRecorder record.EventRecorder
 	Log           logr.Logger
+	// DryRun reconciles without writing to the cluster and reports the changes that would be made instead
+	DryRun bool
 }
 
 // +kubebuilder:rbac:groups=logging.banzaicloud.io,resources=loggings;fluentbitagents;flows;clusterflows;outputs;clusteroutputs;fluentdconfigs;syslogngconfigs,verbs=get;list;watch;create;update;patch;delete
@@ -105,6 +107,13 @@ type LoggingReconciler struct {
 
 // Reconcile logging resources
 func (r *LoggingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if r.DryRun {
+		return r.dryRun(ctx, req)
+	}
+	return r.reconcile(ctx, req)
+}
+
+func (r *LoggingReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("logging", req.Name)
 
 	log.V(1).Info("reconciling")
@@ -142,6 +151,10 @@ func (r *LoggingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if err := logging.SetDefaults(); err != nil {
 		return reconcile.Result{}, err
 	}
+	if r.DryRun {
+		// the config check pods are not created in dry-run mode, so their result would never arrive
+		logging.Spec.FlowConfigCheckDisabled = true
+	}
 	reconcilerOpts := reconciler.ReconcilerOpts{
 		RecreateErrorMessageCondition:                reconciler.MatchImmutableErrorMessages,
 		EnableRecreateWorkloadOnImmutableFieldChange: logging.Spec.EnableRecreateWorkloadOnImmutableFieldChange,
@@ -193,29 +206,19 @@ func (r *LoggingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		}
 	}()
 
-	reconcilers := []resources.ContextAwareComponentReconciler{
-		model.NewValidationReconciler(
-			r.Client,
-			loggingResources,
-			&secretLoaderFactory{
-				Client:  r.Client,
-				Path:    fluentd.OutputSecretPath,
-				Logging: loggingResources.Logging,
-			},
-			log.WithName("validation"),
-		),
-	}
-
 	if logging.AreMultipleAggregatorsSet() {
 		return ctrl.Result{}, errors.New("fluentd and syslogNG cannot be enabled simultaneously")
 	}
 
+	var reconcilers []resources.ContextAwareComponentReconciler
+
 	var loggingDataProvider loggingdataprovider.LoggingDataProvider
 
 	fluentdExternal, fluentdSpec := loggingResources.GetFluentd()
 	if fluentdSpec != nil {
 		logging.AggregatorLevelConfigCheck(fluentdSpec.ConfigCheck)
-		fluentdConfig, secretList, err := r.clusterConfigurationFluentd(loggingResources)
+		fluentdConfig, secretList, bufferLimits, err := r.clusterConfigurationFluentd(loggingResources)
+		loggingResources.Fluentd.BufferLimits = bufferLimits
 		if err != nil {
 			// TODO: move config generation into Fluentd reconciler
 			reconcilers = append(reconcilers, func(ctx context.Context) (*reconcile.Result, error) {
@@ -226,7 +229,7 @@ func (r *LoggingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 				log.Info("flow configuration", "config", fluentdConfig)
 			}
 
-			reconcilers = append(reconcilers, fluentd.New(r.Client, r.Log, &logging, fluentdSpec, fluentdExternal, &fluentdConfig, secretList, reconcilerOpts).Reconcile)
+			reconcilers = append(reconcilers, fluentd.New(r.Client, r.Log, r.EventRecorder, &logging, fluentdSpec, fluentdExternal, &fluentdConfig, secretList, reconcilerOpts).Reconcile)
 		}
 		loggingDataProvider = fluentd.NewDataProvider(r.Client, &logging, fluentdSpec, fluentdExternal)
 	}
@@ -234,7 +237,8 @@ func (r *LoggingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	syslogNGExternal, syslogNGSpec := loggingResources.GetSyslogNGSpec()
 	if syslogNGSpec != nil {
 		logging.AggregatorLevelConfigCheck(syslogNGSPec.ConfigCheck)
-		syslogNGConfig, secretList, err := r.clusterConfigurationSyslogNG(loggingResources)
+		syslogNGConfig, secretList, bufferLimits, err := r.clusterConfigurationSyslogNG(loggingResources)
+		loggingResources.SyslogNG.BufferLimits = bufferLimits
 		if err != nil {
 			// TODO: move config generation into Syslog-NG reconciler
 			reconcilers = append(reconcilers, func(ctx context.Context) (*reconcile.Result, error) {
@@ -245,11 +249,25 @@ func (r *LoggingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 				log.Info("flow configuration", "config", syslogNGConfig)
 			}
 
-			reconcilers = append(reconcilers, syslogng.New(r.Client, r.Log, &logging, syslogNGSpec, syslogNGExternal, syslogNGConfig, secretList, reconcilerOpts).Reconcile)
+			reconcilers = append(reconcilers, syslogng.New(r.Client, r.Log, r.EventRecorder, &logging, syslogNGSpec, syslogNGExternal, syslogNGConfig, secretList, reconcilerOpts).Reconcile)
 		}
 		loggingDataProvider = syslogng.NewDataProvider(r.Client, &logging, syslogNGExternal)
 	}
 
+	// the validation runs first, it reports the buffer limits computed while generating the configurations
+	reconcilers = append([]resources.ContextAwareComponentReconciler{
+		model.NewValidationReconciler(
+			r.Client,
+			loggingResources,
+			&secretLoaderFactory{
+				Client:  r.Client,
+				Path:    fluentd.OutputSecretPath,
+				Logging: loggingResources.Logging,
+			},
+			log.WithName("validation"),
+		),
+	}, reconcilers...)
+
 	switch len(loggingResources.Fluentbits) {
 	case 0:
 		// check for legacy definition
@@ -295,6 +313,10 @@ func (r *LoggingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			return reconcile.Result{}, err
 		}
 		if result != nil {
+			if r.DryRun {
+				// nothing has changed in the cluster, there is no point in waiting for it
+				continue
+			}
 			// short circuit if requested explicitly
 			return *result, err
 		}
@@ -420,9 +442,9 @@ func boolToFloat64(b bool) float64 {
 	return 0
 }
 
-func (r *LoggingReconciler) clusterConfigurationFluentd(resources model.LoggingResources) (string, *secret.MountSecrets, error) {
+func (r *LoggingReconciler) clusterConfigurationFluentd(resources model.LoggingResources) (string, *secret.MountSecrets, *model.BufferLimits, error) {
 	if cfg := resources.Logging.Spec.FlowConfigOverride; cfg != "" {
-		return cfg, nil, nil
+		return cfg, nil, nil, nil
 	}
 
 	slf := secretLoaderFactory{
@@ -433,8 +455,9 @@ func (r *LoggingReconciler) clusterConfigurationFluentd(resources model.LoggingR
 
 	fluentConfig, err := model.CreateSystem(resources, &slf, r.Log)
 	if err != nil {
-		return "", nil, errors.WrapIfWithDetails(err, "failed to build model", "logging", resources.Logging)
+		return "", nil, nil, errors.WrapIfWithDetails(err, "failed to build model", "logging", resources.Logging)
 	}
+	bufferLimits := model.ApplyFluentdBufferLimits(fluentConfig, resources)
 
 	output := &bytes.Buffer{}
 	renderer := render.FluentRender{
@@ -442,15 +465,15 @@ func (r *LoggingReconciler) clusterConfigurationFluentd(resources model.LoggingR
 		Indent: 2,
 	}
 	if err := renderer.Render(fluentConfig); err != nil {
-		return "", nil, errors.WrapIfWithDetails(err, "failed to render fluentd config", "logging", resources.Logging)
+		return "", nil, bufferLimits, errors.WrapIfWithDetails(err, "failed to render fluentd config", "logging", resources.Logging)
 	}
 
-	return output.String(), &slf.Secrets, nil
+	return output.String(), &slf.Secrets, bufferLimits, nil
 }
 
-func (r *LoggingReconciler) clusterConfigurationSyslogNG(resources model.LoggingResources) (string, *secret.MountSecrets, error) {
+func (r *LoggingReconciler) clusterConfigurationSyslogNG(resources model.LoggingResources) (string, *secret.MountSecrets, *model.BufferLimits, error) {
 	if cfg := resources.Logging.Spec.FlowConfigOverride; cfg != "" {
-		return cfg, nil, nil
+		return cfg, nil, nil, nil
 	}
 
 	slf := secretLoaderFactory{
@@ -460,23 +483,32 @@ func (r *LoggingReconciler) clusterConfigurationSyslogNG(resources model.Logging
 	}
 
 	_, syslogngSpec := resources.GetSyslogNGSpec()
+	bufferLimits, clusterOutputs, outputs := model.SyslogNGBufferLimits(resources)
 	in := syslogngconfig.Input{
 		Name:                resources.Logging.Name,
 		Namespace:           resources.Logging.Namespace,
-		ClusterOutputs:      resources.SyslogNG.ClusterOutputs,
-		Outputs:             resources.SyslogNG.Outputs,
+		ClusterOutputs:      clusterOutputs,
+		Outputs:             outputs,
 		ClusterFlows:        resources.SyslogNG.ClusterFlows,
 		Flows:               resources.SyslogNG.Flows,
 		SecretLoaderFactory: &slf,
 		SourcePort:          syslogng.ServicePort,
+		ReservedPorts:       syslogng.ReservedPorts(syslogngSpec),
 		SyslogNGSpec:        syslogngSpec,
 	}
+	for _, quota := range resources.LogQuotas {
+		in.Quotas = append(in.Quotas, syslogngconfig.Quota{
+			Name:             quota.Name,
+			Namespaces:       quota.Namespaces,
+			RecordsPerSecond: quota.Spec.RecordsPerSecondLimit(),
+		})
+	}
 	var b strings.Builder
 	if err := syslogngconfig.RenderConfigInto(in, &b); err != nil {
-		return "", nil, errors.WrapIfWithDetails(err, "failed to render syslog-ng config", "logging", resources.Logging)
+		return "", nil, bufferLimits, errors.WrapIfWithDetails(err, "failed to render syslog-ng config", "logging", resources.Logging)
 	}
 
-	return b.String(), &slf.Secrets, nil
+	return b.String(), &slf.Secrets, bufferLimits, nil
 }
 
 type SecretLoaderWithLogKeyProvider struct {
@@ -577,6 +609,8 @@ func SetupLoggingWithManager(mgr ctrl.Manager, logger logr.Logger) *ctrl.Builder
 			return reconcileRequestsForLoggingRef(loggingList.Items, o.Spec.LoggingRef)
 		case *loggingv1beta1.LoggingRoute:
 			return reconcileRequestsForLoggingRef(loggingList.Items, o.Spec.Source)
+		case *loggingv1beta1.LogQuota:
+			return reconcileRequestsForLoggingRef(loggingList.Items, o.Spec.LoggingRef)
 		case *loggingv1beta1.FluentdConfig:
 			return reconcileRequestsForMatchingControlNamespace(loggingList.Items, o.Namespace)
 		case *loggingv1beta1.SyslogNGConfig:
@@ -631,6 +665,7 @@ func SetupLoggingWithManager(mgr ctrl.Manager, logger logr.Logger) *ctrl.Builder
 		Watches(&loggingv1beta1.SyslogNGFlow{}, requestMapper).
 		Watches(&corev1.Secret{}, requestMapper).
 		Watches(&loggingv1beta1.LoggingRoute{}, requestMapper).
+		Watches(&loggingv1beta1.LogQuota{}, requestMapper).
 		Watches(&loggingv1beta1.FluentdConfig{}, requestMapper).
 		Watches(&loggingv1beta1.SyslogNGConfig{}, requestMapper)
 
@@ -0,0 +1,126 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/fluentd"
+	"github.com/kube-logging/logging-operator/pkg/resources/logquota"
+	"github.com/kube-logging/logging-operator/pkg/resources/model"
+	"github.com/kube-logging/logging-operator/pkg/resources/syslogng"
+	loggingv1beta1 "github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+// LogQuotaUsageInterval is the time between two updates of the usage of a quota
+const LogQuotaUsageInterval = time.Minute
+
+func NewLogQuotaReconciler(client client.Client, log logr.Logger) *LogQuotaReconciler {
+	return &LogQuotaReconciler{
+		Client: client,
+		Log:    log,
+	}
+}
+
+// LogQuotaReconciler reports the consumption of the namespaces limited by a LogQuota.
+// The namespaces of the quota are resolved by the Logging reconciler.
+type LogQuotaReconciler struct {
+	client.Client
+	Log        logr.Logger
+	HTTPClient *http.Client
+}
+
+// +kubebuilder:rbac:groups=logging.banzaicloud.io,resources=logquotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=logging.banzaicloud.io,resources=logquotas/status,verbs=get;update;patch
+
+// Reconcile updates the usage of the quota from the metrics of the aggregators of the logging
+func (r *LogQuotaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var quota loggingv1beta1.LogQuota
+	if err := r.Get(ctx, req.NamespacedName, &quota); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	sources, err := r.quotaSources(ctx, quota.Spec.LoggingRef)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(sources) == 0 {
+		// usage is only available with metrics enabled on the aggregator
+		return ctrl.Result{}, nil
+	}
+
+	usage, err := logquota.Collect(ctx, r.Client, r.HTTPClient, sources...)
+	if err != nil {
+		// partial usage is still worth reporting
+		r.Log.Error(err, "failed to collect quota usage", "quota", quota.Name)
+	}
+
+	patch := client.MergeFrom(quota.DeepCopy())
+	logquota.UpdateStatus(&quota.Status, usage, metav1.Now())
+	if err := r.Status().Patch(ctx, &quota, patch); err != nil {
+		return ctrl.Result{}, errors.WrapIfWithDetails(err, "failed to update quota usage", "quota", quota.Name)
+	}
+
+	return ctrl.Result{RequeueAfter: LogQuotaUsageInterval}, nil
+}
+
+// quotaSources returns the aggregators of the loggings with the loggingRef that expose metrics
+func (r *LogQuotaReconciler) quotaSources(ctx context.Context, loggingRef string) ([]logquota.Source, error) {
+	var loggingList loggingv1beta1.LoggingList
+	if err := r.List(ctx, &loggingList); err != nil {
+		return nil, errors.WrapIf(err, "listing loggings")
+	}
+
+	repo := model.NewLoggingResourceRepository(r.Client, r.Log)
+	var sources []logquota.Source
+	for _, logging := range loggingList.Items {
+		if logging.Spec.LoggingRef != loggingRef {
+			continue
+		}
+		resources := model.LoggingResources{Logging: logging}
+		var err error
+		if resources.Fluentd.Configuration, _, err = repo.FluentdConfigFor(ctx, logging); err != nil {
+			return nil, err
+		}
+		if resources.SyslogNG.Configuration, _, err = repo.SyslogNGConfigFor(ctx, logging); err != nil {
+			return nil, err
+		}
+
+		_, fluentdSpec := resources.GetFluentd()
+		if source := fluentd.QuotaSource(&logging, fluentdSpec); source != nil {
+			sources = append(sources, *source)
+		}
+		_, syslogNGSpec := resources.GetSyslogNGSpec()
+		if source := syslogng.QuotaSource(&logging, syslogNGSpec); source != nil {
+			sources = append(sources, *source)
+		}
+	}
+	return sources, nil
+}
+
+func SetupLogQuotaWithManager(mgr ctrl.Manager, logger logr.Logger) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&loggingv1beta1.LogQuota{}).
+		Complete(NewLogQuotaReconciler(mgr.GetClient(), logger))
+}
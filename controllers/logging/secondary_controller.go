@@ -0,0 +1,126 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/fluentd"
+	"github.com/kube-logging/logging-operator/pkg/resources/model"
+	"github.com/kube-logging/logging-operator/pkg/resources/secondary"
+	loggingv1beta1 "github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+// OutputSecondaryInterval is the time between two updates of the secondary chunks of the outputs
+const OutputSecondaryInterval = time.Minute
+
+func NewOutputSecondaryReconciler(client client.Client, log logr.Logger) *OutputSecondaryReconciler {
+	return &OutputSecondaryReconciler{
+		Client: client,
+		Log:    log,
+	}
+}
+
+// OutputSecondaryReconciler reports the number of chunks written to the secondaries of the outputs of a Logging.
+// The secondaries are validated by the Logging reconciler.
+type OutputSecondaryReconciler struct {
+	client.Client
+	Log        logr.Logger
+	HTTPClient *http.Client
+}
+
+// +kubebuilder:rbac:groups=logging.banzaicloud.io,resources=outputs;clusteroutputs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=logging.banzaicloud.io,resources=outputs/status;clusteroutputs/status,verbs=get;update;patch
+
+// Reconcile updates the secondary chunks of the outputs from the metrics of the fluentd aggregator of the logging
+func (r *OutputSecondaryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var logging loggingv1beta1.Logging
+	if err := r.Get(ctx, req.NamespacedName, &logging); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	resources, err := model.NewLoggingResourceRepository(r.Client, r.Log).LoggingResourcesFor(ctx, logging)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	_, fluentdSpec := resources.GetFluentd()
+	source := fluentd.SecondarySource(&logging, fluentdSpec)
+	if source == nil || !hasSecondary(resources) {
+		// secondary chunks are only available with metrics enabled on the aggregator
+		return ctrl.Result{}, nil
+	}
+
+	chunks, err := secondary.Collect(ctx, r.Client, r.HTTPClient, *source)
+	if err != nil {
+		// partial counts are still worth reporting
+		r.Log.Error(err, "failed to collect secondary chunks", "logging", logging.Name)
+	}
+
+	var errs error
+	for i := range resources.Fluentd.ClusterOutputs {
+		output := &resources.Fluentd.ClusterOutputs[i]
+		key := model.OutputKey{ClusterOutput: true, Namespace: output.Namespace, Name: output.Name}
+		errs = errors.Append(errs, r.updateStatus(ctx, output, &output.Status, output.Spec.Secondary, chunks[key]))
+	}
+	for i := range resources.Fluentd.Outputs {
+		output := &resources.Fluentd.Outputs[i]
+		key := model.OutputKey{Namespace: output.Namespace, Name: output.Name}
+		errs = errors.Append(errs, r.updateStatus(ctx, output, &output.Status, output.Spec.Secondary, chunks[key]))
+	}
+	if errs != nil {
+		return ctrl.Result{}, errs
+	}
+
+	return ctrl.Result{RequeueAfter: OutputSecondaryInterval}, nil
+}
+
+// updateStatus patches the secondary chunks of the output if they have changed
+func (r *OutputSecondaryReconciler) updateStatus(ctx context.Context, obj client.Object, status *loggingv1beta1.OutputStatus, spec *loggingv1beta1.OutputSecondary, chunks float64) error {
+	if spec == nil || status.SecondaryChunks == int64(chunks) {
+		return nil
+	}
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	status.SecondaryChunks = int64(chunks)
+	return errors.WrapIfWithDetails(r.Status().Patch(ctx, obj, patch), "failed to update secondary chunks", "output", obj.GetName(), "namespace", obj.GetNamespace())
+}
+
+func hasSecondary(resources model.LoggingResources) bool {
+	for _, output := range resources.Fluentd.ClusterOutputs {
+		if output.Spec.Secondary != nil {
+			return true
+		}
+	}
+	for _, output := range resources.Fluentd.Outputs {
+		if output.Spec.Secondary != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func SetupOutputSecondaryWithManager(mgr ctrl.Manager, logger logr.Logger) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("output-secondary").
+		For(&loggingv1beta1.Logging{}).
+		Complete(NewOutputSecondaryReconciler(mgr.GetClient(), logger))
+}
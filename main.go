@@ -59,6 +59,7 @@ import (
 	loggingv1beta1 "github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
 	"github.com/kube-logging/logging-operator/pkg/webhook/podhandler"
+	"github.com/kube-logging/logging-operator/pkg/webhook/validation"
 	telemetryv1alpha1 "github.com/kube-logging/telemetry-controller/api/telemetry/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
@@ -93,6 +94,8 @@ func main() {
 	var enableTelemetryControllerRoute bool
 	var klogLevel int
 	var syncPeriod string
+	var validatingWebhookMode string
+	var dryRun bool
 
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
@@ -108,6 +111,8 @@ func main() {
 	flag.BoolVar(&finalizerCleanup, "finalizer-cleanup", false, "Remove finalizers from Logging resources during operator shutdown, useful for Helm uninstallation")
 	flag.BoolVar(&enableTelemetryControllerRoute, "enable-telemetry-controller-route", false, "Enable the Telemetry Controller route for Logging resources")
 	flag.StringVar(&syncPeriod, "sync-period", "", "SyncPeriod determines the minimum frequency at which watched resources are reconciled. Defaults to 10 hours. Parsed using time.ParseDuration.")
+	flag.StringVar(&validatingWebhookMode, "validating-webhook-mode", "", "Validate flows and outputs on admission when webhooks are enabled: \"enforce\" rejects invalid objects, but only warns about references to objects that don't exist yet, \"warn\" only returns admission warnings. Disabled by default.")
+	flag.BoolVar(&dryRun, "dry-run", false, "Reconcile Logging resources without writing to the cluster, report the changes that would be made in events and a ConfigMap instead. Other controllers are not started.")
 	flag.Parse()
 
 	ctx := context.Background()
@@ -146,6 +151,10 @@ func main() {
 		LeaderElection:   enableLeaderElection,
 		LeaderElectionID: "logging-operator." + loggingv1beta1.GroupVersion.Group,
 	}
+	if dryRun {
+		// a dry-run instance runs next to the operator that applies the changes
+		mgrOptions.LeaderElectionID = "logging-operator-dry-run." + loggingv1beta1.GroupVersion.Group
+	}
 
 	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
 		webhookServerOptions := webhook.Options{
@@ -194,7 +203,59 @@ func main() {
 	}
 
 	loggingReconciler := controllers.NewLoggingReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("logging-operator"), ctrl.Log.WithName("logging"))
+	loggingReconciler.DryRun = dryRun
+
+	if err := controllers.SetupLoggingWithManager(mgr, ctrl.Log.WithName("manager")).Complete(loggingReconciler); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Logging")
+		os.Exit(1)
+	}
+
+	if dryRun {
+		setupLog.Info("running in dry-run mode, only the Logging controller is started")
+	} else {
+		setupControllers(mgr, enableTelemetryControllerRoute)
+	}
+
+	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
+		if err := loggingv1beta1.SetupWebhookWithManager(mgr, loggingv1beta1.APITypes()...); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "v1beta1.logging")
+			os.Exit(1)
+		}
+		if err := loggingv1beta1.SetupWebhookWithManager(mgr, loggingv1alpha1.APITypes()...); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "v1alpha1.logging")
+			os.Exit(1)
+		}
+		mode, err := validation.ParseMode(validatingWebhookMode)
+		if err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "validation")
+			os.Exit(1)
+		}
+		if err := validation.SetupWithManager(mgr, mode); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "validation")
+			os.Exit(1)
+		}
+
+		// Webhook server registration
+		setupLog.Info("Setting up webhook server...")
+		webhookServer := mgr.GetWebhookServer()
+
+		setupLog.Info("Registering webhooks...")
+		webhookHandler := podhandler.NewPodHandler(ctrl.Log.WithName("webhook-tailer"))
+		webhookHandler.Decoder = admission.NewDecoder(mgr.GetScheme())
+		webhookServer.Register(config.TailerWebhook.ServerPath, &webhook.Admission{Handler: webhookHandler})
+	}
+
+	// +kubebuilder:scaffold:builder
+	setupLog.Info("starting manager")
+
+	if err := mgr.Start(setupSignalHandler(mgr, finalizerCleanup && !dryRun)); err != nil {
+		setupLog.Error(err, "problem running manager")
+		os.Exit(1)
+	}
+}
 
+// setupControllers sets up the controllers that run next to the Logging controller
+func setupControllers(mgr ctrl.Manager, enableTelemetryControllerRoute bool) {
 	if err := (&extensionsControllers.EventTailerReconciler{
 		Client: mgr.GetClient(),
 		Log:    ctrl.Log.WithName("event-tailer"),
@@ -212,13 +273,18 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := controllers.SetupLoggingWithManager(mgr, ctrl.Log.WithName("manager")).Complete(loggingReconciler); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Logging")
+	if err := controllers.SetupLoggingRouteWithManager(mgr, ctrl.Log.WithName("logging-route")); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "LoggingRoute")
 		os.Exit(1)
 	}
 
-	if err := controllers.SetupLoggingRouteWithManager(mgr, ctrl.Log.WithName("logging-route")); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "LoggingRoute")
+	if err := controllers.SetupLogQuotaWithManager(mgr, ctrl.Log.WithName("log-quota")); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "LogQuota")
+		os.Exit(1)
+	}
+
+	if err := controllers.SetupOutputSecondaryWithManager(mgr, ctrl.Log.WithName("output-secondary")); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OutputSecondary")
 		os.Exit(1)
 	}
 
@@ -233,34 +299,6 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "AxoSyslog")
 		os.Exit(1)
 	}
-
-	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
-		if err := loggingv1beta1.SetupWebhookWithManager(mgr, loggingv1beta1.APITypes()...); err != nil {
-			setupLog.Error(err, "unable to create webhook", "webhook", "v1beta1.logging")
-			os.Exit(1)
-		}
-		if err := loggingv1beta1.SetupWebhookWithManager(mgr, loggingv1alpha1.APITypes()...); err != nil {
-			setupLog.Error(err, "unable to create webhook", "webhook", "v1alpha1.logging")
-			os.Exit(1)
-		}
-
-		// Webhook server registration
-		setupLog.Info("Setting up webhook server...")
-		webhookServer := mgr.GetWebhookServer()
-
-		setupLog.Info("Registering webhooks...")
-		webhookHandler := podhandler.NewPodHandler(ctrl.Log.WithName("webhook-tailer"))
-		webhookHandler.Decoder = admission.NewDecoder(mgr.GetScheme())
-		webhookServer.Register(config.TailerWebhook.ServerPath, &webhook.Admission{Handler: webhookHandler})
-	}
-
-	// +kubebuilder:scaffold:builder
-	setupLog.Info("starting manager")
-
-	if err := mgr.Start(setupSignalHandler(mgr, finalizerCleanup)); err != nil {
-		setupLog.Error(err, "problem running manager")
-		os.Exit(1)
-	}
 }
 
 // Extends sigs.k8s.io/controller-runtime@v0.17.2/pkg/manager/signals/signal.go with
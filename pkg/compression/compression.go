@@ -17,6 +17,7 @@ package compression
 import (
 	"bytes"
 	"compress/gzip"
+	"io"
 
 	"github.com/go-logr/logr"
 )
@@ -34,3 +35,17 @@ func CompressString(str string, log logr.Logger) []byte {
 
 	return b.Bytes()
 }
+
+func DecompressString(data []byte) (string, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	defer gz.Close()
+
+	b, err := io.ReadAll(gz)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
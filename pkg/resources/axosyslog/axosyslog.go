@@ -0,0 +1,140 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axosyslog
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/reconciler"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-logging/logging-operator/pkg/resources"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+const (
+	ServiceName        = "axosyslog"
+	OTLPPort           = 4317
+	StatefulSetName    = "axosyslog"
+	ContainerName      = "axosyslog"
+	configSecretName   = "axosyslog"
+	configKey          = "axosyslog.conf"
+	serviceAccountName = "axosyslog"
+	socketVolumeName   = "socket"
+	socketPath         = "/tmp/syslog-ng/syslog-ng.ctl"
+	configDir          = "/etc/syslog-ng/config"
+	configVolumeName   = "config"
+	otlpPortName       = "otlp-grpc"
+)
+
+// Reconciler holds info what resource to reconcile
+type Reconciler struct {
+	AxoSyslog *v1beta1.AxoSyslog
+	*reconciler.GenericResourceReconciler
+	config   string
+	problems []string
+}
+
+func New(
+	client client.Client,
+	log logr.Logger,
+	axoSyslog *v1beta1.AxoSyslog,
+	opts reconciler.ReconcilerOpts,
+) *Reconciler {
+	return &Reconciler{
+		AxoSyslog:                 axoSyslog,
+		GenericResourceReconciler: reconciler.NewGenericReconciler(client, log, opts),
+	}
+}
+
+// Reconcile reconciles the AxoSyslog resource
+func (r *Reconciler) Reconcile(ctx context.Context) (result *reconcile.Result, err error) {
+	patchBase := client.MergeFrom(r.AxoSyslog.DeepCopy())
+
+	r.config, r.problems = RenderConfig(r.AxoSyslog.Spec)
+
+	// the status is written on every return, so it does not go stale when the reconcile stops early
+	defer func() {
+		if statusErr := r.updateStatus(ctx, patchBase); statusErr != nil {
+			err = errors.Append(err, statusErr)
+		}
+	}()
+
+	for _, res := range []resources.Resource{
+		r.serviceAccount,
+		r.configSecret,
+		r.statefulset,
+		r.service,
+		r.headlessService,
+	} {
+		o, state, err := res()
+		if err != nil {
+			return nil, errors.WrapIf(err, "failed to create desired object")
+		}
+		if o == nil {
+			return nil, errors.Errorf("Reconcile error! Resource %#v returns with nil object", res)
+		}
+		result, err := r.ReconcileResource(o, state)
+		if err != nil {
+			return nil, errors.WrapIf(err, "failed to reconcile resource")
+		}
+		if result != nil {
+			return result, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// updateStatus reports the sources and the configuration problems on the AxoSyslog resource
+func (r *Reconciler) updateStatus(ctx context.Context, patchBase client.Patch) error {
+	r.AxoSyslog.Status.Sources = []v1beta1.Source{
+		{
+			OTLP: &v1beta1.OTLPSource{
+				Endpoint: r.otlpEndpoint(),
+			},
+		},
+	}
+	r.AxoSyslog.Status.Problems = r.problems
+	r.AxoSyslog.Status.ProblemsCount = len(r.problems)
+
+	if err := r.Client.Status().Patch(ctx, r.AxoSyslog, patchBase); err != nil {
+		return errors.WrapWithDetails(err, "failed to patch status", "axosyslog", r.AxoSyslog)
+	}
+	return nil
+}
+
+func (r *Reconciler) otlpEndpoint() string {
+	return fmt.Sprintf("%s.%s.svc:%d", r.AxoSyslog.QualifiedName(ServiceName), r.AxoSyslog.Namespace, OTLPPort)
+}
+
+func (r *Reconciler) getServiceAccountName() string {
+	return r.AxoSyslog.QualifiedName(serviceAccountName)
+}
+
+func RegisterWatches(builder *builder.Builder) *builder.Builder {
+	return builder.
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.Service{}).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&corev1.ServiceAccount{})
+}
@@ -0,0 +1,92 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axosyslog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+const (
+	configVersion = "current"
+	sourceName    = "otlp_input"
+	indent        = "    "
+)
+
+// RenderConfig renders the AxoSyslog configuration for the given spec.
+// Destinations and log paths that cannot be rendered are skipped and reported as problems.
+func RenderConfig(spec v1beta1.AxoSyslogSpec) (string, []string) {
+	var problems []string
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "@version: %s\n\n", configVersion)
+	fmt.Fprintf(&b, "@include %q\n\n", "scl.conf")
+	fmt.Fprintf(&b, "source %q {\n%sopentelemetry(port(%d));\n};\n", sourceName, indent, OTLPPort)
+
+	destinations := make(map[string]struct{}, len(spec.Destinations))
+	for i, d := range spec.Destinations {
+		if d.Name == "" {
+			problems = append(problems, fmt.Sprintf("destination #%d has no name", i))
+			continue
+		}
+		if _, ok := destinations[d.Name]; ok {
+			problems = append(problems, fmt.Sprintf("destination %q is defined multiple times", d.Name))
+			continue
+		}
+		cfg := trimStatement(d.Config)
+		if cfg == "" {
+			problems = append(problems, fmt.Sprintf("destination %q has no config", d.Name))
+			continue
+		}
+		destinations[d.Name] = struct{}{}
+		fmt.Fprintf(&b, "\ndestination %q {\n%s;\n};\n", d.Name, indentLines(cfg, 1))
+	}
+
+	for i, lp := range spec.LogPaths {
+		if lp.Destination != "" {
+			if _, ok := destinations[lp.Destination]; !ok {
+				problems = append(problems, fmt.Sprintf("log path #%d references undefined destination %q", i, lp.Destination))
+				continue
+			}
+		}
+		fmt.Fprintf(&b, "\nlog {\n%ssource(%q);\n", indent, sourceName)
+		if filterx := trimStatement(lp.Filterx); filterx != "" {
+			fmt.Fprintf(&b, "%sfilterx {\n%s;\n%s};\n", indent, indentLines(filterx, 2), indent)
+		}
+		if lp.Destination != "" {
+			fmt.Fprintf(&b, "%sdestination(%q);\n", indent, lp.Destination)
+		}
+		b.WriteString("};\n")
+	}
+
+	return b.String(), problems
+}
+
+func trimStatement(s string) string {
+	return strings.TrimSuffix(strings.TrimSpace(s), ";")
+}
+
+func indentLines(s string, level int) string {
+	prefix := strings.Repeat(indent, level)
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		if strings.TrimSpace(l) != "" {
+			lines[i] = prefix + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,74 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axosyslog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func TestRenderConfig(t *testing.T) {
+	spec := v1beta1.AxoSyslogSpec{
+		LogPaths: []v1beta1.LogPath{
+			{
+				Filterx:     "${json.level} = \"info\";\nunset(${json.debug});",
+				Destination: "http",
+			},
+			{
+				Destination: "missing",
+			},
+		},
+		Destinations: []v1beta1.Destination{
+			{
+				Name:   "http",
+				Config: `http(url("http://example.com"));`,
+			},
+			{
+				Name: "empty",
+			},
+		},
+	}
+
+	config, problems := RenderConfig(spec)
+
+	assert.Equal(t, `@version: current
+
+@include "scl.conf"
+
+source "otlp_input" {
+    opentelemetry(port(4317));
+};
+
+destination "http" {
+    http(url("http://example.com"));
+};
+
+log {
+    source("otlp_input");
+    filterx {
+        ${json.level} = "info";
+        unset(${json.debug});
+    };
+    destination("http");
+};
+`, config)
+	assert.Equal(t, []string{
+		`destination "empty" has no config`,
+		`log path #1 references undefined destination "missing"`,
+	}, problems)
+}
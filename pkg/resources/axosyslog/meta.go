@@ -0,0 +1,49 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axosyslog
+
+import (
+	util "github.com/cisco-open/operator-tools/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+// AxoSyslogObjectMeta creates an objectMeta for resource axosyslog
+func (r *Reconciler) AxoSyslogObjectMeta(name, component string) metav1.ObjectMeta {
+	o := metav1.ObjectMeta{
+		Name:      r.AxoSyslog.QualifiedName(name),
+		Namespace: r.AxoSyslog.Namespace,
+		Labels:    r.getLabels(component),
+		OwnerReferences: []metav1.OwnerReference{
+			{
+				APIVersion: v1beta1.GroupVersion.String(),
+				Kind:       "AxoSyslog",
+				Name:       r.AxoSyslog.Name,
+				UID:        r.AxoSyslog.UID,
+				Controller: util.BoolPointer(true),
+			},
+		},
+	}
+	return *o.DeepCopy()
+}
+
+func (r *Reconciler) getLabels(component string) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "axosyslog",
+		"app.kubernetes.io/instance":  r.AxoSyslog.Name,
+		"app.kubernetes.io/component": component,
+	}
+}
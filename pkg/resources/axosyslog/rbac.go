@@ -0,0 +1,31 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axosyslog
+
+import (
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/merge"
+	"github.com/cisco-open/operator-tools/pkg/reconciler"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func (r *Reconciler) serviceAccount() (runtime.Object, reconciler.DesiredState, error) {
+	account := &corev1.ServiceAccount{
+		ObjectMeta: r.AxoSyslogObjectMeta(serviceAccountName, ComponentAxoSyslog),
+	}
+	err := merge.Merge(account, r.AxoSyslog.Spec.ServiceAccountOverrides)
+	return account, reconciler.StatePresent, errors.WrapIf(err, "unable to merge overrides to base object")
+}
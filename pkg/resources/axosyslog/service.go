@@ -0,0 +1,69 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axosyslog
+
+import (
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/reconciler"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func (r *Reconciler) service() (runtime.Object, reconciler.DesiredState, error) {
+	desired := &corev1.Service{
+		ObjectMeta: r.AxoSyslogObjectMeta(ServiceName, ComponentAxoSyslog),
+		Spec: corev1.ServiceSpec{
+			Ports:    servicePorts(),
+			Selector: r.getLabels(ComponentAxoSyslog),
+			Type:     corev1.ServiceTypeClusterIP,
+		},
+	}
+
+	beforeUpdateHook := reconciler.DesiredStateHook(func(current runtime.Object) error {
+		if s, ok := current.(*corev1.Service); ok {
+			desired.Spec.ClusterIP = s.Spec.ClusterIP
+		} else {
+			return errors.Errorf("failed to cast service object %+v", current)
+		}
+		return nil
+	})
+
+	return desired, beforeUpdateHook, nil
+}
+
+func (r *Reconciler) headlessService() (runtime.Object, reconciler.DesiredState, error) {
+	desired := &corev1.Service{
+		ObjectMeta: r.AxoSyslogObjectMeta(ServiceName+"-headless", ComponentAxoSyslog),
+		Spec: corev1.ServiceSpec{
+			Ports:     servicePorts(),
+			Selector:  r.getLabels(ComponentAxoSyslog),
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: corev1.ClusterIPNone,
+		},
+	}
+	return desired, reconciler.StatePresent, nil
+}
+
+func servicePorts() []corev1.ServicePort {
+	return []corev1.ServicePort{
+		{
+			Name:       otlpPortName,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       OTLPPort,
+			TargetPort: intstr.FromString(otlpPortName),
+		},
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axosyslog
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/merge"
+	"github.com/cisco-open/operator-tools/pkg/reconciler"
+	util "github.com/cisco-open/operator-tools/pkg/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func (r *Reconciler) statefulset() (runtime.Object, reconciler.DesiredState, error) {
+	desired := &appsv1.StatefulSet{
+		ObjectMeta: r.AxoSyslogObjectMeta(StatefulSetName, ComponentAxoSyslog),
+		Spec: appsv1.StatefulSetSpec{
+			PodManagementPolicy: appsv1.OrderedReadyPodManagement,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: r.getLabels(ComponentAxoSyslog),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: r.getLabels(ComponentAxoSyslog),
+					Annotations: map[string]string{
+						"fluentbit.io/exclude": "true",
+					},
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: r.getServiceAccountName(),
+					Containers: []corev1.Container{
+						r.axoSyslogContainer(),
+						r.configReloadContainer(),
+					},
+					Volumes: r.generateVolumes(),
+					SecurityContext: &corev1.PodSecurityContext{
+						FSGroup: util.IntPointer64(101),
+					},
+				},
+			},
+			ServiceName: r.AxoSyslog.QualifiedName(ServiceName + "-headless"),
+		},
+	}
+
+	err := merge.Merge(desired, r.AxoSyslog.Spec.StatefulSetOverrides)
+	if err != nil {
+		return desired, reconciler.StatePresent, errors.WrapIf(err, "unable to merge overrides to base object")
+	}
+
+	return desired, reconciler.StatePresent, nil
+}
+
+func (r *Reconciler) axoSyslogContainer() corev1.Container {
+	return corev1.Container{
+		Name:            ContainerName,
+		Image:           r.AxoSyslog.Spec.Image.RepositoryWithTag(),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Ports: []corev1.ContainerPort{{
+			Name:          otlpPortName,
+			ContainerPort: OTLPPort,
+			Protocol:      corev1.ProtocolTCP,
+		}},
+		Args: []string{
+			"--cfgfile=" + configDir + "/" + configKey,
+			"--control=" + socketPath,
+			"--no-caps",
+			"-Fe",
+		},
+		VolumeMounts: generateVolumeMounts(),
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("400M"),
+				corev1.ResourceCPU:    resource.MustParse("1000m"),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("100M"),
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+			},
+		},
+		LivenessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{"/usr/sbin/syslog-ng-ctl", "--control=" + socketPath, "query", "get", "global.sdata_updates.processed"},
+				},
+			},
+			InitialDelaySeconds: 30,
+			PeriodSeconds:       10,
+			FailureThreshold:    3,
+		},
+	}
+}
+
+func (r *Reconciler) configReloadContainer() corev1.Container {
+	return corev1.Container{
+		Name:            "config-reloader",
+		Image:           r.AxoSyslog.Spec.ConfigReloadImage.RepositoryWithTag(),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Args: []string{
+			"-cfgjson",
+			generateConfigReloaderConfig(configDir),
+		},
+		VolumeMounts: generateVolumeMounts(),
+	}
+}
+
+func generateVolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      configVolumeName,
+			MountPath: configDir,
+		},
+		{
+			Name:      socketVolumeName,
+			MountPath: filepath.Dir(socketPath),
+		},
+	}
+}
+
+func (r *Reconciler) generateVolumes() []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name: configVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: r.AxoSyslog.QualifiedName(configSecretName),
+				},
+			},
+		},
+		{
+			Name: socketVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumDefault,
+				},
+			},
+		},
+	}
+}
+
+func generateConfigReloaderConfig(configDir string) string {
+	return fmt.Sprintf(`
+	{
+		"events": {
+		  "onFileCreate": {
+			"%s" : [
+			  {
+				"exec": {
+				  "key": "info",
+				  "command": "echo $(date) config secret changed!"
+				}
+			  },
+			  {
+				"exec": {
+					"key": "reload",
+					"command": "echo RELOAD | socat - UNIX-CONNECT:%s"
+				}
+			  }
+			]
+		  }
+		}
+	  }
+	`, filepath.Join(configDir, "..data"), socketPath)
+}
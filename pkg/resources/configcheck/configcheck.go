@@ -16,15 +16,32 @@ package configcheck
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strings"
 
 	"emperror.dev/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 )
 
 const HashLabel = "logging.banzaicloud.io/config-hash"
 
+// EventReasonFailed is the reason of the event emitted when a config check fails
+const EventReasonFailed = "ConfigCheckFailed"
+
+var (
+	// syslog-ng marks the offending line of the configuration with an arrow, e.g. `25---->     http(`
+	syslogNGErrorLine = regexp.MustCompile(`^\s*\d+---->\s*(.*)$`)
+	// fluentd reports configuration errors as `config error file="..." error_class=... error="..."`
+	fluentdError = regexp.MustCompile(`error="((?:[^"\\]|\\.)*)"`)
+)
+
 func WithHashLabel(accessor v1.Object, hash string) {
 	l := accessor.GetLabels()
 	if l == nil {
@@ -99,3 +116,106 @@ func (c *ConfigCheckCleaner) PodCleanup(ctx context.Context, hash string) (multi
 
 	return
 }
+
+// SetCondition sets the ConfigCheckPassed condition of the logging based on the config check result of the current config hash
+func SetCondition(logging *v1beta1.Logging, hash string) {
+	condition := v1.Condition{
+		Type:               v1beta1.ConditionConfigCheckPassed,
+		ObservedGeneration: logging.Generation,
+	}
+
+	valid, checked := logging.Status.ConfigCheckResults[hash]
+	switch {
+	case logging.Spec.FlowConfigCheckDisabled:
+		condition.Status = v1.ConditionTrue
+		condition.Reason = v1beta1.ReasonConfigCheckDisabled
+		condition.Message = "config check is disabled"
+	case !checked:
+		condition.Status = v1.ConditionUnknown
+		condition.Reason = v1beta1.ReasonConfigCheckPending
+		condition.Message = "waiting for the config check result"
+	case !valid:
+		condition.Status = v1.ConditionFalse
+		condition.Reason = v1beta1.ReasonConfigCheckFailed
+		condition.Message = fmt.Sprintf("configuration with checksum %s has failed", hash)
+		if directive := FailingDirective(logging.Status.ConfigCheckFailures[hash]); directive != "" {
+			condition.Message += fmt.Sprintf(": %s", directive)
+		}
+	default:
+		condition.Status = v1.ConditionTrue
+		condition.Reason = v1beta1.ReasonConfigCheckPassed
+		condition.Message = "configuration has passed the config check"
+	}
+
+	meta.SetStatusCondition(&logging.Status.Conditions, condition)
+}
+
+// RemoveCondition removes the ConfigCheckPassed condition of a logging that has no aggregator to check the config of
+func RemoveCondition(logging *v1beta1.Logging) {
+	meta.RemoveStatusCondition(&logging.Status.Conditions, v1beta1.ConditionConfigCheckPassed)
+}
+
+// FailureOutput returns the output of the failed containers of a config check pod.
+// The check containers fall back to the tail of their logs if they don't write a termination message.
+func FailureOutput(pod *corev1.Pod) string {
+	var output []string
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if t := status.State.Terminated; t != nil && t.ExitCode != 0 {
+				if msg := strings.TrimSpace(t.Message); msg != "" {
+					output = append(output, msg)
+				}
+			}
+		}
+	}
+	return strings.Join(output, "\n")
+}
+
+// FailingDirective extracts the configuration line or directive that failed the config check from its output
+func FailingDirective(output string) string {
+	lines := strings.Split(output, "\n")
+	for _, line := range lines {
+		if m := syslogNGErrorLine.FindStringSubmatch(line); m != nil {
+			return strings.TrimSpace(m[1])
+		}
+	}
+	for _, line := range lines {
+		if m := fluentdError.FindStringSubmatch(line); m != nil {
+			return strings.ReplaceAll(m[1], `\"`, `"`)
+		}
+	}
+	for _, line := range lines {
+		if strings.Contains(strings.ToLower(line), "error") {
+			return strings.TrimSpace(line)
+		}
+	}
+	return ""
+}
+
+// RecordFailure stores the output of a failed config check in the logging status and emits a warning event about it
+func RecordFailure(recorder record.EventRecorder, logging *v1beta1.Logging, hash string, output string) {
+	if output != "" {
+		if logging.Status.ConfigCheckFailures == nil {
+			logging.Status.ConfigCheckFailures = make(map[string]string)
+		}
+		logging.Status.ConfigCheckFailures[hash] = output
+	}
+
+	if recorder == nil {
+		return
+	}
+	msg := fmt.Sprintf("configuration with checksum %s has failed", hash)
+	if directive := FailingDirective(output); directive != "" {
+		msg += fmt.Sprintf(", failing directive: %s", directive)
+	}
+	recorder.Event(logging, corev1.EventTypeWarning, EventReasonFailed, msg)
+}
+
+// PruneFailures removes the stored config check outputs that have no corresponding config check result
+func PruneFailures(logging *v1beta1.Logging) {
+	for hash := range logging.Status.ConfigCheckFailures {
+		if _, ok := logging.Status.ConfigCheckResults[hash]; !ok {
+			delete(logging.Status.ConfigCheckFailures, hash)
+		}
+	}
+}
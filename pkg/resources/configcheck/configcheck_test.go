@@ -0,0 +1,126 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configcheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func TestFailingDirective(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{
+			name: "syslog-ng",
+			output: `Error parsing destination statement, destination plugin htp not found in /etc/syslog-ng/config/syslog-ng.conf:25:5-25:8:
+20      };
+25---->     htp(url("http://example.com"));
+26      };`,
+			want: `htp(url("http://example.com"));`,
+		},
+		{
+			name:   "fluentd",
+			output: `2025-01-01 00:00:00 +0000 [error]: config error file="/fluentd/etc/fluent.conf" error_class=Fluent::ConfigError error="Unknown output plugin 'htp'. Run 'gem search -rd fluent-plugin' to find plugins"`,
+			want:   `Unknown output plugin 'htp'. Run 'gem search -rd fluent-plugin' to find plugins`,
+		},
+		{
+			name:   "generic error line",
+			output: "starting\nERROR: something went wrong\n",
+			want:   "ERROR: something went wrong",
+		},
+		{
+			name:   "no error",
+			output: "timeout",
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FailingDirective(tt.output))
+		})
+	}
+}
+
+func TestFailureOutput(t *testing.T) {
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Message: "ignored"}}},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: "config error\n"}}},
+			},
+		},
+	}
+	assert.Equal(t, "config error", FailureOutput(pod))
+}
+
+func TestRecordFailure(t *testing.T) {
+	recorder := record.NewFakeRecorder(1)
+	logging := &v1beta1.Logging{
+		Status: v1beta1.LoggingStatus{
+			ConfigCheckResults: map[string]bool{"abc": false},
+		},
+	}
+
+	RecordFailure(recorder, logging, "abc", `config error file="/fluentd/etc/fluent.conf" error="Unknown output plugin 'htp'"`)
+	SetCondition(logging, "abc")
+
+	assert.Equal(t, map[string]string{"abc": `config error file="/fluentd/etc/fluent.conf" error="Unknown output plugin 'htp'"`}, logging.Status.ConfigCheckFailures)
+	assert.Equal(t, "Warning ConfigCheckFailed configuration with checksum abc has failed, failing directive: Unknown output plugin 'htp'", <-recorder.Events)
+	assert.Equal(t, "configuration with checksum abc has failed: Unknown output plugin 'htp'",
+		meta.FindStatusCondition(logging.Status.Conditions, v1beta1.ConditionConfigCheckPassed).Message)
+
+	logging.Status.ConfigCheckResults = nil
+	PruneFailures(logging)
+	assert.Empty(t, logging.Status.ConfigCheckFailures)
+}
+
+func TestSetCondition(t *testing.T) {
+	logging := &v1beta1.Logging{
+		Status: v1beta1.LoggingStatus{
+			ConfigCheckResults: map[string]bool{"old": false, "new": true},
+		},
+	}
+
+	// only the result of the current config matters
+	SetCondition(logging, "new")
+	condition := meta.FindStatusCondition(logging.Status.Conditions, v1beta1.ConditionConfigCheckPassed)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, v1beta1.ReasonConfigCheckPassed, condition.Reason)
+
+	SetCondition(logging, "old")
+	condition = meta.FindStatusCondition(logging.Status.Conditions, v1beta1.ConditionConfigCheckPassed)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1beta1.ReasonConfigCheckFailed, condition.Reason)
+
+	SetCondition(logging, "next")
+	condition = meta.FindStatusCondition(logging.Status.Conditions, v1beta1.ConditionConfigCheckPassed)
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	assert.Equal(t, v1beta1.ReasonConfigCheckPending, condition.Reason)
+
+	RemoveCondition(logging)
+	assert.Nil(t, meta.FindStatusCondition(logging.Status.Conditions, v1beta1.ConditionConfigCheckPassed))
+}
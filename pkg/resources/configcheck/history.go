@@ -0,0 +1,259 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configcheck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+// EventReasonRolledBack is the reason of the event emitted when the aggregator config is rolled back
+const EventReasonRolledBack = "ConfigRolledBack"
+
+// HistoryLimit is the number of known good config revisions kept
+const HistoryLimit = 5
+
+const (
+	// PendingLabel marks the revisions that are applied, but not confirmed to run on the pods yet
+	PendingLabel = "logging.banzaicloud.io/config-pending"
+	// AppliedAtAnnotation is the time the operator has seen the config of a pending revision applied
+	AppliedAtAnnotation = "logging.banzaicloud.io/config-applied-at"
+)
+
+// ConfigHistory keeps the aggregator configs that are known to be good in secrets labelled with the config hash.
+// The config that is applied, but not confirmed yet is kept as a pending revision.
+type ConfigHistory struct {
+	client client.Client
+	labels client.MatchingLabels
+}
+
+func NewConfigHistory(c client.Client, component string, logging string) *ConfigHistory {
+	return &ConfigHistory{
+		client: c,
+		labels: client.MatchingLabels{
+			"app.kubernetes.io/component":  component,
+			"app.kubernetes.io/managed-by": logging,
+		},
+	}
+}
+
+// Get returns the known good revision with the given hash or nil if it is not in the history
+func (h *ConfigHistory) Get(ctx context.Context, hash string) (*corev1.Secret, error) {
+	revisions, err := h.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range revisions {
+		if _, match := hasHashLabel(&revisions[i], hash); match && !isPending(&revisions[i]) {
+			return &revisions[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// LastGood returns the newest known good revision that doesn't match the given hash or nil if there is none
+func (h *ConfigHistory) LastGood(ctx context.Context, hash string) (*corev1.Secret, error) {
+	revisions, err := h.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range revisions {
+		if _, match := hasHashLabel(&revisions[i], hash); !match && !isPending(&revisions[i]) {
+			return &revisions[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Pending stores the revision as pending unless it is already in the history, and returns the time the config was applied at.
+// Pending revisions of other configs are removed, since those configs are not applied anymore.
+func (h *ConfigHistory) Pending(ctx context.Context, secret *corev1.Secret, now time.Time) (time.Time, error) {
+	revisions, err := h.list(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	hash := secret.Labels[HashLabel]
+	var multierr error
+	for i := range revisions {
+		revision := &revisions[i]
+		if _, match := hasHashLabel(revision, hash); match {
+			if appliedAt, err := time.Parse(time.RFC3339, revision.Annotations[AppliedAtAnnotation]); err == nil {
+				return appliedAt, nil
+			}
+			return now, nil
+		}
+		if isPending(revision) {
+			if err := client.IgnoreNotFound(h.client.Delete(ctx, revision)); err != nil {
+				multierr = errors.Combine(multierr,
+					errors.Wrapf(err, "failed to remove pending config revision %s", revision.Name))
+			}
+		}
+	}
+	if multierr != nil {
+		return time.Time{}, multierr
+	}
+
+	secret.Labels[PendingLabel] = "true"
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[AppliedAtAnnotation] = now.UTC().Format(time.RFC3339)
+	if err := h.client.Create(ctx, secret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return time.Time{}, errors.WrapIff(err, "failed to record pending config revision %s", secret.Name)
+	}
+	return now, nil
+}
+
+// Record stores a known good revision, or confirms the pending one, and removes the oldest ones above HistoryLimit.
+// The secret must have the logging.banzaicloud.io/config-hash label and the labels of the history.
+func (h *ConfigHistory) Record(ctx context.Context, secret *corev1.Secret) error {
+	revisions, err := h.list(ctx)
+	if err != nil {
+		return err
+	}
+	var pending *corev1.Secret
+	for i := range revisions {
+		if _, match := hasHashLabel(&revisions[i], secret.Labels[HashLabel]); match && isPending(&revisions[i]) {
+			pending = &revisions[i]
+		}
+	}
+	if pending != nil {
+		patchBase := client.MergeFrom(pending.DeepCopy())
+		delete(pending.Labels, PendingLabel)
+		delete(pending.Annotations, AppliedAtAnnotation)
+		if err := h.client.Patch(ctx, pending, patchBase); err != nil {
+			return errors.WrapIff(err, "failed to confirm config revision %s", pending.Name)
+		}
+	} else if err := h.client.Create(ctx, secret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return errors.WrapIff(err, "failed to record config revision %s", secret.Name)
+	}
+
+	if revisions, err = h.list(ctx); err != nil {
+		return err
+	}
+	var multierr error
+	good := 0
+	for i := range revisions {
+		if isPending(&revisions[i]) {
+			continue
+		}
+		if good++; good <= HistoryLimit {
+			continue
+		}
+		if err := client.IgnoreNotFound(h.client.Delete(ctx, &revisions[i])); err != nil {
+			multierr = errors.Combine(multierr,
+				errors.Wrapf(err, "failed to remove config revision %s", revisions[i].Name))
+		}
+	}
+	return multierr
+}
+
+// list returns the revisions from the newest to the oldest
+func (h *ConfigHistory) list(ctx context.Context) ([]corev1.Secret, error) {
+	revisions := &corev1.SecretList{}
+	if err := h.client.List(ctx, revisions, h.labels); err != nil {
+		return nil, errors.Wrap(err, "failed to list config revisions")
+	}
+	sort.SliceStable(revisions.Items, func(i, j int) bool {
+		a, b := revisions.Items[i].CreationTimestamp, revisions.Items[j].CreationTimestamp
+		if a.Equal(&b) {
+			return revisions.Items[i].Name > revisions.Items[j].Name
+		}
+		return b.Before(&a)
+	})
+	return revisions.Items, nil
+}
+
+func isPending(secret *corev1.Secret) bool {
+	return secret.Labels[PendingLabel] == "true"
+}
+
+// CrashLooping returns true if any container of the pods is waiting to be restarted after repeated failures
+func CrashLooping(pods []corev1.Pod) bool {
+	for _, pod := range pods {
+		for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RunningSince checks that every container of the pods has been started after the config was applied, so the pods run the config.
+// Hot reloads are not taken into account, since a config that fails to reload leaves the previous one running.
+// It returns how long the pods have to stay ready before the config is known to be good, zero if they are stable already.
+func RunningSince(pods []corev1.Pod, appliedAt time.Time, now time.Time, window time.Duration) (running bool, remaining time.Duration) {
+	if len(pods) == 0 {
+		return false, 0
+	}
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Running == nil || !status.State.Running.StartedAt.After(appliedAt) {
+				return false, 0
+			}
+			remaining = max(remaining, status.State.Running.StartedAt.Add(window).Sub(now))
+		}
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodReady {
+				remaining = max(remaining, cond.LastTransitionTime.Add(window).Sub(now))
+			}
+		}
+	}
+	return true, remaining
+}
+
+// AllReady returns true if there is at least one pod and all pods are ready
+func AllReady(pods []corev1.Pod) bool {
+	if len(pods) == 0 {
+		return false
+	}
+	for _, pod := range pods {
+		ready := false
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodReady {
+				ready = cond.Status == corev1.ConditionTrue
+			}
+		}
+		if !ready {
+			return false
+		}
+	}
+	return true
+}
+
+// RecordRollback stores the rollback in the logging status and emits a warning event about it
+func RecordRollback(recorder record.EventRecorder, logging *v1beta1.Logging, failedHash string, restoredHash string) {
+	logging.Status.ConfigRollback = &v1beta1.ConfigRollback{
+		FailedHash:   failedHash,
+		RestoredHash: restoredHash,
+		Time:         v1.Now(),
+	}
+	if recorder != nil {
+		recorder.Event(logging, corev1.EventTypeWarning, EventReasonRolledBack,
+			fmt.Sprintf("aggregator pods are crash-looping with configuration %s, rolled back to the last known good configuration %s", failedHash, restoredHash))
+	}
+}
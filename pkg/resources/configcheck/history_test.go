@@ -0,0 +1,157 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configcheck
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfigHistory(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	history := NewConfigHistory(c, "fluentd-config-history", "logging")
+
+	start := time.Now()
+	for i := 0; i < HistoryLimit+2; i++ {
+		secret := &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:              fmt.Sprintf("config-%d", i),
+				Namespace:         "logging",
+				CreationTimestamp: v1.NewTime(start.Add(time.Duration(i) * time.Minute)),
+				Labels: map[string]string{
+					"app.kubernetes.io/component":  "fluentd-config-history",
+					"app.kubernetes.io/managed-by": "logging",
+				},
+			},
+		}
+		WithHashLabel(secret, fmt.Sprintf("hash%d", i))
+		require.NoError(t, history.Record(ctx, secret))
+	}
+
+	oldest, err := history.Get(ctx, "hash1")
+	require.NoError(t, err)
+	assert.Nil(t, oldest)
+
+	lastGood, err := history.LastGood(ctx, fmt.Sprintf("hash%d", HistoryLimit+1))
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("config-%d", HistoryLimit), lastGood.Name)
+}
+
+func TestCrashLooping(t *testing.T) {
+	pods := []corev1.Pod{{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			},
+		},
+	}}
+	assert.True(t, CrashLooping(pods))
+	assert.False(t, AllReady(pods))
+	assert.False(t, CrashLooping(nil))
+}
+
+func TestPendingConfigRevision(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	history := NewConfigHistory(c, "fluentd-config-history", "logging")
+
+	revision := func(hash string) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "config-" + hash,
+				Namespace: "logging",
+				Labels: map[string]string{
+					"app.kubernetes.io/component":  "fluentd-config-history",
+					"app.kubernetes.io/managed-by": "logging",
+				},
+			},
+		}
+		WithHashLabel(secret, hash)
+		return secret
+	}
+
+	applied := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	appliedAt, err := history.Pending(ctx, revision("old"), applied)
+	require.NoError(t, err)
+	assert.Equal(t, applied, appliedAt)
+
+	appliedAt, err = history.Pending(ctx, revision("new"), applied.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, applied.Add(time.Hour), appliedAt)
+
+	// the pending revision keeps the time it was applied at
+	appliedAt, err = history.Pending(ctx, revision("new"), applied.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, applied.Add(time.Hour), appliedAt)
+
+	// pending revisions are not known to be good
+	known, err := history.Get(ctx, "new")
+	require.NoError(t, err)
+	assert.Nil(t, known)
+	lastGood, err := history.LastGood(ctx, "other")
+	require.NoError(t, err)
+	assert.Nil(t, lastGood)
+
+	require.NoError(t, history.Record(ctx, revision("new")))
+	known, err = history.Get(ctx, "new")
+	require.NoError(t, err)
+	require.NotNil(t, known)
+	assert.NotContains(t, known.Labels, PendingLabel)
+
+	// the pending revision of the replaced config is removed
+	revisions, err := history.list(ctx)
+	require.NoError(t, err)
+	assert.Len(t, revisions, 1)
+}
+
+func TestRunningSince(t *testing.T) {
+	appliedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	pod := func(started time.Time) corev1.Pod {
+		return corev1.Pod{
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: v1.NewTime(started.Add(10 * time.Second))},
+				},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: v1.NewTime(started)}}},
+				},
+			},
+		}
+	}
+
+	// the pod has been hot reloaded at most, it may still run the previous config
+	running, _ := RunningSince([]corev1.Pod{pod(appliedAt.Add(-time.Hour))}, appliedAt, appliedAt.Add(time.Hour), time.Minute)
+	assert.False(t, running)
+
+	running, remaining := RunningSince([]corev1.Pod{pod(appliedAt.Add(time.Minute))}, appliedAt, appliedAt.Add(90*time.Second), time.Minute)
+	assert.True(t, running)
+	assert.Equal(t, 40*time.Second, remaining)
+
+	running, remaining = RunningSince([]corev1.Pod{pod(appliedAt.Add(time.Minute))}, appliedAt, appliedAt.Add(time.Hour), time.Minute)
+	assert.True(t, running)
+	assert.Zero(t, remaining)
+
+	running, _ = RunningSince(nil, appliedAt, appliedAt, time.Minute)
+	assert.False(t, running)
+}
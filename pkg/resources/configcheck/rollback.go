@@ -0,0 +1,181 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configcheck
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+// StabilizationWindow is how long the pods have to stay ready with a config before it is known to be good
+const StabilizationWindow = 2 * time.Minute
+
+// Aggregator holds the details of an aggregator that are needed to keep its config history and roll back its config
+type Aggregator struct {
+	// Kind of the aggregator used in the names of the revisions and in the logs, fluentd or syslog-ng
+	Kind     string
+	Client   client.Client
+	Recorder record.EventRecorder
+	Log      logr.Logger
+	Logging  *v1beta1.Logging
+	// HistoryComponent is the component label of the revisions
+	HistoryComponent string
+	PodLabels        client.MatchingLabels
+	// ConfigSecret is the secret the config is applied in
+	ConfigSecret client.ObjectKey
+	// ConfigKey is the key of the config in the config secret and in the revisions
+	ConfigKey string
+	// RevisionMeta returns the object meta of a revision with the given name
+	RevisionMeta func(name string) metav1.ObjectMeta
+	// RolloutPending returns true while a config has not reached every replica in a canary rollout
+	RolloutPending func(hash string) bool
+}
+
+// ReconcileHistory keeps the configs that ran on stable aggregator pods as known good revisions.
+// A config is known to be good once every container of the pods has been started after the config was applied
+// and the pods stayed ready for the StabilizationWindow. Hot reloaded configs are confirmed when the pods restart.
+// When the pods crash-loop with a config that is not known to be good, the last good revision is restored
+// in the given config until the config changes again.
+// It returns how long to wait before the pending config can be confirmed, if the pods are not stable yet.
+func (a *Aggregator) ReconcileHistory(ctx context.Context, patchBase client.Patch, desiredHash string, config *string) (time.Duration, *reconcile.Result, error) {
+	history := NewConfigHistory(a.Client, a.HistoryComponent, a.Logging.GetName())
+
+	hash := desiredHash
+	rollback := a.Logging.Status.ConfigRollback
+	if rollback != nil && rollback.FailedHash == desiredHash {
+		restored, err := history.Get(ctx, rollback.RestoredHash)
+		if err != nil {
+			return 0, nil, err
+		}
+		if restored != nil {
+			*config = string(restored.Data[a.ConfigKey])
+			hash = rollback.RestoredHash
+		}
+	}
+
+	if a.RolloutPending != nil && a.RolloutPending(hash) {
+		// the canary rollout decides about configs that have not reached every replica
+		return 0, nil, nil
+	}
+
+	applied := &corev1.Secret{}
+	if err := a.Client.Get(ctx, a.ConfigSecret, applied); err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil, nil
+		}
+		return 0, nil, errors.WrapIff(err, "failed to get %s config secret", a.Kind)
+	}
+	if applied.Labels[HashLabel] != hash {
+		// the config is not applied yet, the pods can't tell anything about it
+		return 0, nil, nil
+	}
+
+	var pods corev1.PodList
+	if err := a.Client.List(ctx, &pods, client.InNamespace(a.Logging.Spec.ControlNamespace), a.PodLabels); err != nil {
+		return 0, nil, errors.WrapIff(err, "listing %s pods", a.Kind)
+	}
+
+	known, err := history.Get(ctx, hash)
+	if err != nil {
+		return 0, nil, err
+	}
+	var appliedAt time.Time
+	if known == nil {
+		if appliedAt, err = history.Pending(ctx, a.revision(hash, *config), appliedTime(applied)); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	switch {
+	case CrashLooping(pods.Items) && known == nil:
+		lastGood, err := history.LastGood(ctx, hash)
+		if err != nil {
+			return 0, nil, err
+		}
+		if lastGood == nil {
+			a.Log.Info(a.Kind+" pods are crash-looping, but there is no known good config to roll back to", "hash", hash)
+			return 0, nil, nil
+		}
+		restoredHash := lastGood.Labels[HashLabel]
+		a.Log.Info(a.Kind+" pods are crash-looping, rolling back to the last known good config", "hash", hash, "restored", restoredHash)
+		RecordRollback(a.Recorder, a.Logging, hash, restoredHash)
+		if err := a.Client.Status().Patch(ctx, a.Logging, patchBase); err != nil {
+			return 0, nil, errors.WrapWithDetails(err, "failed to patch status", "logging", a.Logging)
+		}
+		// explicitly ask for a requeue to apply the restored config
+		return 0, &reconcile.Result{Requeue: true}, nil
+
+	case AllReady(pods.Items):
+		if known == nil {
+			running, remaining := RunningSince(pods.Items, appliedAt, time.Now(), StabilizationWindow)
+			if !running {
+				// the pods still run the config they were started with
+				return 0, nil, nil
+			}
+			if remaining > 0 {
+				return remaining, nil, nil
+			}
+			if err := history.Record(ctx, a.revision(hash, *config)); err != nil {
+				return 0, nil, err
+			}
+		}
+		// a new config has replaced the one that has been rolled back
+		if rollback != nil && hash == desiredHash {
+			a.Logging.Status.ConfigRollback = nil
+			if err := a.Client.Status().Patch(ctx, a.Logging, patchBase); err != nil {
+				return 0, nil, errors.WrapWithDetails(err, "failed to patch status", "logging", a.Logging)
+			}
+		}
+	}
+
+	return 0, nil, nil
+}
+
+func (a *Aggregator) revision(hash string, config string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: a.RevisionMeta(fmt.Sprintf("%s-config-%s", a.Kind, hash)),
+		Data: map[string][]byte{
+			a.ConfigKey: []byte(config),
+		},
+	}
+	WithHashLabel(secret, hash)
+	return secret
+}
+
+// appliedTime returns the last time the config secret was written, or the current time if it is not known
+func appliedTime(secret *corev1.Secret) time.Time {
+	applied := secret.CreationTimestamp.Time
+	for _, field := range secret.ManagedFields {
+		if field.Time != nil && field.Time.After(applied) {
+			applied = field.Time.Time
+		}
+	}
+	if applied.IsZero() {
+		return time.Now()
+	}
+	return applied
+}
@@ -0,0 +1,120 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configdiff tells which flows, filters and outputs have changed between two rendered aggregator configs
+package configdiff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Config is the rendered config of an aggregator split into flows, filters and outputs keyed by their ids
+type Config struct {
+	Flows   map[string]string
+	Filters map[string]string
+	Outputs map[string]string
+}
+
+func newConfig() Config {
+	return Config{
+		Flows:   make(map[string]string),
+		Filters: make(map[string]string),
+		Outputs: make(map[string]string),
+	}
+}
+
+// Changes lists the ids of the added, removed and modified sections
+type Changes struct {
+	Added    []string `json:"added,omitempty"`
+	Removed  []string `json:"removed,omitempty"`
+	Modified []string `json:"modified,omitempty"`
+}
+
+// Empty returns true if nothing has changed
+func (c Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Modified) == 0
+}
+
+// Diff is the structured difference of two rendered configs
+type Diff struct {
+	Flows   Changes `json:"flows,omitempty"`
+	Filters Changes `json:"filters,omitempty"`
+	Outputs Changes `json:"outputs,omitempty"`
+}
+
+// Empty returns true if no flow, filter or output has changed
+func (d Diff) Empty() bool {
+	return d.Flows.Empty() && d.Filters.Empty() && d.Outputs.Empty()
+}
+
+func (d Diff) String() string {
+	if d.Empty() {
+		return "no flows, filters or outputs changed"
+	}
+	var parts []string
+	for _, kind := range []struct {
+		name    string
+		changes Changes
+	}{
+		{"flows", d.Flows},
+		{"filters", d.Filters},
+		{"outputs", d.Outputs},
+	} {
+		for _, change := range []struct {
+			name string
+			ids  []string
+		}{
+			{"added", kind.changes.Added},
+			{"removed", kind.changes.Removed},
+			{"modified", kind.changes.Modified},
+		} {
+			if len(change.ids) > 0 {
+				parts = append(parts, fmt.Sprintf("%s %s: %s", kind.name, change.name, strings.Join(change.ids, ", ")))
+			}
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Compute returns the changes between the previous and the current config
+func Compute(previous, current Config) Diff {
+	return Diff{
+		Flows:   compare(previous.Flows, current.Flows),
+		Filters: compare(previous.Filters, current.Filters),
+		Outputs: compare(previous.Outputs, current.Outputs),
+	}
+}
+
+func compare(previous, current map[string]string) (changes Changes) {
+	for id, content := range current {
+		old, ok := previous[id]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, id)
+		case old != content:
+			changes.Modified = append(changes.Modified, id)
+		}
+	}
+	for id := range previous {
+		if _, ok := current[id]; !ok {
+			changes.Removed = append(changes.Removed, id)
+		}
+	}
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Removed)
+	sort.Strings(changes.Modified)
+	return
+}
@@ -0,0 +1,202 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configdiff
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func fluentdConfig(namespaces string, outputHost string, withFilter bool) string {
+	filter := ""
+	if withFilter {
+		filter = heredoc.Doc(`
+		  <filter **>
+		    @type stdout
+		    @id flow:default:app:0
+		  </filter>`)
+	}
+	return heredoc.Docf(`
+	<source>
+	  @type forward
+	  @id main_forward
+	</source>
+	<match **>
+	  @type label_router
+	  @id main
+	  <route>
+	    @label @app
+	    <match>
+	      namespaces %s
+	    </match>
+	  </route>
+	  <route>
+	    @label @other
+	  </route>
+	</match>
+	<label @app>
+	%s
+	  <match **>
+	    @type http
+	    @id flow:default:app:output:default:http
+	    endpoint %s
+	  </match>
+	</label>
+	<label @other>
+	  <match **>
+	    @type null
+	    @id clusterflow:logging:other:clusteroutput:logging:null
+	  </match>
+	</label>`, namespaces, filter, outputHost)
+}
+
+func TestFluentdDiff(t *testing.T) {
+	previous := ParseFluentd(fluentdConfig("default", "http://a", false))
+	assert.ElementsMatch(t, []string{"flow:default:app", "clusterflow:logging:other"}, keys(previous.Flows))
+
+	diff := Compute(previous, ParseFluentd(fluentdConfig("default", "http://b", true)))
+	assert.Equal(t, Diff{
+		Flows:   Changes{Modified: []string{"flow:default:app"}},
+		Filters: Changes{Added: []string{"flow:default:app:0"}},
+		Outputs: Changes{Modified: []string{"flow:default:app:output:default:http"}},
+	}, diff)
+
+	// match changes show up in the router
+	diff = Compute(previous, ParseFluentd(fluentdConfig("default,other", "http://a", false)))
+	assert.Equal(t, Diff{Flows: Changes{Modified: []string{"flow:default:app"}}}, diff)
+
+	assert.True(t, Compute(previous, previous).Empty())
+}
+
+const syslogNGConfig = `@version: current
+
+@include "scl.conf"
+
+source "main_input" {
+    network(flags("no-parse") port(601) transport("tcp"));
+};
+
+destination "output_default_test-syslog-out" {
+	syslog("test.local" transport("tcp") persist_name("output_default_test-syslog-out"));
+};
+
+filter "flow_default_test-flow_ns_filter" {
+	match("default" value("json.kubernetes.namespace_name") type("string"));
+};
+filter "flow_default_test-flow_match" {
+	match("nginx" value("kubernetes.labels.app"));
+};
+rewrite "flow_default_test-flow_filters_0" {
+	set("test-cluster" value("cluster"));
+};
+log {
+	source("main_input");
+	filter("flow_default_test-flow_ns_filter");
+	filter("flow_default_test-flow_match");
+	rewrite("flow_default_test-flow_filters_0");
+	log {
+        destination("output_default_test-syslog-out");
+    };
+};
+`
+
+func TestSyslogNGDiff(t *testing.T) {
+	previous := ParseSyslogNG(syslogNGConfig)
+	assert.Equal(t, []string{"flow_default_test-flow"}, keys(previous.Flows))
+	assert.Equal(t, []string{"flow_default_test-flow_filters_0"}, keys(previous.Filters))
+	assert.Equal(t, []string{"output_default_test-syslog-out"}, keys(previous.Outputs))
+
+	current := syslogNGConfig
+	current = strings.Replace(current, `match("nginx"`, `match("apache"`, 1)
+	current = strings.Replace(current, `set("test-cluster"`, `set("other-cluster { }"`, 1)
+	current = strings.Replace(current, `"test.local"`, `"other.local"`, 1)
+	diff := Compute(previous, ParseSyslogNG(current))
+	assert.Equal(t, Diff{
+		Flows:   Changes{Modified: []string{"flow_default_test-flow"}},
+		Filters: Changes{Modified: []string{"flow_default_test-flow_filters_0"}},
+		Outputs: Changes{Modified: []string{"output_default_test-syslog-out"}},
+	}, diff)
+
+	diff = Compute(previous, ParseSyslogNG("@version: current\n"))
+	assert.Equal(t, Diff{
+		Flows:   Changes{Removed: []string{"flow_default_test-flow"}},
+		Filters: Changes{Removed: []string{"flow_default_test-flow_filters_0"}},
+		Outputs: Changes{Removed: []string{"output_default_test-syslog-out"}},
+	}, diff)
+	assert.Equal(t, "flows removed: flow_default_test-flow; filters removed: flow_default_test-flow_filters_0; outputs removed: output_default_test-syslog-out", diff.String())
+}
+
+func TestHistory(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	meta := metav1.ObjectMeta{Name: "logging-fluentd-config-diff", Namespace: "logging"}
+	history := NewHistory(c, meta, 3)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		entry := Entry{
+			Time:         metav1.NewTime(start.Add(time.Duration(i) * time.Minute)),
+			PreviousHash: fmt.Sprintf("hash%d", i),
+			Hash:         fmt.Sprintf("hash%d", i+1),
+			Diff:         Diff{Flows: Changes{Added: []string{fmt.Sprintf("flow%d", i)}}},
+		}
+		require.NoError(t, history.Record(ctx, entry))
+		// repeated reconciliations don't duplicate the entry
+		entry.Time = metav1.NewTime(entry.Time.Add(time.Second))
+		require.NoError(t, history.Record(ctx, entry))
+	}
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: meta.Namespace, Name: meta.Name}, cm))
+	assert.ElementsMatch(t, []string{
+		"20250101-000200-hash3",
+		"20250101-000300-hash4",
+		"20250101-000400-hash5",
+	}, keys(cm.Data))
+}
+
+func TestRecordEvent(t *testing.T) {
+	recorder := record.NewFakeRecorder(2)
+	logging := &v1beta1.Logging{ObjectMeta: metav1.ObjectMeta{Name: "logging"}}
+	diff := Diff{Flows: Changes{Added: []string{"flow:default:app"}}}
+
+	RecordEvent(recorder, logging, "abc", "def", diff)
+	assert.Equal(t, "Normal ConfigChanged aggregator configuration changed from abc to def: flows added: flow:default:app", <-recorder.Events)
+
+	// the config applied by an older version has no hash label
+	RecordEvent(recorder, logging, "", "def", diff)
+	assert.Equal(t, "Normal ConfigChanged aggregator configuration changed to def from a configuration without a hash: flows added: flow:default:app", <-recorder.Events)
+}
+
+func keys(m map[string]string) (result []string) {
+	for k := range m {
+		result = append(result, k)
+	}
+	return
+}
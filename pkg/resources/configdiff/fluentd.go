@@ -0,0 +1,160 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configdiff
+
+import (
+	"strings"
+)
+
+// directive is a <name arg> ... </name> section of a fluentd config
+type directive struct {
+	name     string
+	arg      string
+	params   []string
+	children []*directive
+}
+
+func (d *directive) id() string {
+	return d.param("@id")
+}
+
+func (d *directive) param(key string) string {
+	for _, p := range d.params {
+		if k, v, _ := strings.Cut(p, " "); k == key {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+func (d *directive) header() string {
+	if d.arg == "" {
+		return "<" + d.name + ">"
+	}
+	return "<" + d.name + " " + d.arg + ">"
+}
+
+func (d *directive) write(b *strings.Builder) {
+	b.WriteString(d.header())
+	b.WriteByte('\n')
+	for _, p := range d.params {
+		b.WriteString(p)
+		b.WriteByte('\n')
+	}
+	for _, c := range d.children {
+		c.write(b)
+	}
+	b.WriteString("</" + d.name + ">\n")
+}
+
+func (d *directive) String() string {
+	var b strings.Builder
+	d.write(&b)
+	return b.String()
+}
+
+// ParseFluentd splits a rendered fluentd config into flows, filters and outputs.
+// Every <label> is a flow, the filters and outputs of the flows are keyed by their @id.
+// The routes of the label router belong to the flow they point to, so match changes show up as a modified flow.
+func ParseFluentd(config string) Config {
+	c := newConfig()
+
+	root := parseDirectives(config)
+
+	routes := make(map[string]string)
+	var collectRoutes func(d *directive)
+	collectRoutes = func(d *directive) {
+		for _, child := range d.children {
+			if child.name == "route" {
+				if label := child.param("@label"); label != "" {
+					routes[label] += child.String()
+				}
+				continue
+			}
+			collectRoutes(child)
+		}
+	}
+	collectRoutes(root)
+
+	for _, label := range root.children {
+		if label.name != "label" {
+			continue
+		}
+		var flow strings.Builder
+		for _, p := range label.params {
+			flow.WriteString(p)
+			flow.WriteByte('\n')
+		}
+		flowID := ""
+		for _, child := range label.children {
+			id := child.id()
+			if id == "" {
+				child.write(&flow)
+				continue
+			}
+			// the content of the filters and outputs is compared on its own
+			flow.WriteString(child.header() + " " + id + "\n")
+			switch child.name {
+			case "filter":
+				c.Filters[id] = child.String()
+			case "match":
+				c.Outputs[id] = child.String()
+			}
+			if flowID == "" {
+				flowID = fluentdFlowID(id)
+			}
+		}
+		flow.WriteString(routes[label.arg])
+		if flowID == "" {
+			flowID = strings.TrimPrefix(label.arg, "@")
+		}
+		c.Flows[flowID] = flow.String()
+	}
+
+	return c
+}
+
+// fluentdFlowID returns the flow:namespace:name part of the id of a filter or output
+func fluentdFlowID(id string) string {
+	parts := strings.SplitN(id, ":", 4)
+	if len(parts) < 3 {
+		return id
+	}
+	return strings.Join(parts[:3], ":")
+}
+
+func parseDirectives(config string) *directive {
+	root := &directive{}
+	stack := []*directive{root}
+	for _, line := range strings.Split(config, "\n") {
+		line = strings.TrimSpace(line)
+		current := stack[len(stack)-1]
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "</") && strings.HasSuffix(line, ">"):
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case strings.HasPrefix(line, "<") && strings.HasSuffix(line, ">"):
+			name, arg, _ := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(line, "<"), ">"), " ")
+			d := &directive{name: name, arg: strings.TrimSpace(arg)}
+			current.children = append(current.children, d)
+			stack = append(stack, d)
+		default:
+			current.params = append(current.params, line)
+		}
+	}
+	return root
+}
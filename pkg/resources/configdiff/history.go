@@ -0,0 +1,130 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configdiff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+// EventReasonChanged is the reason of the event emitted when the aggregator config changes
+const EventReasonChanged = "ConfigChanged"
+
+// DefaultHistoryLimit is the number of config changes kept when the limit is not set
+const DefaultHistoryLimit = 10
+
+// events are rejected above this size
+const maxEventMessageLength = 1024
+
+// Entry is a config change stored in the history
+type Entry struct {
+	Time         metav1.Time `json:"time"`
+	PreviousHash string      `json:"previousHash"`
+	Hash         string      `json:"hash"`
+	Diff         Diff        `json:"diff"`
+}
+
+// RecordEvent emits an event about the changed flows, filters and outputs.
+// The previous hash is empty if the applied config was created by a version that didn't label it with its hash.
+func RecordEvent(recorder record.EventRecorder, logging *v1beta1.Logging, previousHash string, hash string, diff Diff) {
+	if recorder == nil {
+		return
+	}
+	message := fmt.Sprintf("aggregator configuration changed from %s to %s: %s", previousHash, hash, diff)
+	if previousHash == "" {
+		message = fmt.Sprintf("aggregator configuration changed to %s from a configuration without a hash: %s", hash, diff)
+	}
+	if len(message) > maxEventMessageLength {
+		message = message[:maxEventMessageLength-3] + "..."
+	}
+	recorder.Event(logging, corev1.EventTypeNormal, EventReasonChanged, message)
+}
+
+// History keeps the last config changes in a ConfigMap, one entry per key
+type History struct {
+	client client.Client
+	meta   metav1.ObjectMeta
+	limit  int
+}
+
+func NewHistory(c client.Client, meta metav1.ObjectMeta, limit int) *History {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	return &History{
+		client: c,
+		meta:   meta,
+		limit:  limit,
+	}
+}
+
+// Record adds the entry to the history and removes the oldest entries above the limit
+func (h *History) Record(ctx context.Context, entry Entry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return errors.WrapIf(err, "failed to marshal config diff")
+	}
+	// keys are sortable by time, the hash tells apart changes within the same second
+	key := fmt.Sprintf("%s-%s", entry.Time.UTC().Format("20060102-150405"), entry.Hash)
+
+	cm := &corev1.ConfigMap{}
+	if err := h.client.Get(ctx, client.ObjectKey{Namespace: h.meta.Namespace, Name: h.meta.Name}, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.WrapIf(err, "failed to get config diff history")
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: h.meta,
+			Data:       map[string]string{key: string(data)},
+		}
+		return errors.WrapIf(h.client.Create(ctx, cm), "failed to create config diff history")
+	}
+
+	keys := make([]string, 0, len(cm.Data))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if len(keys) > 0 {
+		var last Entry
+		// the same change is recorded only once even if the reconciliation is repeated before it is applied
+		if err := json.Unmarshal([]byte(cm.Data[keys[len(keys)-1]]), &last); err == nil &&
+			last.Hash == entry.Hash && last.PreviousHash == entry.PreviousHash {
+			return nil
+		}
+	}
+
+	patchBase := client.MergeFrom(cm.DeepCopy())
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[key] = string(data)
+	keys = append(keys, key)
+	sort.Strings(keys)
+	for i := 0; i < len(keys)-h.limit; i++ {
+		delete(cm.Data, keys[i])
+	}
+	return errors.WrapIf(h.client.Patch(ctx, cm, patchBase), "failed to update config diff history")
+}
@@ -0,0 +1,138 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configdiff
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// names of the statements rendered for a flow, e.g. flow_default_app_ns_filter or clusterflow_logging_all_filters_0
+	flowStatementName = regexp.MustCompile(`^((?:cluster)?flow_[^_]+_[^_]+)_(ns_filter|match|filters_.+)$`)
+	// references in log statements, e.g. filter("flow_default_app_match") or destination("output_default_http")
+	statementRef = regexp.MustCompile(`\b(filter|rewrite|parser|destination)\("([^"]+)"\)`)
+)
+
+// statement is a top level `kind "name" { ... };` or `log { ... };` statement of a syslog-ng config
+type statement struct {
+	kind string
+	name string
+	body string
+}
+
+// ParseSyslogNG splits a rendered syslog-ng config into flows, filters and outputs.
+// Destinations are the outputs, the filter, parser and rewrite statements of the flow filters are the filters.
+// A flow consists of its log statement and its namespace and match filters.
+func ParseSyslogNG(config string) Config {
+	c := newConfig()
+
+	var logs []statement
+	flowParts := make(map[string]string)
+	for _, stmt := range parseStatements(config) {
+		switch stmt.kind {
+		case "log":
+			logs = append(logs, stmt)
+		case "destination":
+			c.Outputs[stmt.name] = stmt.body
+		case "filter", "parser", "rewrite":
+			match := flowStatementName.FindStringSubmatch(stmt.name)
+			if match == nil {
+				continue
+			}
+			if strings.HasPrefix(match[2], "filters_") {
+				c.Filters[stmt.name] = stmt.body
+			} else {
+				flowParts[match[1]] += stmt.body
+			}
+		}
+	}
+
+	for _, log := range logs {
+		flowID := ""
+		var destinations []string
+		for _, ref := range statementRef.FindAllStringSubmatch(log.body, -1) {
+			if ref[1] == "destination" {
+				destinations = append(destinations, ref[2])
+				continue
+			}
+			if match := flowStatementName.FindStringSubmatch(ref[2]); match != nil && flowID == "" {
+				flowID = match[1]
+			}
+		}
+		if flowID == "" {
+			// a clusterflow without match and filters can only be told apart by its destinations
+			flowID = "log(" + strings.Join(destinations, ",") + ")"
+		}
+		c.Flows[flowID] += flowParts[flowID] + log.body
+	}
+
+	return c
+}
+
+func parseStatements(config string) []statement {
+	var stmts []statement
+	var current strings.Builder
+	depth := 0
+	var quote rune
+	escaped := false
+	for _, line := range strings.Split(config, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if depth == 0 && (trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "@")) {
+			continue
+		}
+		for _, ch := range trimmed {
+			switch {
+			case escaped:
+				escaped = false
+			case quote != 0:
+				if ch == '\\' {
+					escaped = true
+				} else if ch == quote {
+					quote = 0
+				}
+			case ch == '"' || ch == '\'':
+				quote = ch
+			case ch == '{':
+				depth++
+			case ch == '}':
+				depth--
+			}
+		}
+		current.WriteString(trimmed)
+		current.WriteByte('\n')
+		if depth <= 0 && strings.HasSuffix(trimmed, ";") {
+			if stmt, ok := newStatement(current.String()); ok {
+				stmts = append(stmts, stmt)
+			}
+			current.Reset()
+			depth = 0
+		}
+	}
+	return stmts
+}
+
+func newStatement(body string) (statement, bool) {
+	header, _, found := strings.Cut(body, "{")
+	if !found {
+		return statement{}, false
+	}
+	kind, name, _ := strings.Cut(strings.TrimSpace(header), " ")
+	return statement{
+		kind: kind,
+		name: strings.Trim(strings.TrimSpace(name), `"`),
+		body: body,
+	}, true
+}
@@ -0,0 +1,233 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dryrun provides a client that sends every write as a server side dry-run and records what would have changed
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"emperror.dev/errors"
+	objectmatcher "github.com/cisco-open/k8s-objectmatcher/patch"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	VerbCreate      = "create"
+	VerbUpdate      = "update"
+	VerbPatch       = "patch"
+	VerbDelete      = "delete"
+	VerbDeleteAllOf = "deleteallof"
+)
+
+// Action is a write that has not been persisted
+type Action struct {
+	Verb        string `json:"verb"`
+	Subresource string `json:"subresource,omitempty"`
+	Kind        string `json:"kind"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+	// JSON patch from the object in the cluster to the object the update or patch would have resulted in
+	Changes []jsonpatch.Operation `json:"changes,omitempty"`
+}
+
+func (a Action) String() string {
+	verb := a.Verb
+	if a.Subresource != "" {
+		verb = fmt.Sprintf("%s %s", a.Verb, a.Subresource)
+	}
+	if a.Namespace == "" {
+		return fmt.Sprintf("%s %s %s", verb, a.Kind, a.Name)
+	}
+	return fmt.Sprintf("%s %s %s/%s", verb, a.Kind, a.Namespace, a.Name)
+}
+
+// Client reads through the wrapped client and turns every write into a dry-run.
+// Updates and patches that don't change the object are not recorded.
+type Client struct {
+	client.Client
+
+	mu      sync.Mutex
+	actions []Action
+}
+
+var _ client.Client = &Client{}
+
+func NewClient(c client.Client) *Client {
+	return &Client{Client: c}
+}
+
+// Actions returns the recorded writes
+func (c *Client) Actions() []Action {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Action(nil), c.actions...)
+}
+
+func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, append(opts, client.DryRunAll)...); err != nil {
+		return err
+	}
+	c.record(obj, VerbCreate, "", nil)
+	return nil
+}
+
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.write(ctx, obj, VerbUpdate, "", func() error {
+		return c.Client.Update(ctx, obj, append(opts, client.DryRunAll)...)
+	})
+}
+
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.write(ctx, obj, VerbPatch, "", func() error {
+		return c.Client.Patch(ctx, obj, patch, append(opts, client.DryRunAll)...)
+	})
+}
+
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.Client.Delete(ctx, obj, append(opts, client.DryRunAll)...); err != nil {
+		return err
+	}
+	c.record(obj, VerbDelete, "", nil)
+	return nil
+}
+
+func (c *Client) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	if err := c.Client.DeleteAllOf(ctx, obj, append(opts, client.DryRunAll)...); err != nil {
+		return err
+	}
+	c.record(obj, VerbDeleteAllOf, "", nil)
+	return nil
+}
+
+func (c *Client) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *Client) SubResource(subResource string) client.SubResourceClient {
+	return &subResourceClient{
+		SubResourceClient: c.Client.SubResource(subResource),
+		client:            c,
+		subResource:       subResource,
+	}
+}
+
+// write runs the dry-run write and records the difference between the object in the cluster and the result
+func (c *Client) write(ctx context.Context, obj client.Object, verb string, subResource string, dryRun func() error) error {
+	live, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return errors.Errorf("unexpected object type %T", obj)
+	}
+	if err := c.Client.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+		return err
+	}
+	if err := dryRun(); err != nil {
+		return err
+	}
+	changes, err := changes(live, obj)
+	if err != nil {
+		return err
+	}
+	if len(changes) > 0 {
+		c.record(obj, verb, subResource, changes)
+	}
+	return nil
+}
+
+func (c *Client) record(obj client.Object, verb string, subResource string, changes []jsonpatch.Operation) {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		kind = gvk.GroupKind().String()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.actions = append(c.actions, Action{
+		Verb:        verb,
+		Subresource: subResource,
+		Kind:        kind,
+		Namespace:   obj.GetNamespace(),
+		Name:        obj.GetName(),
+		Changes:     changes,
+	})
+}
+
+// changes returns the JSON patch between two versions of an object ignoring the fields that change on every write
+func changes(from, to runtime.Object) ([]jsonpatch.Operation, error) {
+	fromJSON, err := comparable(from)
+	if err != nil {
+		return nil, err
+	}
+	toJSON, err := comparable(to)
+	if err != nil {
+		return nil, err
+	}
+	ops, err := jsonpatch.CreatePatch(fromJSON, toJSON)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to compare objects")
+	}
+	sort.Sort(jsonpatch.ByPath(ops))
+	return ops, nil
+}
+
+func comparable(obj runtime.Object) ([]byte, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to convert object")
+	}
+	if metadata, ok := u["metadata"].(map[string]any); ok {
+		delete(metadata, "managedFields")
+		delete(metadata, "resourceVersion")
+		delete(metadata, "generation")
+		if annotations, ok := metadata["annotations"].(map[string]any); ok {
+			delete(annotations, objectmatcher.LastAppliedConfig)
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+	return json.Marshal(u)
+}
+
+type subResourceClient struct {
+	client.SubResourceClient
+	client      *Client
+	subResource string
+}
+
+func (s *subResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	if err := s.SubResourceClient.Create(ctx, obj, subResource, append(opts, client.DryRunAll)...); err != nil {
+		return err
+	}
+	s.client.record(obj, VerbCreate, s.subResource, nil)
+	return nil
+}
+
+func (s *subResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return s.client.write(ctx, obj, VerbUpdate, s.subResource, func() error {
+		return s.SubResourceClient.Update(ctx, obj, append(opts, client.DryRunAll)...)
+	})
+}
+
+func (s *subResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return s.client.write(ctx, obj, VerbPatch, s.subResource, func() error {
+		return s.SubResourceClient.Patch(ctx, obj, patch, append(opts, client.DryRunAll)...)
+	})
+}
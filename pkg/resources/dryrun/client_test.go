@@ -0,0 +1,65 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dryrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "logging"},
+		Data:       map[string]string{"key": "value"},
+	}
+	c := NewClient(fake.NewClientBuilder().WithObjects(existing.DeepCopy()).Build())
+
+	created := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "created", Namespace: "logging"}}
+	require.NoError(t, c.Create(ctx, created))
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(created), &corev1.ConfigMap{})))
+
+	unchanged := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(existing), unchanged))
+	require.NoError(t, c.Update(ctx, unchanged))
+
+	updated := unchanged.DeepCopy()
+	updated.Data["key"] = "other"
+	require.NoError(t, c.Update(ctx, updated))
+
+	live := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(existing), live))
+	assert.Equal(t, "value", live.Data["key"])
+
+	require.NoError(t, c.Delete(ctx, live))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(existing), live))
+
+	actions := c.Actions()
+	require.Len(t, actions, 3)
+	assert.Equal(t, "create ConfigMap logging/created", actions[0].String())
+	assert.Equal(t, "update ConfigMap logging/existing", actions[1].String())
+	require.Len(t, actions[1].Changes, 1)
+	assert.Equal(t, "/data/key", actions[1].Changes[0].Path)
+	assert.Equal(t, "other", actions[1].Changes[0].Value)
+	assert.Equal(t, "delete ConfigMap logging/existing", actions[2].String())
+}
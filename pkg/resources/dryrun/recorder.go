@@ -0,0 +1,90 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dryrun
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Event is an event that has not been emitted
+type Event struct {
+	Type      string `json:"type"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+func (e Event) String() string {
+	if e.Namespace == "" {
+		return fmt.Sprintf("event %s %s on %s %s: %s", e.Type, e.Reason, e.Kind, e.Name, e.Message)
+	}
+	return fmt.Sprintf("event %s %s on %s %s/%s: %s", e.Type, e.Reason, e.Kind, e.Namespace, e.Name, e.Message)
+}
+
+// Recorder collects the events instead of emitting them
+type Recorder struct {
+	scheme *runtime.Scheme
+
+	mu     sync.Mutex
+	events []Event
+}
+
+var _ record.EventRecorder = &Recorder{}
+
+func NewRecorder(scheme *runtime.Scheme) *Recorder {
+	return &Recorder{scheme: scheme}
+}
+
+// Events returns the collected events
+func (r *Recorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+func (r *Recorder) Event(object runtime.Object, eventtype, reason, message string) {
+	event := Event{
+		Type:    eventtype,
+		Reason:  reason,
+		Message: message,
+		Kind:    object.GetObjectKind().GroupVersionKind().Kind,
+	}
+	if gvk, err := apiutil.GVKForObject(object, r.scheme); err == nil {
+		event.Kind = gvk.GroupKind().String()
+	}
+	if accessor, err := meta.Accessor(object); err == nil {
+		event.Namespace = accessor.GetNamespace()
+		event.Name = accessor.GetName()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *Recorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...any) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *Recorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason, messageFmt string, args ...any) {
+	r.Eventf(object, eventtype, reason, messageFmt, args...)
+}
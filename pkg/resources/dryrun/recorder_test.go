@@ -0,0 +1,37 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dryrun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestRecorder(t *testing.T) {
+	r := NewRecorder(scheme.Scheme)
+	object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "logging"}}
+	r.Event(object, corev1.EventTypeNormal, "ConfigChanged", "config changed")
+	r.Eventf(object, corev1.EventTypeWarning, "ConfigRolledBack", "rolled back to %s", "abc")
+
+	events := r.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, "event Normal ConfigChanged on ConfigMap logging/config: config changed", events[0].String())
+	assert.Equal(t, "event Warning ConfigRolledBack on ConfigMap logging/config: rolled back to abc", events[1].String())
+}
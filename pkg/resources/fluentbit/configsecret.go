@@ -407,6 +407,7 @@ func (r *Reconciler) configSecret() (runtime.Object, reconciler.DesiredState, er
 		for _, a := range loggingResources.LoggingRoutes {
 			tenants = append(tenants, a.Status.Tenants...)
 		}
+		r.tenants = tenants
 		if err := r.configureInputsForTenants(tenants, &input); err != nil {
 			return nil, nil, errors.WrapIf(err, "configuring inputs for target tenants")
 		}
@@ -439,6 +440,21 @@ func (r *Reconciler) configSecret() (runtime.Object, reconciler.DesiredState, er
 
 	r.applyNetworkSettings(input)
 
+	r.targets = nil
+	if input.FluentForwardOutput != nil {
+		for _, t := range input.FluentForwardOutput.Targets {
+			r.targets = append(r.targets, fmt.Sprintf("%s:%d", t.Host, t.Port))
+		}
+	}
+	if input.SyslogNGOutput != nil {
+		for _, t := range input.SyslogNGOutput.Targets {
+			r.targets = append(r.targets, fmt.Sprintf("%s:%d", t.Host, t.Port))
+		}
+	}
+	if len(r.targets) == 0 {
+		r.problems = append(r.problems, fmt.Sprintf("logging %s has no aggregator configured, logs are not forwarded", r.Logging.Name))
+	}
+
 	conf, err := generateConfig(input)
 	if err != nil {
 		return nil, reconciler.StatePresent, errors.WrapIf(err, "failed to generate config for fluentbit")
@@ -96,6 +96,9 @@ type Reconciler struct {
 	loggingDataProvider  loggingdataprovider.LoggingDataProvider
 	nameProvider         NameProvider
 	loggingResourcesRepo *model.LoggingResourceRepository
+	targets              []string
+	tenants              []v1beta1.Tenant
+	problems             []string
 }
 
 // NewReconciler creates a new FluentbitAgent reconciler
@@ -119,7 +122,14 @@ func New(client client.Client,
 }
 
 // Reconcile reconciles the fluentBit resource
-func (r *Reconciler) Reconcile(ctx context.Context) (*reconcile.Result, error) {
+func (r *Reconciler) Reconcile(ctx context.Context) (result *reconcile.Result, err error) {
+	// the status is written on every return, so it does not go stale when the reconcile stops early
+	defer func() {
+		if statusErr := r.updateStatus(ctx, err); statusErr != nil {
+			err = errors.Append(err, statusErr)
+		}
+	}()
+
 	if err := v1beta1.FluentBitDefaults(r.fluentbitSpec); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,96 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentbit
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"emperror.dev/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updateStatus reports the rollout and configuration state on the FluentbitAgent resource.
+// The legacy fluentbit definition inside the Logging resource has no status to update.
+// A failed reconcile is reported as a problem.
+func (r *Reconciler) updateStatus(ctx context.Context, reconcileErr error) error {
+	nameProvider, ok := r.nameProvider.(*FluentbitNameProvider)
+	if !ok || nameProvider.fluentbit == nil {
+		return nil
+	}
+	agent := nameProvider.fluentbit
+	patchBase := client.MergeFrom(agent.DeepCopy())
+
+	problems := r.problems
+	if reconcileErr != nil {
+		problems = append(problems, fmt.Sprintf("reconcile failed: %s", reconcileErr))
+	}
+
+	daemonSet := &appsv1.DaemonSet{}
+	meta := r.FluentbitObjectMeta(fluentbitDaemonSetName)
+	if err := r.resourceReconciler.Client.Get(ctx, client.ObjectKey{Namespace: meta.Namespace, Name: meta.Name}, daemonSet); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.WrapIf(err, "getting fluentbit daemonset")
+		}
+		problems = append(problems, fmt.Sprintf("daemonset %s/%s not found", meta.Namespace, meta.Name))
+	}
+
+	// the hash of the applied secret, the desired configuration may have failed to apply
+	configSecret := &corev1.Secret{}
+	meta = r.FluentbitObjectMeta(fluentBitSecretConfigName)
+	if err := r.resourceReconciler.Client.Get(ctx, client.ObjectKey{Namespace: meta.Namespace, Name: meta.Name}, configSecret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.WrapIf(err, "getting fluentbit config secret")
+		}
+		problems = append(problems, fmt.Sprintf("config secret %s/%s not found", meta.Namespace, meta.Name))
+	}
+
+	agent.Status.DesiredNumberScheduled = daemonSet.Status.DesiredNumberScheduled
+	agent.Status.NumberReady = daemonSet.Status.NumberReady
+	agent.Status.UpdatedNumberScheduled = daemonSet.Status.UpdatedNumberScheduled
+	agent.Status.ConfigHash = configHash(configSecret.Data)
+	agent.Status.Targets = r.targets
+	agent.Status.Tenants = r.tenants
+	agent.Status.Problems = problems
+	agent.Status.ProblemsCount = len(problems)
+
+	if err := r.resourceReconciler.Client.Status().Patch(ctx, agent, patchBase); err != nil {
+		return errors.WrapWithDetails(err, "failed to patch status", "fluentbitagent", agent.Name)
+	}
+	return nil
+}
+
+func configHash(configs map[string][]byte) string {
+	if len(configs) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(configs))
+	for k := range configs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	hasher := fnv.New32()
+	for _, k := range keys {
+		_, _ = hasher.Write([]byte(k))
+		_, _ = hasher.Write(configs[k])
+	}
+	return fmt.Sprintf("%x", hasher.Sum32())
+}
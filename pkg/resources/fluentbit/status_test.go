@@ -0,0 +1,39 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentbit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigHash(t *testing.T) {
+	assert.Empty(t, configHash(nil))
+
+	configs := map[string][]byte{
+		BaseConfigName:     []byte("[SERVICE]"),
+		UpstreamConfigName: []byte("[UPSTREAM]"),
+	}
+	hash := configHash(configs)
+	assert.NotEmpty(t, hash)
+
+	for range 10 {
+		assert.Equal(t, hash, configHash(configs), "hash must not depend on map iteration order")
+	}
+
+	configs[UpstreamConfigName] = []byte("[UPSTREAM]\n    name fluentd-upstream")
+	assert.NotEqual(t, hash, configHash(configs))
+}
@@ -39,6 +39,8 @@ type ConfigCheckResult struct {
 	Valid   bool
 	Ready   bool
 	Message string
+	// Output of the failed config check pod
+	Output string
 }
 
 func (r *Reconciler) appConfigSecret() (runtime.Object, reconciler.DesiredState, error) {
@@ -53,6 +55,13 @@ func (r *Reconciler) appConfigSecret() (runtime.Object, reconciler.DesiredState,
 	meta := r.FluentdObjectMeta(AppSecretConfigName, ComponentFluentd)
 	meta.Labels = utils.MergeLabels(meta.Labels, map[string]string{"logging.banzaicloud.io/watch": "enabled"})
 
+	hash, err := r.configHash()
+	if err != nil {
+		return nil, nil, err
+	}
+	// the hash of the applied config is used to tell whether the pods run with it
+	configcheck.WithHashLabel(&meta, hash)
+
 	return &corev1.Secret{
 		ObjectMeta: meta,
 		Data:       data,
@@ -183,8 +192,9 @@ func (r *Reconciler) configCheck(ctx context.Context) (*ConfigCheckResult, error
 			return &ConfigCheckResult{}, nil
 		case corev1.PodFailed:
 			return &ConfigCheckResult{
-				Ready: true,
-				Valid: false,
+				Ready:  true,
+				Valid:  false,
+				Output: configcheck.FailureOutput(pod),
 			}, nil
 		case corev1.PodUnknown:
 			fallthrough
@@ -392,6 +402,8 @@ func (r *Reconciler) containerCheckPod(fluentdSpec v1beta1.FluentdSpec) []corev1
 			ImagePullPolicy: corev1.PullPolicy(fluentdSpec.Image.PullPolicy),
 			Args:            containerArgs,
 			Env:             fluentdSpec.EnvVars,
+			// Keep the tail of the log as termination message to report why the check failed
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 			VolumeMounts: []corev1.VolumeMount{
 				{
 					Name:      "config",
@@ -15,8 +15,11 @@
 package fluentd
 
 const (
-	ComponentFluentd     = "fluentd"
-	ComponentConfigCheck = "fluentd-configcheck"
-	ComponentDrainer     = "fluentd-drainer"
-	ComponentPlaceholder = "fluentd-placeholder"
+	ComponentFluentd       = "fluentd"
+	ComponentConfigCheck   = "fluentd-configcheck"
+	ComponentConfigDiff    = "fluentd-config-diff"
+	ComponentConfigHistory = "fluentd-config-history"
+	ComponentConfigRollout = "fluentd-config-rollout"
+	ComponentDrainer       = "fluentd-drainer"
+	ComponentPlaceholder   = "fluentd-placeholder"
 )
@@ -0,0 +1,81 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentd
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kube-logging/logging-operator/pkg/compression"
+	"github.com/kube-logging/logging-operator/pkg/resources/configcheck"
+	"github.com/kube-logging/logging-operator/pkg/resources/configdiff"
+)
+
+const configDiffHistoryName = "fluentd-config-diff"
+
+// reconcileConfigDiff reports the flows, filters and outputs that change when the config replaces the applied one
+func (r *Reconciler) reconcileConfigDiff(ctx context.Context) error {
+	applied, err := r.appliedConfigSecret(ctx)
+	if err != nil || applied == nil {
+		return err
+	}
+	hash, err := r.configHash()
+	if err != nil {
+		return err
+	}
+	appliedHash := applied.Labels[configcheck.HashLabel]
+	if appliedHash == hash {
+		return nil
+	}
+
+	previous := string(applied.Data[AppConfigKey])
+	if compressed, ok := applied.Data[AppConfigKey+".gz"]; ok {
+		if previous, err = compression.DecompressString(compressed); err != nil {
+			return errors.WrapIf(err, "failed to decompress applied config")
+		}
+	}
+
+	diff := configdiff.Compute(configdiff.ParseFluentd(previous), configdiff.ParseFluentd(*r.config))
+	configdiff.RecordEvent(r.eventRecorder, r.Logging, appliedHash, hash, diff)
+
+	if spec := r.Logging.Spec.ConfigDiffHistory; spec != nil {
+		history := configdiff.NewHistory(r.Client, r.FluentdObjectMeta(configDiffHistoryName, ComponentConfigDiff), spec.Limit)
+		return history.Record(ctx, configdiff.Entry{
+			Time:         metav1.Now(),
+			PreviousHash: appliedHash,
+			Hash:         hash,
+			Diff:         diff,
+		})
+	}
+	return nil
+}
+
+// appliedConfigSecret returns the app config secret as it is in the cluster or nil if it doesn't exist yet
+func (r *Reconciler) appliedConfigSecret(ctx context.Context) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	meta := r.FluentdObjectMeta(AppSecretConfigName, ComponentFluentd)
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: meta.Namespace, Name: meta.Name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.WrapIf(err, "failed to get app config secret")
+	}
+	return secret, nil
+}
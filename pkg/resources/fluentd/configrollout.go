@@ -0,0 +1,86 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentd
+
+import (
+	"context"
+
+	"github.com/cisco-open/operator-tools/pkg/reconciler"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/rollout"
+)
+
+var defaultErrorMetrics = []string{"fluentd_output_status_num_errors", "fluentd_output_status_retry_count"}
+
+// reconcileConfigRollout decides which config the fluentd replicas run when the canary rollout strategy is used
+func (r *Reconciler) reconcileConfigRollout(ctx context.Context, patchBase client.Patch) (*reconcile.Result, error) {
+	config := rollout.Config{
+		Aggregator:        rolloutAggregator{r},
+		SecretName:        AppSecretConfigName,
+		RevisionComponent: ComponentConfigRollout,
+	}
+	decision, result, err := config.Reconcile(ctx, patchBase)
+	r.configRollout = decision
+	return result, err
+}
+
+// appConfigSecretName returns the name of the secret the pod template refers to for the app config
+func (r *Reconciler) appConfigSecretName() string {
+	return r.Logging.QualifiedName(rollout.SecretName(AppSecretConfigName, r.configRollout))
+}
+
+// rolloutAggregator provides the fluentd details of the config rollout
+type rolloutAggregator struct {
+	*Reconciler
+}
+
+func (r rolloutAggregator) Canary() *rollout.Canary {
+	if !r.fluentdSpec.ConfigRollout.IsCanary() {
+		return nil
+	}
+	canary := &rollout.Canary{
+		Client:       r.Client,
+		Recorder:     r.eventRecorder,
+		Log:          r.Log,
+		Logging:      r.Logging,
+		StatefulSet:  client.ObjectKey{Namespace: r.Logging.Spec.ControlNamespace, Name: r.Logging.QualifiedName(StatefulSetName)},
+		PodLabels:    r.Logging.GetFluentdLabels(ComponentFluentd, *r.fluentdSpec),
+		ErrorMetrics: defaultErrorMetrics,
+	}
+	if spec := r.fluentdSpec.ConfigRollout.Canary; spec != nil {
+		canary.Spec = *spec
+	}
+	if r.fluentdSpec.Metrics != nil {
+		canary.MetricsPort = r.fluentdSpec.Metrics.Port
+		canary.MetricsPath = r.fluentdSpec.GetFluentdMetricsPath()
+	}
+	return canary
+}
+
+func (r rolloutAggregator) ConfigHash() (string, error) {
+	return r.configHash()
+}
+
+func (r rolloutAggregator) ConfigRevision(name string) (runtime.Object, reconciler.DesiredState, error) {
+	revision, state, err := r.appConfigSecret()
+	if secret, ok := revision.(*corev1.Secret); ok {
+		secret.ObjectMeta = r.FluentdObjectMeta(name, ComponentConfigRollout)
+	}
+	return revision, state, err
+}
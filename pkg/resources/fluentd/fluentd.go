@@ -17,6 +17,7 @@ package fluentd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"emperror.dev/errors"
 	"github.com/cisco-open/operator-tools/pkg/reconciler"
@@ -31,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -38,6 +40,7 @@ import (
 	"github.com/kube-logging/logging-operator/pkg/resources"
 	"github.com/kube-logging/logging-operator/pkg/resources/configcheck"
 	"github.com/kube-logging/logging-operator/pkg/resources/kubetool"
+	"github.com/kube-logging/logging-operator/pkg/resources/rollout"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 )
 
@@ -72,8 +75,13 @@ type Reconciler struct {
 	fluentdSpec   *v1beta1.FluentdSpec
 	fluentdConfig *v1beta1.FluentdConfig
 	*reconciler.GenericResourceReconciler
-	config  *string
-	secrets *secret.MountSecrets
+	config        *string
+	secrets       *secret.MountSecrets
+	eventRecorder record.EventRecorder
+	// configRollout is set when the canary rollout strategy is used
+	configRollout *rollout.Decision
+	// configHistoryRequeue is set while the pods are not stable with a config that is not known to be good yet
+	configHistoryRequeue time.Duration
 }
 
 type Desire struct {
@@ -112,7 +120,7 @@ func (r *Reconciler) getServiceAccount() string {
 	return r.Logging.QualifiedName(defaultServiceAccountName)
 }
 
-func New(client client.Client, log logr.Logger,
+func New(client client.Client, log logr.Logger, eventRecorder record.EventRecorder,
 	logging *v1beta1.Logging, fluentdSpec *v1beta1.FluentdSpec, fluentdConfig *v1beta1.FluentdConfig, config *string, secrets *secret.MountSecrets, opts reconciler.ReconcilerOpts) *Reconciler {
 	return &Reconciler{
 		Logging:                   logging,
@@ -121,6 +129,7 @@ func New(client client.Client, log logr.Logger,
 		GenericResourceReconciler: reconciler.NewGenericReconciler(client, log, opts),
 		config:                    config,
 		secrets:                   secrets,
+		eventRecorder:             eventRecorder,
 	}
 }
 
@@ -167,7 +176,7 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*reconcile.Result, error) {
 				return nil, errors.WrapIf(err, "current config is invalid")
 			}
 			// clean the status so that we can rerun the check
-			return r.statusUpdate(ctx, patchBase, nil)
+			return r.statusUpdate(ctx, patchBase, hash, nil)
 		}
 
 		if result, ok := r.Logging.Status.ConfigCheckResults[hash]; ok {
@@ -181,7 +190,7 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*reconcile.Result, error) {
 				// Errors with the cleanup should not block the reconciliation, we just note it
 				r.Log.Error(err, "issues during configcheck cleanup, moving on")
 			} else if len(r.Logging.Status.ConfigCheckResults) > 1 {
-				return r.statusUpdate(ctx, patchBase, map[string]bool{
+				return r.statusUpdate(ctx, patchBase, hash, map[string]bool{
 					hash: result,
 				})
 			}
@@ -195,6 +204,10 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*reconcile.Result, error) {
 			}
 			if result.Ready {
 				r.Logging.Status.ConfigCheckResults[hash] = result.Valid
+				if !result.Valid {
+					configcheck.RecordFailure(r.eventRecorder, r.Logging, hash, result.Output)
+				}
+				configcheck.SetCondition(r.Logging, hash)
 				if err := r.Client.Status().Patch(ctx, r.Logging, patchBase); err != nil {
 					return nil, errors.WrapWithDetails(err, "failed to patch status", "logging", r.Logging)
 				} else {
@@ -211,6 +224,17 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*reconcile.Result, error) {
 			}
 		}
 	}
+	// Roll back to the last known good config if the current one crash-loops the pods
+	if res, err := r.reconcileConfigHistory(ctx, patchBase); res != nil || err != nil {
+		return res, err
+	}
+	if res, err := r.reconcileConfigRollout(ctx, patchBase); res != nil || err != nil {
+		return res, err
+	}
+	if err := r.reconcileConfigDiff(ctx); err != nil {
+		// Errors with the config diff should not block the reconciliation, we just note it
+		r.Log.Error(err, "issues during config diff, moving on")
+	}
 	// Prepare output secret
 	outputSecret, outputSecretDesiredState, err := r.outputSecret(r.secrets)
 	if err != nil {
@@ -275,11 +299,22 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*reconcile.Result, error) {
 		return res, err
 	}
 
+	if r.configRollout != nil && r.configRollout.RequeueAfter > 0 {
+		// check the canary replicas again
+		return &reconcile.Result{RequeueAfter: r.configRollout.RequeueAfter}, nil
+	}
+	if r.configHistoryRequeue > 0 {
+		// confirm the config once the pods are stable
+		return &reconcile.Result{RequeueAfter: r.configHistoryRequeue}, nil
+	}
+
 	return nil, nil
 }
 
-func (r *Reconciler) statusUpdate(ctx context.Context, patchBase client.Patch, result map[string]bool) (*reconcile.Result, error) {
+func (r *Reconciler) statusUpdate(ctx context.Context, patchBase client.Patch, hash string, result map[string]bool) (*reconcile.Result, error) {
 	r.Logging.Status.ConfigCheckResults = result
+	configcheck.PruneFailures(r.Logging)
+	configcheck.SetCondition(r.Logging, hash)
 	if err := r.Client.Status().Patch(ctx, r.Logging, patchBase); err != nil {
 		return nil, errors.WrapWithDetails(err, "failed to patch status", "logging", r.Logging)
 	} else {
@@ -0,0 +1,36 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentd
+
+import (
+	"github.com/kube-logging/logging-operator/pkg/resources/logquota"
+	"github.com/kube-logging/logging-operator/pkg/resources/model"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+// QuotaSource returns where the quota metrics of the fluentd pods can be scraped, or nil if metrics are disabled
+func QuotaSource(logging *v1beta1.Logging, fluentdSpec *v1beta1.FluentdSpec) *logquota.Source {
+	if fluentdSpec == nil || fluentdSpec.Metrics == nil {
+		return nil
+	}
+	return &logquota.Source{
+		Namespace:      logging.Spec.ControlNamespace,
+		PodLabels:      logging.GetFluentdLabels(ComponentFluentd, *fluentdSpec),
+		MetricsPort:    fluentdSpec.Metrics.Port,
+		MetricsPath:    fluentdSpec.GetFluentdMetricsPath(),
+		RecordsMetric:  model.FluentdQuotaRecordsMetric,
+		AcceptedMetric: model.FluentdQuotaAcceptedRecordsMetric,
+	}
+}
@@ -137,6 +137,15 @@ func (r *Reconciler) statefulsetSpec() *appsv1.StatefulSetSpec {
 		sts.Replicas = util.IntPointer(cast.ToInt32(r.fluentdSpec.Scaling.Replicas))
 	}
 
+	if r.configRollout != nil {
+		sts.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+				Partition: util.IntPointer(r.configRollout.Partition),
+			},
+		}
+	}
+
 	return sts
 }
 
@@ -329,7 +338,7 @@ func (r *Reconciler) generateVolume() (v []corev1.Volume) {
 			Name: "app-config-compress",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: r.Logging.QualifiedName(AppSecretConfigName),
+					SecretName: r.appConfigSecretName(),
 				},
 			},
 		})
@@ -338,7 +347,7 @@ func (r *Reconciler) generateVolume() (v []corev1.Volume) {
 			Name: "app-config",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: r.Logging.QualifiedName(AppSecretConfigName),
+					SecretName: r.appConfigSecretName(),
 				},
 			},
 		})
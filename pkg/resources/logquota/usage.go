@@ -0,0 +1,112 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logquota collects the consumption of the namespaces limited by log quotas from the metrics of the aggregators
+package logquota
+
+import (
+	"context"
+	"net/http"
+
+	"emperror.dev/errors"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/podmetrics"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+const namespaceLabel = "namespace"
+
+// Source is an aggregator whose pods expose the quota metrics
+type Source struct {
+	Namespace   string
+	PodLabels   client.MatchingLabels
+	MetricsPort int32
+	MetricsPath string
+	// RecordsMetric counts the records before and AcceptedMetric after the limits
+	RecordsMetric  string
+	AcceptedMetric string
+}
+
+// Usage is the number of records received and accepted by namespace
+type Usage struct {
+	Records  map[string]float64
+	Accepted map[string]float64
+}
+
+// Collect sums the quota metrics of the running pods of the aggregators by namespace
+func Collect(ctx context.Context, c client.Reader, httpClient *http.Client, sources ...Source) (Usage, error) {
+	usage := Usage{
+		Records:  make(map[string]float64),
+		Accepted: make(map[string]float64),
+	}
+	var errs error
+	for _, source := range sources {
+		pods, err := podmetrics.RunningPods(ctx, c, source.Namespace, source.PodLabels)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+		scraper := podmetrics.Scraper{HTTPClient: httpClient, Port: source.MetricsPort, Path: source.MetricsPath}
+		for _, pod := range pods {
+			families, err := scraper.Scrape(ctx, pod)
+			if err != nil {
+				errs = errors.Append(errs, err)
+				continue
+			}
+			usage.add(families, source)
+		}
+	}
+	return usage, errs
+}
+
+// add adds the samples of the quota metrics to the usage of the namespaces
+func (usage Usage) add(families podmetrics.Families, source Source) {
+	for name, values := range map[string]map[string]float64{
+		source.RecordsMetric:  usage.Records,
+		source.AcceptedMetric: usage.Accepted,
+	} {
+		families.Each(name, func(m *dto.Metric, value float64) {
+			if namespace := podmetrics.Label(m, namespaceLabel); namespace != "" {
+				values[namespace] += value
+			}
+		})
+	}
+}
+
+// UpdateStatus sets the usage of the namespaces of the quota.
+// A namespace is throttled if records have been dropped since the previous update.
+func UpdateStatus(status *v1beta1.LogQuotaStatus, usage Usage, now metav1.Time) {
+	previous := make(map[string]v1beta1.NamespaceQuotaUsage, len(status.Usage))
+	for _, u := range status.Usage {
+		previous[u.Namespace] = u
+	}
+
+	status.Usage = nil
+	for _, ns := range status.Namespaces {
+		records := int64(usage.Records[ns])
+		throttled := max(records-int64(usage.Accepted[ns]), 0)
+		prev, ok := previous[ns]
+		status.Usage = append(status.Usage, v1beta1.NamespaceQuotaUsage{
+			Namespace:        ns,
+			Records:          records,
+			ThrottledRecords: throttled,
+			// counters start over when the pods restart
+			Throttled: throttled > 0 && (!ok || throttled != prev.ThrottledRecords),
+		})
+	}
+	status.UsageUpdateTime = &now
+}
@@ -0,0 +1,75 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logquota
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/podmetrics"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func TestUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `# TYPE fluentd_quota_records_total counter`)
+		fmt.Fprintln(w, `fluentd_quota_records_total{namespace="a",quota="q"} 100`)
+		fmt.Fprintln(w, `fluentd_quota_records_total{namespace="b",quota="q"} 10`)
+		fmt.Fprintln(w, `# TYPE fluentd_quota_accepted_records_total counter`)
+		fmt.Fprintln(w, `fluentd_quota_accepted_records_total{namespace="a",quota="q"} 60`)
+		fmt.Fprintln(w, `fluentd_quota_accepted_records_total{namespace="b",quota="q"} 10`)
+	}))
+	defer server.Close()
+
+	families, err := podmetrics.Scraper{HTTPClient: server.Client()}.Get(context.Background(), server.URL)
+	require.NoError(t, err)
+	usage := Usage{Records: map[string]float64{"a": 1}, Accepted: map[string]float64{}}
+	usage.add(families, Source{
+		RecordsMetric:  "fluentd_quota_records_total",
+		AcceptedMetric: "fluentd_quota_accepted_records_total",
+	})
+	assert.Equal(t, map[string]float64{"a": 101, "b": 10}, usage.Records)
+	assert.Equal(t, map[string]float64{"a": 60, "b": 10}, usage.Accepted)
+}
+
+func TestUpdateStatus(t *testing.T) {
+	status := v1beta1.LogQuotaStatus{Namespaces: []string{"a", "b", "c"}}
+	usage := Usage{
+		Records:  map[string]float64{"a": 100, "b": 10},
+		Accepted: map[string]float64{"a": 60, "b": 10},
+	}
+	now := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	UpdateStatus(&status, usage, now)
+	assert.Equal(t, []v1beta1.NamespaceQuotaUsage{
+		{Namespace: "a", Records: 100, ThrottledRecords: 40, Throttled: true},
+		{Namespace: "b", Records: 10},
+		{Namespace: "c"},
+	}, status.Usage)
+	assert.Equal(t, &now, status.UsageUpdateTime)
+
+	// nothing has been dropped since the previous update
+	usage.Records["a"], usage.Accepted["a"] = 120, 80
+	UpdateStatus(&status, usage, now)
+	assert.Equal(t, v1beta1.NamespaceQuotaUsage{Namespace: "a", Records: 120, ThrottledRecords: 40}, status.Usage[0])
+}
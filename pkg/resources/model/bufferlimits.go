@@ -0,0 +1,406 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	syslogngoutput "github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/output"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+const (
+	defaultBufferUsagePercent = 90
+	// computed limits are rounded down to MiB
+	bufferLimitUnit = 1 << 20
+	// name of the syslog-ng volume that is guessed to hold the buffers
+	defaultSyslogNGBuffersVolumeName = "buffers"
+)
+
+// BufferLimits are the size limits of the buffers computed from the size of the buffer volume
+type BufferLimits struct {
+	// Limit of each buffer of the outputs in bytes
+	Limits map[OutputKey]int64
+	// Problems of the outputs
+	OutputProblems map[OutputKey][]string
+	// Problems of the logging
+	Problems []string
+}
+
+// LimitOf returns the computed limit of the buffers of the output, or an empty string if it has none
+func (l *BufferLimits) LimitOf(key OutputKey) string {
+	if l == nil {
+		return ""
+	}
+	if limit, ok := l.Limits[key]; ok {
+		return formatBufferSize(limit)
+	}
+	return ""
+}
+
+// ProblemsOf returns the problems found while computing the limits of the buffers of the output
+func (l *BufferLimits) ProblemsOf(key OutputKey) []string {
+	if l == nil {
+		return nil
+	}
+	return l.OutputProblems[key]
+}
+
+// DiskBufferProblemsOf returns a problem if the disk-buffer of the syslog-ng output has no size and no limit is computed for it either.
+// syslog-ng would get `disk_buf_size(0)` otherwise, which is below the minimum size of the disk-buffer.
+func (l *BufferLimits) DiskBufferProblemsOf(key OutputKey, spec *v1beta1.SyslogNGOutputSpec) []string {
+	diskBuffer := syslogNGDiskBuffer(spec)
+	if diskBuffer == nil || diskBuffer.DiskBufSize > 0 {
+		return nil
+	}
+	if l != nil {
+		if _, ok := l.Limits[key]; ok {
+			return nil
+		}
+		if len(l.OutputProblems[key]) > 0 {
+			// the reason why no limit is computed is reported already
+			return nil
+		}
+	}
+	return []string{"disk_buf_size of the disk-buffer is not set and no buffer limit is computed for it, set it or enable bufferLimits in the syslog-ng spec"}
+}
+
+// LoggingProblems returns the problems of the buffer limits that are not specific to an output
+func (l *BufferLimits) LoggingProblems() []string {
+	if l == nil {
+		return nil
+	}
+	return l.Problems
+}
+
+func (l *BufferLimits) addOutputProblem(key OutputKey, problem string) {
+	if l.OutputProblems == nil {
+		l.OutputProblems = make(map[OutputKey][]string)
+	}
+	l.OutputProblems[key] = append(l.OutputProblems[key], problem)
+}
+
+// bufferUsage is a buffer stored on the buffer volume
+type bufferUsage struct {
+	key   OutputKey
+	keyed bool
+	// weight of the buffer when sharing the space left by the explicitly limited buffers
+	weight int64
+	// explicit limit of the buffer in bytes, zero if not set
+	explicit int64
+	// apply sets the computed limit in bytes on the buffer
+	apply func(limit int64)
+}
+
+// computeBufferLimits shares the available space among the buffers without an explicit limit
+func computeBufferLimits(available int64, usages []bufferUsage) *BufferLimits {
+	limits := &BufferLimits{Limits: make(map[OutputKey]int64)}
+
+	var explicitTotal, totalWeight int64
+	for _, usage := range usages {
+		if usage.explicit > 0 {
+			explicitTotal += usage.explicit
+		} else {
+			totalWeight += usage.weight
+		}
+	}
+
+	if explicitTotal > available {
+		problem := fmt.Sprintf("explicit buffer limits add up to %s, more than the %s available for the buffers on the buffer volume",
+			formatBufferSize(explicitTotal), formatBufferSize(available))
+		limits.Problems = append(limits.Problems, problem)
+		for _, usage := range usages {
+			if usage.explicit > 0 && usage.keyed && len(limits.ProblemsOf(usage.key)) == 0 {
+				limits.addOutputProblem(usage.key, problem)
+			}
+		}
+		return limits
+	}
+	if totalWeight == 0 {
+		return limits
+	}
+
+	share := (available - explicitTotal) / totalWeight
+	for _, usage := range usages {
+		if usage.explicit > 0 {
+			continue
+		}
+		limit := share * usage.weight
+		limit -= limit % bufferLimitUnit
+		if limit == 0 {
+			if usage.keyed && len(limits.ProblemsOf(usage.key)) == 0 {
+				limits.addOutputProblem(usage.key, "not enough space left on the buffer volume for the buffer of the output")
+			}
+			continue
+		}
+		usage.apply(limit)
+		if usage.keyed {
+			limits.Limits[usage.key] = limit
+		}
+	}
+	return limits
+}
+
+// usableBufferSpace returns the part of the volume the buffers may use together
+func usableBufferSpace(volumeSize int64, spec *v1beta1.BufferLimits) int64 {
+	percent := int64(spec.UsagePercent)
+	if percent <= 0 || percent > 100 {
+		percent = defaultBufferUsagePercent
+	}
+	return volumeSize * percent / 100
+}
+
+func formatBufferSize(size int64) string {
+	return resource.NewQuantity(size, resource.BinarySI).String()
+}
+
+func bufferWeight(weight int32) int64 {
+	if weight < 1 {
+		return 1
+	}
+	return int64(weight)
+}
+
+// ApplyFluentdBufferLimits sets the `total_limit_size` of the file buffers of the system that have no explicit limit.
+// Every buffer rendered into the configuration is counted, so an output referenced by multiple flows gets a share for each of its buffers.
+// It returns nil if the buffer limits are not enabled.
+func ApplyFluentdBufferLimits(system *types.System, resources LoggingResources) *BufferLimits {
+	_, fluentdSpec := resources.GetFluentd()
+	if system == nil || fluentdSpec == nil || fluentdSpec.BufferLimits == nil {
+		return nil
+	}
+
+	volumeSize, ok := fluentdBufferVolumeSize(fluentdSpec)
+	if !ok {
+		return &BufferLimits{Problems: []string{"buffer limits are enabled, but the size of the buffer volume is unknown, set it in bufferLimits.volumeSize"}}
+	}
+	// every worker has its own instance of the buffers
+	workers := max(int64(fluentdSpec.Workers), 1)
+	available := usableBufferSpace(volumeSize, fluentdSpec.BufferLimits) / workers
+
+	var usages []bufferUsage
+	var problems []string
+	for _, flow := range system.Flows {
+		usages, problems = appendFluentdFlowBufferUsages(usages, problems, flow, resources)
+	}
+	limits := computeBufferLimits(available, usages)
+	limits.Problems = append(problems, limits.Problems...)
+	return limits
+}
+
+func appendFluentdFlowBufferUsages(usages []bufferUsage, problems []string, flow *types.Flow, resources LoggingResources) ([]bufferUsage, []string) {
+	for _, output := range flow.Outputs {
+		usages, problems = appendFluentdOutputBufferUsages(usages, problems, output, resources)
+	}
+	for _, route := range flow.Routes {
+		usages, problems = appendFluentdFlowBufferUsages(usages, problems, route, resources)
+	}
+	return usages, problems
+}
+
+func appendFluentdOutputBufferUsages(usages []bufferUsage, problems []string, output types.Directive, resources LoggingResources) ([]bufferUsage, []string) {
+	if relabelled, _ := output.(*types.RelabelledOutput); relabelled != nil {
+		for _, target := range relabelled.Target.Outputs {
+			usages, problems = appendFluentdOutputBufferUsages(usages, problems, target, resources)
+		}
+		return usages, problems
+	}
+
+	for _, section := range output.GetSections() {
+		buffer, _ := section.(*types.GenericDirective)
+		// memory buffers are not stored on the volume
+		if buffer == nil || buffer.Directive != "buffer" || !strings.HasPrefix(buffer.Type, "file") {
+			continue
+		}
+		key, keyed := OutputKeyFor(output.GetPluginMeta().Id)
+		usage := bufferUsage{
+			key:    key,
+			keyed:  keyed,
+			weight: fluentdBufferWeight(key, keyed, resources),
+			apply: func(limit int64) {
+				if buffer.Params == nil {
+					buffer.Params = make(map[string]string)
+				}
+				buffer.Params["total_limit_size"] = fmt.Sprintf("%dm", limit/bufferLimitUnit)
+			},
+		}
+		if size, ok := buffer.Params["total_limit_size"]; ok {
+			explicit, err := parseFluentdSize(size)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("output %s: %s", output.GetPluginMeta().Id, err))
+			}
+			// a buffer with an invalid limit is left as it is
+			usage.explicit = max(explicit, 1)
+		}
+		usages = append(usages, usage)
+	}
+	return usages, problems
+}
+
+func fluentdBufferWeight(key OutputKey, keyed bool, resources LoggingResources) int64 {
+	switch {
+	case !keyed:
+		return 1
+	case key.ClusterOutput:
+		if output := resources.Fluentd.ClusterOutputs.FindByName(key.Name); output != nil {
+			return bufferWeight(output.Spec.BufferWeight)
+		}
+	default:
+		if output := resources.Fluentd.Outputs.FindByNamespacedName(key.Namespace, key.Name); output != nil {
+			return bufferWeight(output.Spec.BufferWeight)
+		}
+	}
+	return 1
+}
+
+// fluentdBufferVolumeSize returns the size of the buffer volume of fluentd
+func fluentdBufferVolumeSize(spec *v1beta1.FluentdSpec) (int64, bool) {
+	switch volume := spec.BufferStorageVolume; {
+	case spec.BufferLimits.VolumeSize != nil:
+		return spec.BufferLimits.VolumeSize.Value(), true
+	case volume.PersistentVolumeClaim != nil:
+		storage, ok := volume.PersistentVolumeClaim.PersistentVolumeClaimSpec.Resources.Requests[corev1.ResourceStorage]
+		return storage.Value(), ok
+	case volume.EmptyDir != nil && volume.EmptyDir.SizeLimit != nil:
+		return volume.EmptyDir.SizeLimit.Value(), true
+	default:
+		return 0, false
+	}
+}
+
+// parseFluentdSize parses a size parameter of fluentd, like `512m`
+func parseFluentdSize(size string) (int64, error) {
+	s := strings.ToLower(strings.TrimSpace(size))
+	multiplier := int64(1)
+	if s != "" {
+		switch s[len(s)-1] {
+		case 'k':
+			multiplier = 1 << 10
+		case 'm':
+			multiplier = 1 << 20
+		case 'g':
+			multiplier = 1 << 30
+		case 't':
+			multiplier = 1 << 40
+		}
+		if multiplier > 1 {
+			s = s[:len(s)-1]
+		}
+	}
+	value, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || value < 0 {
+		return 0, errors.Errorf("invalid buffer size %q", size)
+	}
+	return value * multiplier, nil
+}
+
+// SyslogNGBufferLimits computes the `disk_buf_size` of the disk-buffers of the outputs without an explicit size.
+// It returns the outputs to render with the computed sizes set, the outputs of the resources are not modified.
+// The limits are nil and the outputs are returned as they are if the buffer limits are not enabled.
+func SyslogNGBufferLimits(resources LoggingResources) (*BufferLimits, SyslogNGClusterOutputs, SyslogNGOutputs) {
+	_, syslogNGSpec := resources.GetSyslogNGSpec()
+	if syslogNGSpec == nil || syslogNGSpec.BufferLimits == nil {
+		return nil, resources.SyslogNG.ClusterOutputs, resources.SyslogNG.Outputs
+	}
+
+	clusterOutputs := make(SyslogNGClusterOutputs, len(resources.SyslogNG.ClusterOutputs))
+	for i := range resources.SyslogNG.ClusterOutputs {
+		resources.SyslogNG.ClusterOutputs[i].DeepCopyInto(&clusterOutputs[i])
+	}
+	outputs := make(SyslogNGOutputs, len(resources.SyslogNG.Outputs))
+	for i := range resources.SyslogNG.Outputs {
+		resources.SyslogNG.Outputs[i].DeepCopyInto(&outputs[i])
+	}
+
+	volumeSize, ok := syslogNGBufferVolumeSize(syslogNGSpec)
+	if !ok {
+		return &BufferLimits{Problems: []string{"buffer limits are enabled, but the size of the buffer volume is unknown, set it in bufferLimits.volumeSize"}}, clusterOutputs, outputs
+	}
+
+	var usages []bufferUsage
+	for i := range clusterOutputs {
+		output := &clusterOutputs[i]
+		key := OutputKey{ClusterOutput: true, Namespace: output.Namespace, Name: output.Name}
+		usages = appendSyslogNGBufferUsage(usages, key, &output.Spec.SyslogNGOutputSpec)
+	}
+	for i := range outputs {
+		output := &outputs[i]
+		key := OutputKey{Namespace: output.Namespace, Name: output.Name}
+		usages = appendSyslogNGBufferUsage(usages, key, &output.Spec)
+	}
+	return computeBufferLimits(usableBufferSpace(volumeSize, syslogNGSpec.BufferLimits), usages), clusterOutputs, outputs
+}
+
+func appendSyslogNGBufferUsage(usages []bufferUsage, key OutputKey, spec *v1beta1.SyslogNGOutputSpec) []bufferUsage {
+	diskBuffer := syslogNGDiskBuffer(spec)
+	if diskBuffer == nil {
+		return usages
+	}
+	return append(usages, bufferUsage{
+		key:      key,
+		keyed:    true,
+		weight:   bufferWeight(spec.BufferWeight),
+		explicit: diskBuffer.DiskBufSize,
+		apply: func(limit int64) {
+			diskBuffer.DiskBufSize = limit
+		},
+	})
+}
+
+// syslogNGDiskBuffer returns the disk-buffer of the destination driver of the output
+func syslogNGDiskBuffer(spec *v1beta1.SyslogNGOutputSpec) *syslogngoutput.DiskBuffer {
+	v := reflect.ValueOf(spec).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		driver := v.Field(i)
+		if driver.Kind() != reflect.Ptr || driver.IsNil() || driver.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		if field := driver.Elem().FieldByName("DiskBuffer"); field.IsValid() {
+			if diskBuffer, _ := field.Interface().(*syslogngoutput.DiskBuffer); diskBuffer != nil {
+				return diskBuffer
+			}
+		}
+	}
+	return nil
+}
+
+// syslogNGBufferVolumeSize returns the size of the claim template of the volume guessed to hold the buffers of syslog-ng
+func syslogNGBufferVolumeSize(spec *v1beta1.SyslogNGSpec) (int64, bool) {
+	if spec.BufferLimits.VolumeSize != nil {
+		return spec.BufferLimits.VolumeSize.Value(), true
+	}
+	name := defaultSyslogNGBuffersVolumeName
+	if spec.BufferVolumeMetrics != nil && spec.BufferVolumeMetrics.MountName != "" {
+		name = spec.BufferVolumeMetrics.MountName
+	}
+	if spec.StatefulSetOverrides == nil {
+		return 0, false
+	}
+	for _, template := range spec.StatefulSetOverrides.Spec.VolumeClaimTemplates {
+		if template.Name == name {
+			storage, ok := template.Spec.Resources.Requests[corev1.ResourceStorage]
+			return storage.Value(), ok
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,221 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/typeoverride"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/output"
+	syslogngoutput "github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/output"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+func TestFluentdBufferLimits(t *testing.T) {
+	volumeSize := resource.MustParse("1000Mi")
+	resources := LoggingResources{
+		Logging: v1beta1.Logging{Spec: v1beta1.LoggingSpec{FluentdSpec: &v1beta1.FluentdSpec{
+			BufferLimits: &v1beta1.BufferLimits{VolumeSize: &volumeSize, UsagePercent: 100},
+		}}},
+		Fluentd: FluentdLoggingResources{
+			Outputs: Outputs{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns"},
+					Spec:       v1beta1.OutputSpec{FileOutput: &output.FileOutputConfig{Path: "/tmp/loki"}, BufferWeight: 3},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "ns"},
+					Spec:       v1beta1.OutputSpec{FileOutput: &output.FileOutputConfig{Path: "/tmp/backup"}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "archive", Namespace: "ns"},
+					Spec: v1beta1.OutputSpec{FileOutput: &output.FileOutputConfig{
+						Path:   "/tmp/archive",
+						Buffer: &output.Buffer{TotalLimitSize: "200m"},
+					}},
+				},
+			},
+		},
+	}
+	flow := v1beta1.Flow{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Spec: v1beta1.FlowSpec{
+			FlowLabel:       "@test",
+			LocalOutputRefs: []string{"loki", "backup", "archive"},
+		},
+	}
+	result, err := FlowForFlow(flow, resources.Fluentd.ClusterOutputs, resources.Fluentd.Outputs, testSecretLoaderFactory{})
+	require.NoError(t, err)
+	system := &types.System{Flows: []*types.Flow{result}}
+
+	limits := ApplyFluentdBufferLimits(system, resources)
+	require.NotNil(t, limits)
+	assert.Empty(t, limits.LoggingProblems())
+
+	totalLimitSizes := map[string]string{}
+	for _, directive := range result.Outputs {
+		for _, section := range directive.GetSections() {
+			if buffer, _ := section.(*types.GenericDirective); buffer != nil && buffer.Directive == "buffer" {
+				totalLimitSizes[directive.GetPluginMeta().Id] = buffer.Params["total_limit_size"]
+			}
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"flow:ns:test:output:ns:loki":    "600m",
+		"flow:ns:test:output:ns:backup":  "200m",
+		"flow:ns:test:output:ns:archive": "200m",
+	}, totalLimitSizes)
+	assert.Equal(t, "600Mi", limits.LimitOf(OutputKey{Namespace: "ns", Name: "loki"}))
+	assert.Equal(t, "200Mi", limits.LimitOf(OutputKey{Namespace: "ns", Name: "backup"}))
+	assert.Equal(t, "", limits.LimitOf(OutputKey{Namespace: "ns", Name: "archive"}))
+}
+
+func TestFluentdBufferLimitsProblems(t *testing.T) {
+	resources := LoggingResources{
+		Logging: v1beta1.Logging{Spec: v1beta1.LoggingSpec{FluentdSpec: &v1beta1.FluentdSpec{
+			BufferLimits: &v1beta1.BufferLimits{},
+		}}},
+	}
+	limits := ApplyFluentdBufferLimits(&types.System{}, resources)
+	assert.Equal(t, []string{"buffer limits are enabled, but the size of the buffer volume is unknown, set it in bufferLimits.volumeSize"}, limits.LoggingProblems())
+
+	volumeSize := resource.MustParse("100Mi")
+	resources.Logging.Spec.FluentdSpec.BufferLimits.VolumeSize = &volumeSize
+	resources.Fluentd.Outputs = Outputs{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "archive", Namespace: "ns"},
+			Spec: v1beta1.OutputSpec{FileOutput: &output.FileOutputConfig{
+				Path:   "/tmp/archive",
+				Buffer: &output.Buffer{TotalLimitSize: "1g"},
+			}},
+		},
+	}
+	flow := v1beta1.Flow{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Spec:       v1beta1.FlowSpec{FlowLabel: "@test", LocalOutputRefs: []string{"archive"}},
+	}
+	result, err := FlowForFlow(flow, nil, resources.Fluentd.Outputs, testSecretLoaderFactory{})
+	require.NoError(t, err)
+
+	limits = ApplyFluentdBufferLimits(&types.System{Flows: []*types.Flow{result}}, resources)
+	problem := "explicit buffer limits add up to 1Gi, more than the 90Mi available for the buffers on the buffer volume"
+	assert.Equal(t, []string{problem}, limits.LoggingProblems())
+	assert.Equal(t, []string{problem}, limits.ProblemsOf(OutputKey{Namespace: "ns", Name: "archive"}))
+
+	assert.Nil(t, ApplyFluentdBufferLimits(&types.System{}, LoggingResources{
+		Logging: v1beta1.Logging{Spec: v1beta1.LoggingSpec{FluentdSpec: &v1beta1.FluentdSpec{}}},
+	}))
+}
+
+func TestSyslogNGBufferLimits(t *testing.T) {
+	resources := LoggingResources{
+		Logging: v1beta1.Logging{Spec: v1beta1.LoggingSpec{SyslogNGSpec: &v1beta1.SyslogNGSpec{
+			BufferLimits: &v1beta1.BufferLimits{UsagePercent: 50},
+			StatefulSetOverrides: &typeoverride.StatefulSet{
+				Spec: typeoverride.StatefulSetSpec{
+					VolumeClaimTemplates: []typeoverride.PersistentVolumeClaim{{
+						EmbeddedPersistentVolumeClaimObjectMeta: typeoverride.EmbeddedPersistentVolumeClaimObjectMeta{Name: "buffers"},
+						Spec: corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{
+								corev1.ResourceStorage: resource.MustParse("2Gi"),
+							}},
+						},
+					}},
+				},
+			},
+		}}},
+		SyslogNG: SyslogNGLoggingResources{
+			ClusterOutputs: SyslogNGClusterOutputs{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "archive", Namespace: "control"},
+					Spec: v1beta1.SyslogNGClusterOutputSpec{SyslogNGOutputSpec: v1beta1.SyslogNGOutputSpec{
+						Syslog: &syslogngoutput.SyslogOutput{
+							Host:       "archive",
+							DiskBuffer: &syslogngoutput.DiskBuffer{DiskBufSize: 256 << 20},
+						},
+					}},
+				},
+			},
+			Outputs: SyslogNGOutputs{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns"},
+					Spec: v1beta1.SyslogNGOutputSpec{
+						Syslog:       &syslogngoutput.SyslogOutput{Host: "loki", DiskBuffer: &syslogngoutput.DiskBuffer{}},
+						BufferWeight: 2,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "ns"},
+					Spec: v1beta1.SyslogNGOutputSpec{
+						Syslog: &syslogngoutput.SyslogOutput{Host: "backup", DiskBuffer: &syslogngoutput.DiskBuffer{}},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "memory", Namespace: "ns"},
+					Spec: v1beta1.SyslogNGOutputSpec{
+						Syslog: &syslogngoutput.SyslogOutput{Host: "memory"},
+					},
+				},
+			},
+		},
+	}
+
+	limits, clusterOutputs, outputs := SyslogNGBufferLimits(resources)
+	require.NotNil(t, limits)
+	assert.Empty(t, limits.LoggingProblems())
+
+	// 1Gi is available, 768Mi of it is left after the explicit disk-buffer of the cluster output
+	assert.Equal(t, int64(256<<20), clusterOutputs[0].Spec.Syslog.DiskBuffer.DiskBufSize)
+	assert.Equal(t, int64(512<<20), outputs[0].Spec.Syslog.DiskBuffer.DiskBufSize)
+	assert.Equal(t, int64(256<<20), outputs[1].Spec.Syslog.DiskBuffer.DiskBufSize)
+	assert.Nil(t, outputs[2].Spec.Syslog.DiskBuffer)
+	assert.Equal(t, "512Mi", limits.LimitOf(OutputKey{Namespace: "ns", Name: "loki"}))
+
+	// the outputs of the resources are not modified
+	assert.Equal(t, int64(0), resources.SyslogNG.Outputs[0].Spec.Syslog.DiskBuffer.DiskBufSize)
+
+	assert.Empty(t, limits.DiskBufferProblemsOf(OutputKey{Namespace: "ns", Name: "loki"}, &resources.SyslogNG.Outputs[0].Spec))
+	assert.Empty(t, limits.DiskBufferProblemsOf(OutputKey{Namespace: "ns", Name: "memory"}, &resources.SyslogNG.Outputs[2].Spec))
+}
+
+func TestSyslogNGDiskBufferWithoutSize(t *testing.T) {
+	spec := v1beta1.SyslogNGOutputSpec{
+		Syslog: &syslogngoutput.SyslogOutput{Host: "loki", DiskBuffer: &syslogngoutput.DiskBuffer{}},
+	}
+	key := OutputKey{Namespace: "ns", Name: "loki"}
+	problem := "disk_buf_size of the disk-buffer is not set and no buffer limit is computed for it, set it or enable bufferLimits in the syslog-ng spec"
+
+	// the buffer limits are not enabled
+	var limits *BufferLimits
+	assert.Equal(t, []string{problem}, limits.DiskBufferProblemsOf(key, &spec))
+
+	// the size of the buffer volume is unknown
+	limits = &BufferLimits{Problems: []string{"buffer limits are enabled, but the size of the buffer volume is unknown, set it in bufferLimits.volumeSize"}}
+	assert.Equal(t, []string{problem}, limits.DiskBufferProblemsOf(key, &spec))
+
+	// the reason is reported already
+	limits.addOutputProblem(key, "not enough space left on the buffer volume for the buffer of the output")
+	assert.Empty(t, limits.DiskBufferProblemsOf(key, &spec))
+
+	spec.Syslog.DiskBuffer.DiskBufSize = 1 << 20
+	assert.Empty(t, (*BufferLimits)(nil).DiskBufferProblemsOf(key, &spec))
+}
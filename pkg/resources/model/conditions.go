@@ -0,0 +1,93 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/configcheck"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func setCondition(conditions *[]metav1.Condition, generation int64, conditionType string, ok bool, reason string, message string) {
+	status := metav1.ConditionFalse
+	if ok {
+		status = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// setReadyCondition sets the Ready condition based on the problems reported for the resource
+func setReadyCondition(conditions *[]metav1.Condition, generation int64, problems []string) {
+	if len(problems) == 0 {
+		setCondition(conditions, generation, v1beta1.ConditionReady, true, v1beta1.ReasonReady, "resource has no problems")
+		return
+	}
+	setCondition(conditions, generation, v1beta1.ConditionReady, false, v1beta1.ReasonProblemsFound, strings.Join(problems, "; "))
+}
+
+// setOutputsResolvedCondition sets the OutputsResolved condition of a flow based on the output reference problems
+func setOutputsResolvedCondition(conditions *[]metav1.Condition, generation int64, refProblems []string) {
+	if len(refProblems) == 0 {
+		setCondition(conditions, generation, v1beta1.ConditionOutputsResolved, true, v1beta1.ReasonOutputsResolved, "all referenced outputs are valid")
+		return
+	}
+	setCondition(conditions, generation, v1beta1.ConditionOutputsResolved, false, v1beta1.ReasonOutputsUnresolved, strings.Join(refProblems, "; "))
+}
+
+// setConfigCheckCondition sets the ConfigCheckPassed condition of the logging resource if the config check is disabled,
+// otherwise it is left to the aggregator reconcilers that know the hash of the current config.
+// Loggings without an aggregator have no config to check, so they get no condition.
+func setConfigCheckCondition(resources *LoggingResources) {
+	logging := &resources.Logging
+	_, fluentdSpec := resources.GetFluentd()
+	_, syslogNGSpec := resources.GetSyslogNGSpec()
+
+	switch {
+	case fluentdSpec == nil && syslogNGSpec == nil:
+		configcheck.RemoveCondition(logging)
+	case logging.Spec.FlowConfigCheckDisabled:
+		configcheck.SetCondition(logging, "")
+	}
+}
+
+// setAggregatorCondition sets the AggregatorAvailable condition of the logging resource
+func setAggregatorCondition(resources *LoggingResources) {
+	logging := &resources.Logging
+	_, fluentdSpec := resources.GetFluentd()
+	_, syslogNGSpec := resources.GetSyslogNGSpec()
+
+	switch {
+	case len(resources.Fluentd.ExcessFluentds) > 0 || len(resources.SyslogNG.ExcessSyslogNGs) > 0:
+		setCondition(&logging.Status.Conditions, logging.Generation, v1beta1.ConditionAggregatorAvailable, false, v1beta1.ReasonAggregatorAmbiguous, "multiple aggregator configurations found")
+	case fluentdSpec != nil && syslogNGSpec != nil:
+		setCondition(&logging.Status.Conditions, logging.Generation, v1beta1.ConditionAggregatorAvailable, false, v1beta1.ReasonAggregatorAmbiguous, "fluentd and syslog-ng cannot be enabled simultaneously")
+	case fluentdSpec != nil:
+		setCondition(&logging.Status.Conditions, logging.Generation, v1beta1.ConditionAggregatorAvailable, true, v1beta1.ReasonAggregatorConfigured, "fluentd aggregator is configured")
+	case syslogNGSpec != nil:
+		setCondition(&logging.Status.Conditions, logging.Generation, v1beta1.ConditionAggregatorAvailable, true, v1beta1.ReasonAggregatorConfigured, "syslog-ng aggregator is configured")
+	default:
+		setCondition(&logging.Status.Conditions, logging.Generation, v1beta1.ConditionAggregatorAvailable, false, v1beta1.ReasonAggregatorMissing, "no aggregator is configured")
+	}
+}
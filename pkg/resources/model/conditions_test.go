@@ -0,0 +1,90 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func TestSetReadyCondition(t *testing.T) {
+	var conditions []metav1.Condition
+
+	setReadyCondition(&conditions, 2, []string{"dangling global output reference: a", "no output target configured"})
+	ready := meta.FindStatusCondition(conditions, v1beta1.ConditionReady)
+	assert.Equal(t, metav1.ConditionFalse, ready.Status)
+	assert.Equal(t, v1beta1.ReasonProblemsFound, ready.Reason)
+	assert.Equal(t, "dangling global output reference: a; no output target configured", ready.Message)
+	assert.Equal(t, int64(2), ready.ObservedGeneration)
+
+	setReadyCondition(&conditions, 3, nil)
+	ready = meta.FindStatusCondition(conditions, v1beta1.ConditionReady)
+	assert.Len(t, conditions, 1)
+	assert.Equal(t, metav1.ConditionTrue, ready.Status)
+	assert.Equal(t, v1beta1.ReasonReady, ready.Reason)
+	assert.Equal(t, int64(3), ready.ObservedGeneration)
+}
+
+func TestSetAggregatorCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources LoggingResources
+		status    metav1.ConditionStatus
+		reason    string
+	}{
+		{
+			name:   "no aggregator",
+			status: metav1.ConditionFalse,
+			reason: v1beta1.ReasonAggregatorMissing,
+		},
+		{
+			name: "inline fluentd",
+			resources: LoggingResources{
+				Logging: v1beta1.Logging{Spec: v1beta1.LoggingSpec{FluentdSpec: &v1beta1.FluentdSpec{}}},
+			},
+			status: metav1.ConditionTrue,
+			reason: v1beta1.ReasonAggregatorConfigured,
+		},
+		{
+			name: "detached syslog-ng",
+			resources: LoggingResources{
+				SyslogNG: SyslogNGLoggingResources{Configuration: &v1beta1.SyslogNGConfig{}},
+			},
+			status: metav1.ConditionTrue,
+			reason: v1beta1.ReasonAggregatorConfigured,
+		},
+		{
+			name: "excess fluentd configurations",
+			resources: LoggingResources{
+				Fluentd: FluentdLoggingResources{ExcessFluentds: []v1beta1.FluentdConfig{{}, {}}},
+			},
+			status: metav1.ConditionFalse,
+			reason: v1beta1.ReasonAggregatorAmbiguous,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setAggregatorCondition(&tt.resources)
+			condition := meta.FindStatusCondition(tt.resources.Logging.Status.Conditions, v1beta1.ConditionAggregatorAvailable)
+			assert.Equal(t, tt.status, condition.Status)
+			assert.Equal(t, tt.reason, condition.Reason)
+		})
+	}
+}
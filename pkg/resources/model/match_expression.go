@@ -0,0 +1,465 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"math/bits"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/maps/mapstrstr"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/filter"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/ruby"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/plugins"
+)
+
+const (
+	// maxRouteSelectors is the number of distinct selectors up to which an expression is translated into a route.
+	// The route lists every combination of the selectors, so it grows exponentially.
+	maxRouteSelectors = 4
+	// matchExpressionKey holds the result of the expression evaluated by the filters
+	matchExpressionKey = "_flow_match_expression"
+)
+
+// MatchExpressionProblems checks the structure and the namespace regular expressions of a match expression
+func MatchExpressionProblems(expr *v1beta1.FlowMatchExpression) (problems []string) {
+	if expr == nil {
+		return nil
+	}
+	set := 0
+	for _, isSet := range []bool{len(expr.And) > 0, len(expr.Or) > 0, expr.Not != nil, !reflect.DeepEqual(selectorOf(expr), selector{})} {
+		if isSet {
+			set++
+		}
+	}
+	switch set {
+	case 0:
+		problems = append(problems, "empty match expression")
+	case 1:
+	default:
+		problems = append(problems, "match expression should set exactly one of and, or, not or the selector fields")
+	}
+	for _, re := range expr.NamespacesRegex {
+		if _, err := regexp.Compile(re); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid namespaces_regex %q: %s", re, err))
+		}
+	}
+	problems = append(problems, LabelExpressionProblems(expr.LabelExpressions)...)
+	for i := range expr.And {
+		problems = append(problems, MatchExpressionProblems(&expr.And[i])...)
+	}
+	for i := range expr.Or {
+		problems = append(problems, MatchExpressionProblems(&expr.Or[i])...)
+	}
+	return append(problems, MatchExpressionProblems(expr.Not)...)
+}
+
+// LabelExpressionProblems checks the values and the regular expressions of label expressions
+func LabelExpressionProblems(exprs []v1beta1.LabelExpression) (problems []string) {
+	for _, expr := range exprs {
+		switch expr.Operator {
+		case v1beta1.LabelOpIn, v1beta1.LabelOpNotIn:
+			if len(expr.Values) == 0 {
+				problems = append(problems, fmt.Sprintf("label expression on %q: operator %s requires values", expr.Key, expr.Operator))
+			}
+		case v1beta1.LabelOpExists, v1beta1.LabelOpDoesNotExist:
+			if len(expr.Values) > 0 {
+				problems = append(problems, fmt.Sprintf("label expression on %q: operator %s does not take values", expr.Key, expr.Operator))
+			}
+		case v1beta1.LabelOpRegex:
+			if len(expr.Values) == 0 {
+				problems = append(problems, fmt.Sprintf("label expression on %q: operator %s requires values", expr.Key, expr.Operator))
+			}
+			for _, re := range expr.Values {
+				if _, err := regexp.Compile(re); err != nil {
+					problems = append(problems, fmt.Sprintf("label expression on %q: invalid regex %q: %s", expr.Key, re, err))
+				}
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("label expression on %q: unknown operator %q", expr.Key, expr.Operator))
+		}
+	}
+	return
+}
+
+// selector is a match of a route extended with the conditions label-router cannot evaluate
+type selector struct {
+	types.FlowMatch
+	Annotations      map[string]string
+	LabelExpressions []v1beta1.LabelExpression
+}
+
+// routable returns true if label-router can evaluate the selector
+func (s selector) routable() bool {
+	return len(s.Annotations) == 0 && len(s.LabelExpressions) == 0
+}
+
+// matchSelectors returns the matches of the selectors if label-router can evaluate all of them.
+// Otherwise the route selects the whole scope and the returned filters evaluate the selectors in order,
+// the first selector that matches decides the way label-router does.
+func matchSelectors(selectors []selector, scope types.FlowMatch, flowID string) ([]types.FlowMatch, []types.Filter, error) {
+	var matches []types.FlowMatch
+	routable := true
+	for _, s := range selectors {
+		matches = append(matches, s.FlowMatch)
+		routable = routable && s.routable()
+	}
+	if routable {
+		return matches, nil, nil
+	}
+	if selectsNamespaceLabels(selectors) {
+		return nil, nil, errors.New("namespace_labels can't be used together with annotations or label_expressions in the selectors, since they are evaluated by filters then")
+	}
+
+	condition := "false"
+	for i := len(selectors) - 1; i >= 0; i-- {
+		condition = fmt.Sprintf("(%s ? %t : %s)", rubySelector(selectors[i]), !selectors[i].Negate, condition)
+	}
+	filters, err := conditionFilters(condition, flowID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []types.FlowMatch{scope}, filters, nil
+}
+
+// matchExpression translates a match expression limited to the scope into the matches of a label-router route.
+// If the route cannot express it, the route selects the whole scope and the returned filters drop the records
+// that don't match the expression.
+func matchExpression(expr *v1beta1.FlowMatchExpression, scope types.FlowMatch, flowID string) ([]types.FlowMatch, []types.Filter, error) {
+	if problems := MatchExpressionProblems(expr); len(problems) > 0 {
+		return nil, nil, errors.Errorf("invalid match expression: %s", strings.Join(problems, ", "))
+	}
+
+	var selectors []selector
+	collectSelectors(expr, &selectors)
+	if len(selectors) <= maxRouteSelectors && !slices.ContainsFunc(selectors, func(s selector) bool { return !s.routable() }) {
+		matches, ok := routeMatches(expr, selectors, scope)
+		if ok {
+			if len(matches) == 0 {
+				return nil, nil, errors.New("match expression never matches")
+			}
+			return matches, nil, nil
+		}
+	}
+
+	if selectsNamespaceLabels(selectors) {
+		return nil, nil, errors.Errorf(
+			"namespace_labels can't be used in a match expression evaluated by filters, the route evaluates it only with at most %d distinct selectors, "+
+				"no annotations or label_expressions, and no different namespaces_regex in selectors that match together", maxRouteSelectors)
+	}
+	filters, err := conditionFilters(rubyCondition(expr), flowID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []types.FlowMatch{scope}, filters, nil
+}
+
+// selectsNamespaceLabels returns true if a selector selects namespace labels.
+// The records carry no namespace labels, so only label-router can evaluate them, the filters can't.
+func selectsNamespaceLabels(selectors []selector) bool {
+	return slices.ContainsFunc(selectors, func(s selector) bool { return len(s.NamespaceLabels) > 0 })
+}
+
+// conditionFilters returns the filters that drop the records the Ruby condition is false for
+func conditionFilters(condition string, flowID string) ([]types.Filter, error) {
+	converters := []plugins.DirectiveConverter{
+		&filter.RecordModifier{
+			Records: []filter.Record{{matchExpressionKey: fmt.Sprintf("${%s}", condition)}},
+		},
+		&filter.GrepConfig{
+			Regexp: []filter.RegexpSection{{Key: matchExpressionKey, Pattern: "/^true$/"}},
+		},
+		&filter.RecordModifier{
+			RemoveKeys: matchExpressionKey,
+		},
+	}
+	var filters []types.Filter
+	for i, converter := range converters {
+		directive, err := converter.ToDirective(nil, fmt.Sprintf("%s:match:%d", flowID, i))
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, directive)
+	}
+	return filters, nil
+}
+
+func selectorOf(expr *v1beta1.FlowMatchExpression) selector {
+	return selector{
+		FlowMatch: types.FlowMatch{
+			Labels:          expr.Labels,
+			NamespaceLabels: expr.NamespaceLabels,
+			Namespaces:      expr.Namespaces,
+			NamespacesRegex: expr.NamespacesRegex,
+			Hosts:           expr.Hosts,
+			ContainerNames:  expr.ContainerNames,
+		},
+		Annotations:      expr.Annotations,
+		LabelExpressions: expr.LabelExpressions,
+	}
+}
+
+func collectSelectors(expr *v1beta1.FlowMatchExpression, selectors *[]selector) {
+	if expr == nil {
+		return
+	}
+	if expr.IsSelector() {
+		leaf := selectorOf(expr)
+		if !slices.ContainsFunc(*selectors, func(s selector) bool { return reflect.DeepEqual(s, leaf) }) {
+			*selectors = append(*selectors, leaf)
+		}
+		return
+	}
+	for i := range expr.And {
+		collectSelectors(&expr.And[i], selectors)
+	}
+	for i := range expr.Or {
+		collectSelectors(&expr.Or[i], selectors)
+	}
+	collectSelectors(expr.Not, selectors)
+}
+
+// evaluate returns the value of the expression when exactly the selectors in the set match
+func evaluate(expr *v1beta1.FlowMatchExpression, selectors []selector, set uint) bool {
+	switch {
+	case len(expr.And) > 0:
+		for i := range expr.And {
+			if !evaluate(&expr.And[i], selectors, set) {
+				return false
+			}
+		}
+		return true
+	case len(expr.Or) > 0:
+		for i := range expr.Or {
+			if evaluate(&expr.Or[i], selectors, set) {
+				return true
+			}
+		}
+		return false
+	case expr.Not != nil:
+		return !evaluate(expr.Not, selectors, set)
+	default:
+		leaf := selectorOf(expr)
+		for i, s := range selectors {
+			if reflect.DeepEqual(s, leaf) {
+				return set&(1<<i) != 0
+			}
+		}
+		return false
+	}
+}
+
+type routeEntry struct {
+	set   uint
+	match types.FlowMatch
+}
+
+// routeMatches builds a decision list from the truth table of the expression.
+// Label-router takes the first match of a route that selects the record, so listing every combination of
+// the selectors from the largest to the smallest decides each combination by its own entry.
+// It returns false if two selectors of a combination cannot be merged into one match.
+func routeMatches(expr *v1beta1.FlowMatchExpression, selectors []selector, scope types.FlowMatch) ([]types.FlowMatch, bool) {
+	var sets []uint
+	for set := uint(0); set < 1<<len(selectors); set++ {
+		sets = append(sets, set)
+	}
+	sort.SliceStable(sets, func(i, j int) bool {
+		return bits.OnesCount(sets[i]) > bits.OnesCount(sets[j])
+	})
+
+	var entries []routeEntry
+	for _, set := range sets {
+		match := scope
+		satisfiable := true
+		for i, s := range selectors {
+			if set&(1<<i) == 0 {
+				continue
+			}
+			var ok bool
+			if match, satisfiable, ok = mergeMatches(match, s.FlowMatch); !ok {
+				return nil, false
+			}
+			if !satisfiable {
+				break
+			}
+		}
+		if !satisfiable {
+			// the selectors never match together, nothing to decide
+			continue
+		}
+		match.Negate = !evaluate(expr, selectors, set)
+		entries = append(entries, routeEntry{set: set, match: match})
+	}
+
+	// an exclusion is only needed if a later entry would select the records it excludes
+	var matches []types.FlowMatch
+	var selected []uint
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if entry.match.Negate && !slices.ContainsFunc(selected, func(set uint) bool { return set&entry.set == set }) {
+			continue
+		}
+		if !entry.match.Negate {
+			selected = append(selected, entry.set)
+		}
+		matches = append(matches, entry.match)
+	}
+	slices.Reverse(matches)
+	return matches, true
+}
+
+// mergeMatches returns the match that selects the records selected by both matches.
+// It returns false for satisfiable if no record can match both, and false for ok if a single match cannot express it.
+func mergeMatches(a, b types.FlowMatch) (match types.FlowMatch, satisfiable bool, ok bool) {
+	satisfiable = true
+	mergeLabels := func(x, y map[string]string) map[string]string {
+		for k, v := range y {
+			if xv, exists := x[k]; exists && xv != v {
+				satisfiable = false
+			}
+		}
+		return mapstrstr.MergeInto(mapstrstr.MergeInto(nil, x), y)
+	}
+	intersect := func(x, y []string) []string {
+		if len(x) == 0 {
+			return y
+		}
+		if len(y) == 0 {
+			return x
+		}
+		var res []string
+		for _, v := range x {
+			if slices.Contains(y, v) {
+				res = append(res, v)
+			}
+		}
+		if len(res) == 0 {
+			satisfiable = false
+		}
+		return res
+	}
+
+	if len(a.NamespacesRegex) > 0 && len(b.NamespacesRegex) > 0 && !slices.Equal(a.NamespacesRegex, b.NamespacesRegex) {
+		return types.FlowMatch{}, false, false
+	}
+	match = types.FlowMatch{
+		Labels:          mergeLabels(a.Labels, b.Labels),
+		NamespaceLabels: mergeLabels(a.NamespaceLabels, b.NamespaceLabels),
+		Namespaces:      intersect(a.Namespaces, b.Namespaces),
+		NamespacesRegex: a.NamespacesRegex,
+		Hosts:           intersect(a.Hosts, b.Hosts),
+		ContainerNames:  intersect(a.ContainerNames, b.ContainerNames),
+	}
+	if len(match.NamespacesRegex) == 0 {
+		match.NamespacesRegex = b.NamespacesRegex
+	}
+	return match, satisfiable, true
+}
+
+// rubyCondition returns the expression as Ruby code evaluated on the record the way label-router evaluates selectors.
+// The code has no braces, because record_modifier ends the placeholder at the first closing brace.
+func rubyCondition(expr *v1beta1.FlowMatchExpression) string {
+	join := func(exprs []v1beta1.FlowMatchExpression, op string) string {
+		var parts []string
+		for i := range exprs {
+			parts = append(parts, rubyCondition(&exprs[i]))
+		}
+		return "(" + strings.Join(parts, op) + ")"
+	}
+	switch {
+	case len(expr.And) > 0:
+		return join(expr.And, " && ")
+	case len(expr.Or) > 0:
+		return join(expr.Or, " || ")
+	case expr.Not != nil:
+		return "!" + rubyCondition(expr.Not)
+	}
+
+	return rubySelector(selectorOf(expr))
+}
+
+// rubySelector returns the conditions of the selector as Ruby code, every condition must be true.
+// The namespace labels are rejected before, since the records don't carry them.
+func rubySelector(s selector) string {
+	var parts []string
+	for _, key := range sortedKeys(s.Labels) {
+		parts = append(parts, fmt.Sprintf("%s == %s", rubyDig("labels", key), ruby.Quote(s.Labels[key])))
+	}
+	for _, key := range sortedKeys(s.Annotations) {
+		parts = append(parts, fmt.Sprintf("%s == %s", rubyDig("annotations", key), ruby.Quote(s.Annotations[key])))
+	}
+	for _, expr := range s.LabelExpressions {
+		label := rubyDig("labels", expr.Key)
+		switch expr.Operator {
+		case v1beta1.LabelOpIn:
+			parts = append(parts, fmt.Sprintf("%s.include?(%s)", ruby.Array(expr.Values), label))
+		case v1beta1.LabelOpNotIn:
+			parts = append(parts, fmt.Sprintf("!%s.include?(%s)", ruby.Array(expr.Values), label))
+		case v1beta1.LabelOpExists:
+			parts = append(parts, fmt.Sprintf("!%s.nil?", label))
+		case v1beta1.LabelOpDoesNotExist:
+			parts = append(parts, fmt.Sprintf("%s.nil?", label))
+		case v1beta1.LabelOpRegex:
+			parts = append(parts, fmt.Sprintf("!%s.nil? && %s", label, rubyRegexpMatch(expr.Values, label)))
+		}
+	}
+	if len(s.Namespaces) > 0 {
+		parts = append(parts, fmt.Sprintf("%s.include?(%s)", ruby.Array(s.Namespaces), rubyDig("namespace_name")))
+	}
+	if len(s.NamespacesRegex) > 0 {
+		parts = append(parts, rubyRegexpMatch(s.NamespacesRegex, rubyDig("namespace_name")))
+	}
+	if len(s.Hosts) > 0 {
+		parts = append(parts, fmt.Sprintf("%s.include?(%s)", ruby.Array(s.Hosts), rubyDig("host")))
+	}
+	if len(s.ContainerNames) > 0 {
+		parts = append(parts, fmt.Sprintf("%s.include?(%s)", ruby.Array(s.ContainerNames), rubyDig("container_name")))
+	}
+	if len(parts) == 0 {
+		return "true"
+	}
+	return "(" + strings.Join(parts, " && ") + ")"
+}
+
+// rubyDig returns the Ruby code for the value of the Kubernetes metadata of the record at the path
+func rubyDig(path ...string) string {
+	keys := []string{ruby.Quote("kubernetes")}
+	for _, key := range path {
+		keys = append(keys, ruby.Quote(key))
+	}
+	return fmt.Sprintf("record.dig(%s)", strings.Join(keys, ", "))
+}
+
+func rubyRegexpMatch(expressions []string, value string) string {
+	var regexps []string
+	for _, re := range expressions {
+		regexps = append(regexps, fmt.Sprintf("Regexp.new(%s)", ruby.Quote(re)))
+	}
+	return fmt.Sprintf("Regexp.union(%s).match?(%s.to_s)", strings.Join(regexps, ", "), value)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := mapstrstr.Keys(m)
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,178 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+func TestMatchExpressionRoute(t *testing.T) {
+	tests := map[string]struct {
+		expr     v1beta1.FlowMatchExpression
+		scope    types.FlowMatch
+		expected []types.FlowMatch
+	}{
+		"and not": {
+			expr: v1beta1.FlowMatchExpression{And: []v1beta1.FlowMatchExpression{
+				{Labels: map[string]string{"app": "a"}},
+				{Not: &v1beta1.FlowMatchExpression{Labels: map[string]string{"tier": "db"}}},
+			}},
+			expected: []types.FlowMatch{
+				{Labels: map[string]string{"app": "a", "tier": "db"}, Negate: true},
+				{Labels: map[string]string{"app": "a"}},
+			},
+		},
+		"or of disjoint selectors": {
+			expr: v1beta1.FlowMatchExpression{Or: []v1beta1.FlowMatchExpression{
+				{Hosts: []string{"h1"}},
+				{Hosts: []string{"h2"}},
+			}},
+			expected: []types.FlowMatch{
+				{Hosts: []string{"h1"}},
+				{Hosts: []string{"h2"}},
+			},
+		},
+		"not within the namespace of a flow": {
+			expr:  v1beta1.FlowMatchExpression{Not: &v1beta1.FlowMatchExpression{Labels: map[string]string{"app": "a"}}},
+			scope: types.FlowMatch{Namespaces: []string{"ns"}},
+			expected: []types.FlowMatch{
+				{Labels: map[string]string{"app": "a"}, Namespaces: []string{"ns"}, Negate: true},
+				{Namespaces: []string{"ns"}},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, filters, err := matchExpression(&test.expr, test.scope, "clusterflow:ns:test")
+			require.NoError(t, err)
+			assert.Empty(t, filters)
+			assert.Equal(t, test.expected, matches)
+		})
+	}
+}
+
+func TestMatchExpressionFilters(t *testing.T) {
+	expr := v1beta1.FlowMatchExpression{Or: []v1beta1.FlowMatchExpression{
+		{NamespacesRegex: []string{"^dev-"}},
+		{NamespacesRegex: []string{"^test-"}, Labels: map[string]string{"app": "it's"}},
+	}}
+
+	// two different namespace regular expressions cannot be combined in one match
+	matches, filters, err := matchExpression(&expr, types.FlowMatch{}, "clusterflow:ns:test")
+	require.NoError(t, err)
+	assert.Equal(t, []types.FlowMatch{{}}, matches)
+	require.Len(t, filters, 3)
+	assert.Equal(t, "clusterflow:ns:test:match:0", filters[0].GetPluginMeta().Id)
+
+	assert.Equal(t,
+		`((Regexp.union(Regexp.new("^dev-")).match?(record.dig("kubernetes", "namespace_name").to_s))`+
+			` || (record.dig("kubernetes", "labels", "app") == "it's"`+
+			` && Regexp.union(Regexp.new("^test-")).match?(record.dig("kubernetes", "namespace_name").to_s)))`,
+		rubyCondition(&expr))
+
+	// the records carry no namespace labels, the filters can't evaluate them
+	expr.Or = append(expr.Or, v1beta1.FlowMatchExpression{NamespaceLabels: map[string]string{"team": "a"}})
+	_, _, err = matchExpression(&expr, types.FlowMatch{}, "clusterflow:ns:test")
+	assert.ErrorContains(t, err, "namespace_labels can't be used in a match expression evaluated by filters")
+}
+
+func TestMatchExpressionNeverMatches(t *testing.T) {
+	expr := v1beta1.FlowMatchExpression{And: []v1beta1.FlowMatchExpression{
+		{Labels: map[string]string{"app": "a"}},
+		{Not: &v1beta1.FlowMatchExpression{Labels: map[string]string{"app": "a"}}},
+	}}
+	_, _, err := matchExpression(&expr, types.FlowMatch{}, "clusterflow:ns:test")
+	assert.EqualError(t, err, "match expression never matches")
+}
+
+func TestMatchExpressionProblems(t *testing.T) {
+	expr := v1beta1.FlowMatchExpression{
+		Labels: map[string]string{"app": "a"},
+		Or: []v1beta1.FlowMatchExpression{
+			{},
+			{NamespacesRegex: []string{"("}},
+		},
+	}
+	assert.Equal(t, []string{
+		"match expression should set exactly one of and, or, not or the selector fields",
+		"empty match expression",
+		"invalid namespaces_regex \"(\": error parsing regexp: missing closing ): `(`",
+	}, MatchExpressionProblems(&expr))
+}
+
+func TestMatchSelectors(t *testing.T) {
+	routable := []selector{
+		{FlowMatch: types.FlowMatch{Labels: map[string]string{"app": "a"}, Negate: true}},
+		{FlowMatch: types.FlowMatch{}},
+	}
+	matches, filters, err := matchSelectors(routable, types.FlowMatch{}, "clusterflow:ns:test")
+	require.NoError(t, err)
+	assert.Empty(t, filters)
+	assert.Equal(t, []types.FlowMatch{routable[0].FlowMatch, routable[1].FlowMatch}, matches)
+
+	selectors := []selector{
+		{
+			FlowMatch:        types.FlowMatch{Namespaces: []string{"ns"}, Negate: true},
+			LabelExpressions: []v1beta1.LabelExpression{{Key: "tier", Operator: v1beta1.LabelOpIn, Values: []string{"db"}}},
+		},
+		{
+			FlowMatch:   types.FlowMatch{Namespaces: []string{"ns"}},
+			Annotations: map[string]string{"audit": "true"},
+		},
+	}
+	matches, filters, err = matchSelectors(selectors, types.FlowMatch{Namespaces: []string{"ns"}}, "flow:ns:test")
+	require.NoError(t, err)
+	assert.Equal(t, []types.FlowMatch{{Namespaces: []string{"ns"}}}, matches)
+	require.Len(t, filters, 3)
+	assert.Equal(t,
+		`${((["db"].include?(record.dig("kubernetes", "labels", "tier")) && ["ns"].include?(record.dig("kubernetes", "namespace_name"))) ? false`+
+			` : ((record.dig("kubernetes", "annotations", "audit") == "true" && ["ns"].include?(record.dig("kubernetes", "namespace_name"))) ? true : false))}`,
+		filters[0].GetSections()[0].GetParams()[matchExpressionKey])
+}
+
+func TestMatchSelectorsWithNamespaceLabels(t *testing.T) {
+	flow := v1beta1.ClusterFlow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "logging", Name: "test"},
+		Spec: v1beta1.ClusterFlowSpec{
+			Match: []v1beta1.ClusterMatch{
+				{ClusterExclude: &v1beta1.ClusterExclude{Annotations: map[string]string{"audit": "false"}}},
+				{ClusterSelect: &v1beta1.ClusterSelect{NamespaceLabels: map[string]string{"team": "a"}}},
+			},
+		},
+	}
+	_, err := FlowForClusterFlow(flow, nil, nil)
+	assert.EqualError(t, err, "clusterflow logging/test: namespace_labels can't be used together with annotations or label_expressions in the selectors, since they are evaluated by filters then")
+}
+
+func TestLabelExpressionProblems(t *testing.T) {
+	assert.Equal(t, []string{
+		`label expression on "a": operator In requires values`,
+		`label expression on "b": operator Exists does not take values`,
+		"label expression on \"c\": invalid regex \"(\": error parsing regexp: missing closing ): `(`",
+		`label expression on "d": unknown operator "Matches"`,
+	}, LabelExpressionProblems([]v1beta1.LabelExpression{
+		{Key: "a", Operator: v1beta1.LabelOpIn},
+		{Key: "b", Operator: v1beta1.LabelOpExists, Values: []string{"x"}},
+		{Key: "c", Operator: v1beta1.LabelOpRegex, Values: []string{"("}},
+		{Key: "d", Operator: "Matches", Values: []string{"x"}},
+	}))
+}
@@ -0,0 +1,46 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "strings"
+
+const (
+	outputKind        = "output"
+	clusterOutputKind = "clusteroutput"
+)
+
+// OutputKey identifies an Output or a ClusterOutput
+type OutputKey struct {
+	ClusterOutput bool
+	Namespace     string
+	Name          string
+}
+
+// OutputKeyFor returns the output of a plugin id ending with `:output:<namespace>:<name>` or `:clusteroutput:<namespace>:<name>`
+func OutputKeyFor(pluginID string) (OutputKey, bool) {
+	parts := strings.Split(pluginID, ":")
+	if len(parts) < 4 {
+		return OutputKey{}, false
+	}
+	kind, namespace, name := parts[len(parts)-3], parts[len(parts)-2], parts[len(parts)-1]
+	switch kind {
+	case outputKind:
+		return OutputKey{Namespace: namespace, Name: name}, true
+	case clusterOutputKind:
+		return OutputKey{ClusterOutput: true, Namespace: namespace, Name: name}, true
+	default:
+		return OutputKey{}, false
+	}
+}
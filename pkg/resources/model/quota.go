@@ -0,0 +1,96 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/filter"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/plugins"
+)
+
+const (
+	// FluentdQuotaRecordsMetric counts the records of the namespaces limited by a quota
+	FluentdQuotaRecordsMetric = "fluentd_quota_records_total"
+	// FluentdQuotaAcceptedRecordsMetric counts the records of the namespaces that are within the quota
+	FluentdQuotaAcceptedRecordsMetric = "fluentd_quota_accepted_records_total"
+
+	secondsPerMinute = 60
+	secondsPerDay    = 24 * 60 * 60
+)
+
+// QuotaForLogQuota returns the filters enforcing the quota, or nil if there is nothing to enforce
+func QuotaForLogQuota(quota LogQuota, metrics bool) (*types.Quota, error) {
+	if len(quota.Namespaces) == 0 {
+		return nil, nil
+	}
+	id := fmt.Sprintf("quota:%s", quota.Name)
+
+	var converters []plugins.DirectiveConverter
+	if metrics {
+		converters = append(converters, quotaCounter(FluentdQuotaRecordsMetric, "Number of records received from namespaces limited by a quota", quota.Name))
+	}
+	if limit := quota.Spec.RecordsPerSecondLimit(); limit > 0 {
+		converters = append(converters, quotaThrottle(secondsPerMinute, limit*secondsPerMinute))
+	}
+	if limit := quota.Spec.DailyRecordsLimit(); limit > 0 {
+		converters = append(converters, quotaThrottle(secondsPerDay, limit))
+	}
+	if metrics {
+		converters = append(converters, quotaCounter(FluentdQuotaAcceptedRecordsMetric, "Number of records within the quota received from namespaces limited by a quota", quota.Name))
+	}
+	if len(converters) == 0 {
+		return nil, nil
+	}
+
+	result := &types.Quota{
+		Label:      fmt.Sprintf("@quota-%s", quota.Name),
+		Namespaces: quota.Namespaces,
+	}
+	for i, converter := range converters {
+		directive, err := converter.ToDirective(nil, fmt.Sprintf("%s:%d", id, i))
+		if err != nil {
+			return nil, err
+		}
+		result.Filters = append(result.Filters, directive)
+	}
+	return result, nil
+}
+
+func quotaThrottle(periodSeconds int, limit int64) *filter.Throttle {
+	return &filter.Throttle{
+		GroupKey:                 "kubernetes.namespace_name",
+		GroupBucketPeriodSeconds: periodSeconds,
+		GroupBucketLimit:         int(limit),
+		GroupDropLogs:            true,
+	}
+}
+
+func quotaCounter(name, desc, quota string) *filter.PrometheusConfig {
+	return &filter.PrometheusConfig{
+		Metrics: []filter.MetricSection{
+			{
+				Name: name,
+				Type: "counter",
+				Desc: desc,
+			},
+		},
+		Labels: filter.Label{
+			"namespace": "$.kubernetes.namespace_name",
+			"quota":     quota,
+		},
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"emperror.dev/errors"
 	"github.com/cisco-open/operator-tools/pkg/secret"
@@ -31,6 +32,7 @@ import (
 	"github.com/kube-logging/logging-operator/pkg/resources/configcheck"
 
 	"github.com/kube-logging/logging-operator/pkg/mirror"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 )
 
 const LoggingRefConflict = "Other logging resources exist with the same loggingRef"
@@ -63,8 +65,15 @@ func NewValidationReconciler(
 			}
 
 			output.Status.Problems = append(output.Status.Problems,
-				validateOutputSpec(output.Spec.OutputSpec, secrets.OutputSecretLoaderForNamespace(output.Namespace))...)
+				ValidateOutputSpec(output.Spec.OutputSpec, secrets.OutputSecretLoaderForNamespace(output.Namespace))...)
+			output.Status.Problems = append(output.Status.Problems,
+				ProblemMessages(OutputSecondaryProblems(output.Spec.OutputSpec, output.Name, true, resources.Fluentd.ClusterOutputs, resources.Fluentd.Outputs, output.Namespace, secrets.OutputSecretLoaderForNamespace(output.Namespace)))...)
+			bufferKey := OutputKey{ClusterOutput: true, Namespace: output.Namespace, Name: output.Name}
+			output.Status.BufferLimit = resources.Fluentd.BufferLimits.LimitOf(bufferKey)
+			output.Status.Problems = append(output.Status.Problems, resources.Fluentd.BufferLimits.ProblemsOf(bufferKey)...)
 			output.Status.ProblemsCount = len(output.Status.Problems)
+			output.Status.ObservedGeneration = output.Generation
+			setReadyCondition(&output.Status.Conditions, output.Generation, output.Status.Problems)
 		}
 
 		for i := range resources.Fluentd.Outputs {
@@ -75,8 +84,15 @@ func NewValidationReconciler(
 			output.Status.Problems = nil
 
 			output.Status.Problems = append(output.Status.Problems,
-				validateOutputSpec(output.Spec, secrets.OutputSecretLoaderForNamespace(output.Namespace))...)
+				ValidateOutputSpec(output.Spec, secrets.OutputSecretLoaderForNamespace(output.Namespace))...)
+			output.Status.Problems = append(output.Status.Problems,
+				ProblemMessages(OutputSecondaryProblems(output.Spec, output.Name, false, resources.Fluentd.ClusterOutputs, resources.Fluentd.Outputs, output.Namespace, secrets.OutputSecretLoaderForNamespace(output.Namespace)))...)
+			bufferKey := OutputKey{Namespace: output.Namespace, Name: output.Name}
+			output.Status.BufferLimit = resources.Fluentd.BufferLimits.LimitOf(bufferKey)
+			output.Status.Problems = append(output.Status.Problems, resources.Fluentd.BufferLimits.ProblemsOf(bufferKey)...)
 			output.Status.ProblemsCount = len(output.Status.Problems)
+			output.Status.ObservedGeneration = output.Generation
+			setReadyCondition(&output.Status.Conditions, output.Generation, output.Status.Problems)
 		}
 
 		for i := range resources.SyslogNG.ClusterOutputs {
@@ -91,8 +107,14 @@ func NewValidationReconciler(
 			}
 
 			output.Status.Problems = append(output.Status.Problems,
-				validateOutputSpec(output.Spec.SyslogNGOutputSpec, secrets.OutputSecretLoaderForNamespace(output.Namespace))...)
+				ValidateOutputSpec(output.Spec.SyslogNGOutputSpec, secrets.OutputSecretLoaderForNamespace(output.Namespace))...)
+			bufferKey := OutputKey{ClusterOutput: true, Namespace: output.Namespace, Name: output.Name}
+			output.Status.BufferLimit = resources.SyslogNG.BufferLimits.LimitOf(bufferKey)
+			output.Status.Problems = append(output.Status.Problems, resources.SyslogNG.BufferLimits.ProblemsOf(bufferKey)...)
+			output.Status.Problems = append(output.Status.Problems, resources.SyslogNG.BufferLimits.DiskBufferProblemsOf(bufferKey, &output.Spec.SyslogNGOutputSpec)...)
 			output.Status.ProblemsCount = len(output.Status.Problems)
+			output.Status.ObservedGeneration = output.Generation
+			setReadyCondition(&output.Status.Conditions, output.Generation, output.Status.Problems)
 		}
 
 		for i := range resources.SyslogNG.Outputs {
@@ -103,8 +125,14 @@ func NewValidationReconciler(
 			output.Status.Problems = nil
 
 			output.Status.Problems = append(output.Status.Problems,
-				validateOutputSpec(output.Spec, secrets.OutputSecretLoaderForNamespace(output.Namespace))...)
+				ValidateOutputSpec(output.Spec, secrets.OutputSecretLoaderForNamespace(output.Namespace))...)
+			bufferKey := OutputKey{Namespace: output.Namespace, Name: output.Name}
+			output.Status.BufferLimit = resources.SyslogNG.BufferLimits.LimitOf(bufferKey)
+			output.Status.Problems = append(output.Status.Problems, resources.SyslogNG.BufferLimits.ProblemsOf(bufferKey)...)
+			output.Status.Problems = append(output.Status.Problems, resources.SyslogNG.BufferLimits.DiskBufferProblemsOf(bufferKey, &output.Spec)...)
 			output.Status.ProblemsCount = len(output.Status.Problems)
+			output.Status.ObservedGeneration = output.Generation
+			setReadyCondition(&output.Status.Conditions, output.Generation, output.Status.Problems)
 		}
 
 		for i := range resources.Fluentd.ClusterFlows {
@@ -117,22 +145,23 @@ func NewValidationReconciler(
 			if len(flow.Spec.GlobalOutputRefs) == 0 && len(flow.Spec.OutputRefs) > 0 {
 				flow.Status.Problems = append(flow.Status.Problems, "\"outputRefs\" field is deprecated, use \"globalOutputRefs\" instead")
 			}
+			flow.Status.Problems = append(flow.Status.Problems, ClusterFlowMatchProblems(flow)...)
 
-			for _, ref := range flow.Spec.GlobalOutputRefs {
-				switch output := resources.Fluentd.ClusterOutputs.FindByName(ref); {
-				case output == nil:
-					flow.Status.Problems = append(flow.Status.Problems, fmt.Sprintf("dangling global output reference: %s", ref))
-
-				case output.Status.ProblemsCount > 0:
-					flow.Status.Problems = append(flow.Status.Problems, fmt.Sprintf("global output reference: %s has problems", output.Name))
-
-				default:
-					flow.Status.Active = utils.BoolPointer(true)
-					output.Status.Active = utils.BoolPointer(true)
-				}
+			problems, outputs := ClusterFlowOutputRefProblems(flow, resources.Fluentd.ClusterOutputs)
+			refProblems := ProblemMessages(problems)
+			for _, output := range outputs {
+				output.Status.Active = utils.BoolPointer(true)
 			}
+			flow.Status.Active = utils.BoolPointer(len(outputs) > 0)
+			flow.Status.Problems = append(flow.Status.Problems, refProblems...)
 
 			flow.Status.ProblemsCount = len(flow.Status.Problems)
+			flow.Status.ObservedGeneration = flow.Generation
+			if len(outputs) == 0 && len(refProblems) == 0 {
+				refProblems = []string{"flow has no valid outputs"}
+			}
+			setOutputsResolvedCondition(&flow.Status.Conditions, flow.Generation, refProblems)
+			setReadyCondition(&flow.Status.Conditions, flow.Generation, flow.Status.Problems)
 		}
 
 		for i := range resources.Fluentd.Flows {
@@ -145,56 +174,41 @@ func NewValidationReconciler(
 			if len(flow.Spec.LocalOutputRefs)+len(flow.Spec.GlobalOutputRefs) == 0 && len(flow.Spec.OutputRefs) > 0 {
 				flow.Status.Problems = append(flow.Status.Problems, "\"outputRefs\" field is deprecated, use \"globalOutputRefs\" and \"localOutputRefs\" instead")
 			}
+			flow.Status.Problems = append(flow.Status.Problems, FlowMatchProblems(flow)...)
+			flow.Status.Problems = append(flow.Status.Problems, FlowRouteProblems(flow)...)
 
-			hasValidOutput := false
-			for _, ref := range flow.Spec.GlobalOutputRefs {
-				switch output := resources.Fluentd.ClusterOutputs.FindByName(ref); {
-				case output == nil:
-					flow.Status.Problems = append(flow.Status.Problems, fmt.Sprintf("dangling global output reference: %s", ref))
-
-				case output.Spec.Protected:
-					flow.Status.Problems = append(flow.Status.Problems, fmt.Sprintf("global output reference is protected: %s", ref))
-
-				case output.Status.ProblemsCount > 0:
-					flow.Status.Problems = append(flow.Status.Problems, fmt.Sprintf("global output reference: %s has problems", output.Name))
-
-				default:
-					output.Status.Active = utils.BoolPointer(true)
-					hasValidOutput = true
-				}
-			}
-
-			for _, ref := range flow.Spec.LocalOutputRefs {
-				switch output := resources.Fluentd.Outputs.FindByNamespacedName(flow.Namespace, ref); {
-				case output == nil:
-					flow.Status.Problems = append(flow.Status.Problems, fmt.Sprintf("dangling local output reference: %s", ref))
-
-				case output.Status.ProblemsCount > 0:
-					flow.Status.Problems = append(flow.Status.Problems, fmt.Sprintf("local output reference: %s has problems", output.Name))
-
-				default:
-					output.Status.Active = utils.BoolPointer(true)
-					hasValidOutput = true
-				}
+			problems, clusterOutputs, outputs := FlowOutputRefProblems(flow, resources.Fluentd.ClusterOutputs, resources.Fluentd.Outputs)
+			refProblems := ProblemMessages(problems)
+			for _, output := range clusterOutputs {
+				output.Status.Active = utils.BoolPointer(true)
 			}
-
-			// Check if the flow has become dangling with no valid outputs
-			if hasValidOutput {
-				flow.Status.Active = utils.BoolPointer(true)
-			} else {
-				flow.Status.Problems = append(flow.Status.Problems, "flow has become dangling with no valid outputs")
+			for _, output := range outputs {
+				output.Status.Active = utils.BoolPointer(true)
 			}
+			flow.Status.Active = utils.BoolPointer(len(clusterOutputs)+len(outputs) > 0)
+			flow.Status.Problems = append(flow.Status.Problems, refProblems...)
 
 			flow.Status.ProblemsCount = len(flow.Status.Problems)
+			flow.Status.ObservedGeneration = flow.Generation
+			setOutputsResolvedCondition(&flow.Status.Conditions, flow.Generation, refProblems)
+			setReadyCondition(&flow.Status.Conditions, flow.Generation, flow.Status.Problems)
 		}
 
+		_, syslogNGSpec := resources.GetSyslogNGSpec()
 		for i := range resources.SyslogNG.ClusterFlows {
 			flow := &resources.SyslogNG.ClusterFlows[i]
 			registerForPatching(flow)
 
 			flow.Status.Active = utils.BoolPointer(false)
 			flow.Status.Problems = nil
+			flow.Status.Problems = append(flow.Status.Problems, SyslogNGMatchProblems(flow.Spec.Match)...)
+			for _, ref := range flow.Spec.SourceRefs {
+				if !syslogNGSpec.HasSource(ref) {
+					flow.Status.Problems = append(flow.Status.Problems, fmt.Sprintf("dangling source reference: %s", ref))
+				}
+			}
 
+			refProblemsStart := len(flow.Status.Problems)
 			for _, ref := range flow.Spec.GlobalOutputRefs {
 				switch output := resources.SyslogNG.ClusterOutputs.FindByName(ref); {
 				case output == nil:
@@ -210,6 +224,13 @@ func NewValidationReconciler(
 			}
 
 			flow.Status.ProblemsCount = len(flow.Status.Problems)
+			flow.Status.ObservedGeneration = flow.Generation
+			refProblems := flow.Status.Problems[refProblemsStart:]
+			if !*flow.Status.Active && len(refProblems) == 0 {
+				refProblems = []string{"flow has no valid outputs"}
+			}
+			setOutputsResolvedCondition(&flow.Status.Conditions, flow.Generation, refProblems)
+			setReadyCondition(&flow.Status.Conditions, flow.Generation, flow.Status.Problems)
 		}
 
 		for i := range resources.SyslogNG.Flows {
@@ -218,7 +239,9 @@ func NewValidationReconciler(
 
 			flow.Status.Active = utils.BoolPointer(false)
 			flow.Status.Problems = nil
+			flow.Status.Problems = append(flow.Status.Problems, SyslogNGMatchProblems(flow.Spec.Match)...)
 
+			refProblemsStart := len(flow.Status.Problems)
 			hasValidOutput := false
 			for _, ref := range flow.Spec.GlobalOutputRefs {
 				switch output := resources.SyslogNG.ClusterOutputs.FindByName(ref); {
@@ -259,11 +282,36 @@ func NewValidationReconciler(
 			}
 
 			flow.Status.ProblemsCount = len(flow.Status.Problems)
+			flow.Status.ObservedGeneration = flow.Generation
+			setOutputsResolvedCondition(&flow.Status.Conditions, flow.Generation, flow.Status.Problems[refProblemsStart:])
+			setReadyCondition(&flow.Status.Conditions, flow.Generation, flow.Status.Problems)
+		}
+
+		for i := range resources.LogQuotas {
+			quota := &resources.LogQuotas[i]
+			registerForPatching(&quota.LogQuota)
+
+			quota.Status.Namespaces = quota.Namespaces
+			quota.Status.Problems = slices.Clone(quota.Problems)
+			if len(quota.Spec.Namespaces) == 0 && quota.Spec.NamespaceSelector == nil {
+				quota.Status.Problems = append(quota.Status.Problems, "neither namespaces nor namespaceSelector is set")
+			} else if len(quota.Namespaces) == 0 {
+				quota.Status.Problems = append(quota.Status.Problems, "quota does not apply to any namespace")
+			}
+			if quota.Spec.RecordsPerSecondLimit() == 0 && quota.Spec.DailyRecordsLimit() == 0 {
+				quota.Status.Problems = append(quota.Status.Problems, "quota does not set any limit")
+			}
+			if syslogNGSpec != nil && quota.Spec.DailyRecordsLimit() > 0 {
+				quota.Status.Problems = append(quota.Status.Problems, "daily limits are not enforced by syslog-ng")
+			}
+			quota.Status.ProblemsCount = len(quota.Status.Problems)
 		}
 
 		registerForPatching(&resources.Logging)
 		resources.Logging.Status.Problems = nil
 		resources.Logging.Status.WatchNamespaces = nil
+		resources.Logging.Status.Problems = append(resources.Logging.Status.Problems, resources.Fluentd.BufferLimits.LoggingProblems()...)
+		resources.Logging.Status.Problems = append(resources.Logging.Status.Problems, resources.SyslogNG.BufferLimits.LoggingProblems()...)
 
 		if len(resources.Fluentd.ExcessFluentds) != 0 {
 			logger.Info("Excess Fluentd CRDs found")
@@ -281,6 +329,8 @@ func NewValidationReconciler(
 					excessFluentd.Status.Problems = append(excessFluentd.Status.Problems, "logging already has a detached fluentd configuration, remove excess configuration objects")
 				}
 				excessFluentd.Status.ProblemsCount = len(excessFluentd.Status.Problems)
+				excessFluentd.Status.ObservedGeneration = excessFluentd.Generation
+				setReadyCondition(&excessFluentd.Status.Conditions, excessFluentd.Generation, excessFluentd.Status.Problems)
 			}
 		}
 		if resources.Fluentd.Configuration != nil {
@@ -295,6 +345,8 @@ func NewValidationReconciler(
 
 			resources.Fluentd.Configuration.Status.Active = utils.BoolPointer(true)
 			resources.Fluentd.Configuration.Status.Logging = resources.Logging.Name
+			resources.Fluentd.Configuration.Status.ObservedGeneration = resources.Fluentd.Configuration.Generation
+			setReadyCondition(&resources.Fluentd.Configuration.Status.Conditions, resources.Fluentd.Configuration.Generation, resources.Fluentd.Configuration.Status.Problems)
 		} else {
 			resources.Logging.Status.FluentdConfigName = ""
 		}
@@ -315,6 +367,8 @@ func NewValidationReconciler(
 					excessSyslogNG.Status.Problems = append(excessSyslogNG.Status.Problems, "logging already has a detached syslog-ng configuration, remove excess configuration objects")
 				}
 				excessSyslogNG.Status.ProblemsCount = len(excessSyslogNG.Status.Problems)
+				excessSyslogNG.Status.ObservedGeneration = excessSyslogNG.Generation
+				setReadyCondition(&excessSyslogNG.Status.Conditions, excessSyslogNG.Generation, excessSyslogNG.Status.Problems)
 			}
 		}
 
@@ -330,6 +384,8 @@ func NewValidationReconciler(
 			logger.Info("found detached syslog-ng aggregator, making association, done: ", "name=", resources.Logging.Status.SyslogNGConfigName)
 			resources.SyslogNG.Configuration.Status.Active = utils.BoolPointer(true)
 			resources.SyslogNG.Configuration.Status.Logging = resources.Logging.Name
+			resources.SyslogNG.Configuration.Status.ObservedGeneration = resources.SyslogNG.Configuration.Generation
+			setReadyCondition(&resources.SyslogNG.Configuration.Status.Conditions, resources.SyslogNG.Configuration.Generation, resources.SyslogNG.Configuration.Status.Problems)
 		} else {
 			resources.Logging.Status.SyslogNGConfigName = ""
 		}
@@ -370,16 +426,29 @@ func NewValidationReconciler(
 					hash,
 					resources.Logging.Spec.ControlNamespace, configcheck.HashLabel, hash,
 					resources.Logging.Spec.ControlNamespace, configcheck.HashLabel, hash)
+				if directive := configcheck.FailingDirective(resources.Logging.Status.ConfigCheckFailures[hash]); directive != "" {
+					problem += fmt.Sprintf(". Failing directive: %s", directive)
+				}
 				resources.Logging.Status.Problems = append(resources.Logging.Status.Problems, problem)
 			}
 		}
 
+		if rollback := resources.Logging.Status.ConfigRollback; rollback != nil {
+			resources.Logging.Status.Problems = append(resources.Logging.Status.Problems,
+				fmt.Sprintf("Aggregator pods were crash-looping with configuration %s, rolled back to the last known good configuration %s at %s",
+					rollback.FailedHash, rollback.RestoredHash, rollback.Time.UTC().Format(time.RFC3339)))
+		}
+
 		if resources.Logging.Spec.FluentbitSpec != nil && len(resources.LoggingRoutes) > 0 {
 			resources.Logging.Status.Problems = append(resources.Logging.Status.Problems, "Logging routes are not supported for embedded fluentbit configs, please use a separate FluentbitAgent resource!")
 		}
 
 		slices.Sort(resources.Logging.Status.Problems)
 		resources.Logging.Status.ProblemsCount = len(resources.Logging.Status.Problems)
+		resources.Logging.Status.ObservedGeneration = resources.Logging.Generation
+		setConfigCheckCondition(&resources)
+		setAggregatorCondition(&resources)
+		setReadyCondition(&resources.Logging.Status.Conditions, resources.Logging.Generation, resources.Logging.Status.Problems)
 
 		var errs error
 		for _, req := range patchRequests {
@@ -397,10 +466,15 @@ func NewValidationReconciler(
 	}
 }
 
-func validateOutputSpec(spec interface{}, secrets secret.SecretLoader) (problems []string) {
+// ValidateOutputSpec checks that exactly one output target is configured and that the referenced secrets can be loaded
+func ValidateOutputSpec(spec interface{}, secrets secret.SecretLoader) (problems []string) {
 	var configuredFields []string
 	it := mirror.StructRange(spec)
 	for it.Next() {
+		if _, ok := it.Value().Interface().(*v1beta1.OutputSecondary); ok {
+			// checked by OutputSecondaryProblems
+			continue
+		}
 		if it.Field().Type.Kind() == reflect.Ptr && !it.Value().IsNil() {
 			configuredFields = append(configuredFields, jsonFieldName(it.Field()))
 			problems = append(problems, checkSecrets(it.Value().Elem(), secrets)...)
@@ -16,6 +16,8 @@ package model
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"sort"
 
 	"emperror.dev/errors"
@@ -74,6 +76,9 @@ func (r LoggingResourceRepository) LoggingResourcesFor(ctx context.Context, logg
 	res.LoggingRoutes, err = r.LoggingRoutesFor(ctx, logging)
 	errs = errors.Append(errs, err)
 
+	res.LogQuotas, err = r.LogQuotasFor(ctx, logging)
+	errs = errors.Append(errs, err)
+
 	res.WatchNamespaces, err = UniqueWatchNamespaces(ctx, r.Client, &logging)
 	if err != nil {
 		errs = errors.Append(errs, err)
@@ -416,6 +421,62 @@ func (r LoggingResourceRepository) LoggingRoutesFor(ctx context.Context, logging
 	return res, nil
 }
 
+// LogQuotasFor returns the quotas of the logging with their namespaces resolved.
+// A namespace is enforced by the first quota in name order that selects it.
+func (r LoggingResourceRepository) LogQuotasFor(ctx context.Context, logging v1beta1.Logging) ([]LogQuota, error) {
+	var list v1beta1.LogQuotaList
+	if err := r.Client.List(ctx, &list); err != nil {
+		return nil, err
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].Name < list.Items[j].Name
+	})
+
+	var res []LogQuota
+	var errs error
+	enforcedBy := make(map[string]string)
+	for _, i := range list.Items {
+		if i.Spec.LoggingRef != logging.Spec.LoggingRef {
+			continue
+		}
+		quota := LogQuota{LogQuota: i}
+		namespaces, err := quotaNamespaces(ctx, r.Client, i.Spec)
+		if err != nil {
+			errs = errors.Append(errs, errors.WrapIfWithDetails(err, "resolving namespaces of quota", "quota", i.Name))
+			quota.Problems = append(quota.Problems, err.Error())
+		}
+		for _, ns := range namespaces {
+			if other, ok := enforcedBy[ns]; ok {
+				quota.Problems = append(quota.Problems, fmt.Sprintf("namespace %s is already limited by quota %s", ns, other))
+				continue
+			}
+			enforcedBy[ns] = i.Name
+			quota.Namespaces = append(quota.Namespaces, ns)
+		}
+		res = append(res, quota)
+	}
+	return res, errs
+}
+
+func quotaNamespaces(ctx context.Context, reader client.Reader, spec v1beta1.LogQuotaSpec) ([]string, error) {
+	namespaces := slices.Clone(spec.Namespaces)
+	if spec.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(spec.NamespaceSelector)
+		if err != nil {
+			return nil, errors.WrapIf(err, "error in namespaceSelector")
+		}
+		var nsList corev1.NamespaceList
+		if err := reader.List(ctx, &nsList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, errors.WrapIf(err, "listing namespaces for namespaceSelector")
+		}
+		for _, i := range nsList.Items {
+			namespaces = append(namespaces, i.Name)
+		}
+	}
+	slices.Sort(namespaces)
+	return slices.Compact(namespaces), nil
+}
+
 func clusterResourceListOpts(logging v1beta1.Logging) []client.ListOption {
 	var opts []client.ListOption
 	if !logging.Spec.AllowClusterResourcesFromAllNamespaces {
@@ -25,9 +25,19 @@ type LoggingResources struct {
 	SyslogNG        SyslogNGLoggingResources
 	Fluentbits      []v1beta1.FluentbitAgent
 	LoggingRoutes   []v1beta1.LoggingRoute
+	LogQuotas       []LogQuota
 	WatchNamespaces []string
 }
 
+// LogQuota is a quota with the namespaces it is enforced on
+type LogQuota struct {
+	v1beta1.LogQuota
+	// Namespaces selected by the quota that are not limited by another quota
+	Namespaces []string
+	// Problems found while resolving the namespaces
+	Problems []string
+}
+
 func (l LoggingResources) getFluentdConfig() *v1beta1.FluentdConfig {
 	if l.Fluentd.Configuration != nil {
 		return l.Fluentd.Configuration
@@ -50,6 +60,8 @@ type FluentdLoggingResources struct {
 	Outputs        Outputs
 	Configuration  *v1beta1.FluentdConfig
 	ExcessFluentds []v1beta1.FluentdConfig
+	// Limits of the buffers computed while generating the configuration
+	BufferLimits *BufferLimits
 }
 
 func (l LoggingResources) getSyslogNG() *v1beta1.SyslogNGConfig {
@@ -73,6 +85,8 @@ type SyslogNGLoggingResources struct {
 	Outputs         SyslogNGOutputs
 	Configuration   *v1beta1.SyslogNGConfig
 	ExcessSyslogNGs []v1beta1.SyslogNGConfig
+	// Limits of the disk-buffers computed while generating the configuration
+	BufferLimits *BufferLimits
 }
 
 type ClusterOutputs []v1beta1.ClusterOutput
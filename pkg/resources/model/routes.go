@@ -0,0 +1,115 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/filter"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/ruby"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+// routeTagPrefix is prepended to the tags of the records while they are sent to the label of their route
+const routeTagPrefix = "__flow_route"
+
+// defaultRouteTag is the tag prefix of the records matching no route, route names cannot start with an underscore
+const defaultRouteTag = routeTagPrefix + "._default"
+
+// routesForFlow returns the filter deciding the route of each record and the flows of the routes.
+// The records are re-emitted with the tag prefix of their route, so each of them reaches exactly one route flow,
+// which restores the original tag. The records matching no route are sent to the outputs of the flow by the default route.
+func routesForFlow(parent *types.Flow, flow v1beta1.Flow, defaultOutputs []types.Output, clusterOutputs ClusterOutputs, outputs Outputs, secrets SecretLoaderFactory) (types.Filter, []*types.Flow, error) {
+	if problems := FlowRouteProblems(&flow); len(problems) > 0 {
+		return nil, nil, errors.Errorf("invalid routes for flow %s/%s: %s", flow.Namespace, flow.Name, strings.Join(problems, ", "))
+	}
+
+	var (
+		routes []*types.Flow
+		errs   error
+	)
+	for _, route := range flow.Spec.Routes {
+		routeID := fmt.Sprintf("%s:route:%s", parent.FlowID, route.Name)
+		routeOutputs, err := outputsForRefs(routeID, flow, route.GlobalOutputRefs, route.LocalOutputRefs, clusterOutputs, outputs, secrets)
+		errs = errors.Append(errs, err)
+		routeFilters, err := filtersForFilters(routeID, flow.Name, secrets.OutputSecretLoaderForNamespace(flow.Namespace), route.Filters)
+		errs = errors.Append(errs, err)
+		routeFlow, err := newRouteFlow(fmt.Sprintf("%s-route-%s", parent.FlowLabel, route.Name), routeID, routeTag(route.Name))
+		if err != nil {
+			return nil, nil, err
+		}
+		routes = append(routes, routeFlow.WithFilters(routeFilters...).WithOutputs(routeOutputs...))
+	}
+	// the records matching no route are dropped without a default route
+	var fallback string
+	if len(defaultOutputs) > 0 {
+		defaultFlow, err := newRouteFlow(parent.FlowLabel+"-default", parent.FlowID+":default", defaultRouteTag)
+		if err != nil {
+			return nil, nil, err
+		}
+		routes = append(routes, defaultFlow.WithOutputs(defaultOutputs...))
+		fallback = defaultRouteTag
+	}
+
+	// the lambda is prepared, because the patterns may contain braces, which cannot be used in placeholders
+	decide := &filter.RecordModifier{
+		PrepareValues: fmt.Sprintf("@flow_route = lambda do |record| %s end", rubyRoute(flow.Spec.Routes, fallback)),
+		Records:       []filter.Record{{types.FlowRouteKey: "${@flow_route.call(record)}"}},
+	}
+	routeFilter, err := decide.ToDirective(nil, parent.FlowID+":routes")
+	if err != nil {
+		return nil, nil, err
+	}
+	return routeFilter, routes, errs
+}
+
+// newRouteFlow returns the flow of a route receiving the records with the route tag prefix
+func newRouteFlow(label string, id string, tag string) (*types.Flow, error) {
+	flow := &types.Flow{
+		PluginMeta: types.PluginMeta{
+			Directive: "label",
+			Tag:       label,
+		},
+		FlowID:    id,
+		FlowLabel: label,
+		RouteTag:  tag,
+	}
+	cleanup, err := (&filter.RecordModifier{RemoveKeys: types.FlowRouteKey}).ToDirective(nil, id+":cleanup")
+	if err != nil {
+		return nil, err
+	}
+	return flow.WithFilters(cleanup), nil
+}
+
+func routeTag(name string) string {
+	return routeTagPrefix + "." + name
+}
+
+// rubyRoute returns the expression evaluating to the tag prefix of the first matching route, or the fallback
+func rubyRoute(routes []v1beta1.FlowRoute, fallback string) string {
+	expr := ruby.Quote(fallback)
+	for i := len(routes) - 1; i >= 0; i-- {
+		var conditions []string
+		for _, c := range routes[i].Conditions {
+			conditions = append(conditions, rubyRegexpMatch([]string{c.Pattern}, fmt.Sprintf("record.dig(*%s)", ruby.Path(c.Key))))
+		}
+		expr = fmt.Sprintf("(%s ? %s : %s)", strings.Join(conditions, " && "), ruby.Quote(routeTag(routes[i].Name)), expr)
+	}
+	return expr
+}
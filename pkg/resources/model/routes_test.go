@@ -0,0 +1,201 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/filter"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/output"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/render"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+type testSecretLoaderFactory struct{}
+
+func (testSecretLoaderFactory) OutputSecretLoaderForNamespace(namespace string) secret.SecretLoader {
+	return secret.NewSecretLoader(nil, namespace, "", nil)
+}
+
+func TestFlowRoutes(t *testing.T) {
+	flow := v1beta1.Flow{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Spec: v1beta1.FlowSpec{
+			FlowLabel:       "@test",
+			LocalOutputRefs: []string{"loki"},
+			Routes: []v1beta1.FlowRoute{
+				{
+					Name:             "errors",
+					Conditions:       []v1beta1.RouteCondition{{Key: "level", Pattern: "^error$"}},
+					Filters:          []v1beta1.Filter{{StdOut: &filter.StdOutFilterConfig{}}},
+					GlobalOutputRefs: []string{"pagerduty"},
+				},
+				{
+					Name: "audit",
+					Conditions: []v1beta1.RouteCondition{
+						{Key: "$.kubernetes.labels.audit", Pattern: "^true$"},
+						{Key: "message", Pattern: `\d{3}`},
+					},
+					LocalOutputRefs: []string{"s3"},
+				},
+			},
+		},
+	}
+	nullOutput := v1beta1.OutputSpec{NullOutputConfig: &output.NullOutputConfig{}}
+	clusterOutputs := ClusterOutputs{{ObjectMeta: metav1.ObjectMeta{Name: "pagerduty", Namespace: "control"}, Spec: v1beta1.ClusterOutputSpec{OutputSpec: nullOutput}}}
+	outputs := Outputs{
+		{ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns"}, Spec: nullOutput},
+		{ObjectMeta: metav1.ObjectMeta{Name: "s3", Namespace: "ns"}, Spec: nullOutput},
+	}
+
+	result, err := FlowForFlow(flow, clusterOutputs, outputs, testSecretLoaderFactory{})
+	require.NoError(t, err)
+
+	directives := []types.Directive{result}
+	for _, route := range result.Routes {
+		directives = append(directives, route)
+	}
+	b := &bytes.Buffer{}
+	require.NoError(t, (&render.FluentRender{Out: b, Indent: 2}).RenderDirectives(directives, 0))
+
+	expected := `
+<label @test>
+  <match __flow_route.errors.**>
+    @type relabel
+    @id flow:ns:test:route:errors:relabel
+    @label @test-route-errors
+  </match>
+  <match __flow_route.audit.**>
+    @type relabel
+    @id flow:ns:test:route:audit:relabel
+    @label @test-route-audit
+  </match>
+  <match __flow_route._default.**>
+    @type relabel
+    @id flow:ns:test:default:relabel
+    @label @test-default
+  </match>
+  <filter **>
+    @type record_modifier
+    @id flow:ns:test:routes
+    prepare_value @flow_route = lambda do |record| (Regexp.union(Regexp.new("^error$")).match?(record.dig(*["level"]).to_s) ? "__flow_route.errors" : (Regexp.union(Regexp.new("^true$")).match?(record.dig(*["kubernetes", "labels", "audit"]).to_s) && Regexp.union(Regexp.new("\\d{3}")).match?(record.dig(*["message"]).to_s) ? "__flow_route.audit" : "__flow_route._default")) end
+    <record>
+      _flow_route ${@flow_route.call(record)}
+    </record>
+  </filter>
+  <match **>
+    @type rewrite_tag_filter
+    @id flow:ns:test:routes:tag
+    <rule>
+      key _flow_route
+      pattern /^(.+)$/
+      tag $1.${tag}
+    </rule>
+  </match>
+</label>
+<label @test-route-errors>
+  <match __flow_route.errors.**>
+    @type rewrite_tag_filter
+    @id flow:ns:test:route:errors:restore
+    remove_tag_prefix __flow_route.errors
+    <rule>
+      key _flow_route
+      pattern /^.+$/
+      tag ${tag}
+    </rule>
+  </match>
+  <filter **>
+    @type record_modifier
+    @id flow:ns:test:route:errors:cleanup
+    remove_keys _flow_route
+  </filter>
+  <filter **>
+    @type stdout
+    @id flow:ns:test:route:errors:0
+  </filter>
+  <match **>
+    @type null
+    @id flow:ns:test:route:errors:clusteroutput:control:pagerduty
+  </match>
+</label>
+<label @test-route-audit>
+  <match __flow_route.audit.**>
+    @type rewrite_tag_filter
+    @id flow:ns:test:route:audit:restore
+    remove_tag_prefix __flow_route.audit
+    <rule>
+      key _flow_route
+      pattern /^.+$/
+      tag ${tag}
+    </rule>
+  </match>
+  <filter **>
+    @type record_modifier
+    @id flow:ns:test:route:audit:cleanup
+    remove_keys _flow_route
+  </filter>
+  <match **>
+    @type null
+    @id flow:ns:test:route:audit:output:ns:s3
+  </match>
+</label>
+<label @test-default>
+  <match __flow_route._default.**>
+    @type rewrite_tag_filter
+    @id flow:ns:test:default:restore
+    remove_tag_prefix __flow_route._default
+    <rule>
+      key _flow_route
+      pattern /^.+$/
+      tag ${tag}
+    </rule>
+  </match>
+  <filter **>
+    @type record_modifier
+    @id flow:ns:test:default:cleanup
+    remove_keys _flow_route
+  </filter>
+  <match **>
+    @type null
+    @id flow:ns:test:output:ns:loki
+  </match>
+</label>
+`
+	if a, e := diff.TrimLinesInString(b.String()), diff.TrimLinesInString(expected); a != e {
+		t.Errorf("Result does not match (-actual vs +expected):\n%v", diff.LineDiff(a, e))
+	}
+}
+
+func TestFlowRouteProblems(t *testing.T) {
+	flow := v1beta1.Flow{Spec: v1beta1.FlowSpec{Routes: []v1beta1.FlowRoute{
+		{Name: "a", Conditions: []v1beta1.RouteCondition{{Key: "level", Pattern: "error"}}},
+		{Name: "a"},
+		{Name: "B", Conditions: []v1beta1.RouteCondition{{Key: "level"}}},
+	}}}
+	assert.Equal(t, []string{
+		`duplicate route name "a"`,
+		`route "a" has no conditions`,
+		`invalid route name "B"`,
+		`route "B" has a condition without key or pattern`,
+	}, FlowRouteProblems(&flow))
+}
@@ -0,0 +1,106 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+
+	"emperror.dev/errors"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/plugins"
+)
+
+// createOutput creates the directive of an output in the namespace with its secondary
+func createOutput(spec v1beta1.OutputSpec, namespace string, id string, clusterOutputs ClusterOutputs, outputs Outputs, secrets SecretLoaderFactory) (types.Directive, error) {
+	plugin, err := plugins.CreateOutput(spec, id, secrets.OutputSecretLoaderForNamespace(namespace))
+	if err != nil || spec.Secondary == nil {
+		return plugin, err
+	}
+
+	secondarySpec, secondaryNamespace, err := resolveSecondary(spec.Secondary, namespace, clusterOutputs, outputs)
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := plugins.CreateOutput(secondarySpec, id+":secondary", secrets.OutputSecretLoaderForNamespace(secondaryNamespace))
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create secondary output")
+	}
+	if _, ok := secondary.(*types.RelabelledOutput); ok {
+		return nil, errors.New("secondary output must write the chunks itself, outputs converting the records are not supported")
+	}
+
+	target := plugin
+	if relabelled, ok := plugin.(*types.RelabelledOutput); ok {
+		// the records reach the secondary from the buffer of the actual output
+		target = relabelled.Target.Outputs[0]
+	}
+	gd, ok := target.(*types.GenericDirective)
+	if !ok {
+		return nil, errors.Errorf("unexpected output directive %T", target)
+	}
+	gd.SubDirectives = append(gd.SubDirectives, secondaryDirective(secondary))
+	return plugin, nil
+}
+
+// resolveSecondary returns the output spec of the secondary and the namespace of its secrets.
+// The secondary of the secondary is not used.
+func resolveSecondary(secondary *v1beta1.OutputSecondary, namespace string, clusterOutputs ClusterOutputs, outputs Outputs) (v1beta1.OutputSpec, string, error) {
+	if problems := secondaryTargetProblems(secondary); len(problems) > 0 {
+		return v1beta1.OutputSpec{}, "", errors.Errorf("invalid secondary: %s", strings.Join(problems, ", "))
+	}
+
+	var spec v1beta1.OutputSpec
+	switch {
+	case secondary.OutputRef != "":
+		output := outputs.FindByNamespacedName(namespace, secondary.OutputRef)
+		if output == nil {
+			return spec, "", errors.Errorf("secondary output not found: %s", secondary.OutputRef)
+		}
+		spec = output.Spec
+	case secondary.ClusterOutputRef != "":
+		clusterOutput := clusterOutputs.FindByName(secondary.ClusterOutputRef)
+		if clusterOutput == nil {
+			return spec, "", errors.Errorf("secondary clusteroutput not found: %s", secondary.ClusterOutputRef)
+		}
+		spec, namespace = clusterOutput.Spec.OutputSpec, clusterOutput.Namespace
+	default:
+		spec = v1beta1.OutputSpec{FileOutput: secondary.File, S3OutputConfig: secondary.S3}
+	}
+	spec.Secondary = nil
+	return spec, namespace, nil
+}
+
+// secondaryDirective turns an output directive into a secondary section.
+// The chunks are written by the secondary from the buffer of the primary output, so its own buffer is dropped.
+func secondaryDirective(output types.Directive) types.Directive {
+	meta := output.GetPluginMeta()
+	secondary := &types.GenericDirective{
+		PluginMeta: types.PluginMeta{
+			Type:      meta.Type,
+			Id:        meta.Id,
+			LogLevel:  meta.LogLevel,
+			Directive: "secondary",
+		},
+		Params: output.GetParams(),
+	}
+	for _, section := range output.GetSections() {
+		if section.GetPluginMeta().Directive != "buffer" {
+			secondary.SubDirectives = append(secondary.SubDirectives, section)
+		}
+	}
+	return secondary
+}
@@ -0,0 +1,134 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/output"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/render"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+func TestOutputSecondary(t *testing.T) {
+	flow := v1beta1.Flow{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Spec: v1beta1.FlowSpec{
+			FlowLabel:        "@test",
+			LocalOutputRefs:  []string{"loki"},
+			GlobalOutputRefs: []string{"archive"},
+		},
+	}
+	clusterOutputs := ClusterOutputs{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "archive", Namespace: "control"},
+			Spec: v1beta1.ClusterOutputSpec{OutputSpec: v1beta1.OutputSpec{
+				NullOutputConfig: &output.NullOutputConfig{},
+				Secondary:        &v1beta1.OutputSecondary{File: &output.FileOutputConfig{Path: "/tmp/archive"}},
+			}},
+		},
+	}
+	outputs := Outputs{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "loki", Namespace: "ns"},
+			Spec: v1beta1.OutputSpec{
+				NullOutputConfig: &output.NullOutputConfig{},
+				Secondary:        &v1beta1.OutputSecondary{OutputRef: "backup"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "ns"},
+			Spec: v1beta1.OutputSpec{
+				FileOutput: &output.FileOutputConfig{Path: "/tmp/backup"},
+				Secondary:  &v1beta1.OutputSecondary{OutputRef: "loki"},
+			},
+		},
+	}
+
+	result, err := FlowForFlow(flow, clusterOutputs, outputs, testSecretLoaderFactory{})
+	require.NoError(t, err)
+
+	b := &bytes.Buffer{}
+	require.NoError(t, (&render.FluentRender{Out: b, Indent: 2}).RenderDirectives([]types.Directive{result}, 0))
+
+	expected := `
+<label @test>
+  <match **>
+    @type copy
+    <store>
+      @type null
+      @id flow:ns:test:clusteroutput:control:archive
+      <secondary>
+        @type file
+        @id flow:ns:test:clusteroutput:control:archive:secondary
+        add_path_suffix true
+        path /tmp/archive
+      </secondary>
+    </store>
+    <store>
+      @type null
+      @id flow:ns:test:output:ns:loki
+      <secondary>
+        @type file
+        @id flow:ns:test:output:ns:loki:secondary
+        add_path_suffix true
+        path /tmp/backup
+      </secondary>
+    </store>
+  </match>
+</label>
+`
+	if a, e := diff.TrimLinesInString(b.String()), diff.TrimLinesInString(expected); a != e {
+		t.Errorf("Result does not match (-actual vs +expected):\n%v", diff.LineDiff(a, e))
+	}
+
+	outputs[0].Spec.Secondary.OutputRef = "missing"
+	_, err = FlowForFlow(flow, clusterOutputs, outputs, testSecretLoaderFactory{})
+	assert.ErrorContains(t, err, "secondary output not found: missing")
+}
+
+func TestOutputSecondaryProblems(t *testing.T) {
+	clusterOutputs := ClusterOutputs{{ObjectMeta: metav1.ObjectMeta{Name: "archive", Namespace: "control"}}}
+	outputs := Outputs{{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "ns"}}}
+	secrets := testSecretLoaderFactory{}.OutputSecretLoaderForNamespace("ns")
+
+	for name, tc := range map[string]struct {
+		secondary     v1beta1.OutputSecondary
+		clusterScoped bool
+		problems      []string
+	}{
+		"output":                  {secondary: v1beta1.OutputSecondary{OutputRef: "backup"}},
+		"cluster output":          {secondary: v1beta1.OutputSecondary{ClusterOutputRef: "archive"}, clusterScoped: true},
+		"inline":                  {secondary: v1beta1.OutputSecondary{File: &output.FileOutputConfig{Path: "/tmp"}}},
+		"no target":               {problems: []string{"no secondary target configured"}},
+		"multiple targets":        {secondary: v1beta1.OutputSecondary{OutputRef: "backup", File: &output.FileOutputConfig{}}, problems: []string{"multiple secondary targets configured: [outputRef file]"}},
+		"dangling output":         {secondary: v1beta1.OutputSecondary{OutputRef: "missing"}, problems: []string{"dangling secondary output reference: missing"}},
+		"dangling cluster output": {secondary: v1beta1.OutputSecondary{ClusterOutputRef: "missing"}, problems: []string{"dangling secondary clusteroutput reference: missing"}},
+		"self":                    {secondary: v1beta1.OutputSecondary{OutputRef: "test"}, problems: []string{"secondary output reference points to the output itself"}},
+		"output of cluster":       {secondary: v1beta1.OutputSecondary{OutputRef: "backup"}, clusterScoped: true, problems: []string{"secondary output reference is not available for cluster outputs"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			spec := v1beta1.OutputSpec{Secondary: &tc.secondary}
+			assert.Equal(t, tc.problems, ProblemMessages(OutputSecondaryProblems(spec, "test", tc.clusterScoped, clusterOutputs, outputs, "ns", secrets)))
+		})
+	}
+}
@@ -120,6 +120,19 @@ func CreateSystem(resources LoggingResources, secrets SecretLoaderFactory, logge
 		}
 	}
 
+	for _, quota := range resources.LogQuotas {
+		q, err := QuotaForLogQuota(quota, fluentdSpec.Metrics != nil)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to create quota", "quota", quota.Name)
+		}
+		if q == nil {
+			continue
+		}
+		if err := builder.RegisterQuota(q); err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to register quota", "quota", quota.Name)
+		}
+	}
+
 	// Set ErrorOutput
 	var errorFlow *types.Flow
 	if resources.Logging.Spec.ErrorOutputRef != "" {
@@ -158,6 +171,11 @@ func CreateSystem(resources LoggingResources, secrets SecretLoaderFactory, logge
 			for _, output := range flow.Outputs {
 				unsetBufferPath(output)
 			}
+			for _, route := range flow.Routes {
+				for _, output := range route.Outputs {
+					unsetBufferPath(output)
+				}
+			}
 		}
 	}
 
@@ -169,6 +187,11 @@ func unsetBufferPath(directive types.Directive) {
 		delete(gd.Params, "path")
 		return
 	}
+	if relabelled, _ := directive.(*types.RelabelledOutput); relabelled != nil {
+		for _, output := range relabelled.Target.Outputs {
+			unsetBufferPath(output)
+		}
+	}
 	for _, d := range directive.GetSections() {
 		unsetBufferPath(d)
 	}
@@ -185,12 +208,14 @@ func filtersForFilters(flowID string, flowName string, secretLoader secret.Secre
 	)
 	for i, f := range filters {
 		id := fmt.Sprintf("%s:%d", flowID, i)
-		filter, err := plugins.CreateFilter(f, id, secretLoader)
+		directives, err := plugins.CreateFilters(f, id, secretLoader)
 		if err != nil {
 			errs = errors.Append(errs, errors.WrapIff(err, "failed to create filter with index %d for flow %s", i, flowName))
 			continue
 		}
-		result = append(result, filter)
+		for _, filter := range directives {
+			result = append(result, filter)
+		}
 	}
 	return result, errs
 }
@@ -205,7 +230,7 @@ func FlowForError(outputRef string, clusterOutputs ClusterOutputs, secrets Secre
 	}
 
 	if clusterOutput := clusterOutputs.FindByName(outputRef); clusterOutput != nil {
-		plugin, err := plugins.CreateOutput(clusterOutput.Spec.OutputSpec, "main-fluentd-error", secrets.OutputSecretLoaderForNamespace(clusterOutput.Namespace))
+		plugin, err := createOutput(clusterOutput.Spec.OutputSpec, clusterOutput.Namespace, "main-fluentd-error", clusterOutputs, nil, secrets)
 		if err != nil {
 			return nil, errors.WrapIff(err, "failed to create configured output %q", outputRef)
 		}
@@ -220,7 +245,12 @@ func FlowForFlow(flow v1beta1.Flow, clusterOutputs ClusterOutputs, outputs Outpu
 		return nil, errors.Errorf("match and selectors cannot be defined simultaneously for flow %s",
 			utils.ObjectKeyFromObjectMeta(&flow).String())
 	}
+	if flow.Spec.MatchExpression != nil && (flow.Spec.Match != nil || flow.Spec.Selectors != nil) {
+		return nil, errors.Errorf("matchExpression cannot be defined together with match or selectors for flow %s",
+			utils.ObjectKeyFromObjectMeta(&flow).String())
+	}
 
+	var selectors []selector
 	var matches []types.FlowMatch
 	if flow.Spec.Match != nil {
 		for _, match := range flow.Spec.Match {
@@ -230,21 +260,29 @@ func FlowForFlow(flow v1beta1.Flow, clusterOutputs ClusterOutputs, outputs Outpu
 			}
 
 			if match.Select != nil {
-				matches = append(matches, types.FlowMatch{
-					Labels:         match.Select.Labels,
-					ContainerNames: match.Select.ContainerNames,
-					Hosts:          match.Select.Hosts,
-					Namespaces:     []string{flow.Namespace},
-					Negate:         false,
+				selectors = append(selectors, selector{
+					FlowMatch: types.FlowMatch{
+						Labels:         match.Select.Labels,
+						ContainerNames: match.Select.ContainerNames,
+						Hosts:          match.Select.Hosts,
+						Namespaces:     []string{flow.Namespace},
+						Negate:         false,
+					},
+					Annotations:      match.Select.Annotations,
+					LabelExpressions: match.Select.LabelExpressions,
 				})
 			}
 			if match.Exclude != nil {
-				matches = append(matches, types.FlowMatch{
-					Labels:         match.Exclude.Labels,
-					ContainerNames: match.Exclude.ContainerNames,
-					Hosts:          match.Exclude.Hosts,
-					Namespaces:     []string{flow.Namespace},
-					Negate:         true,
+				selectors = append(selectors, selector{
+					FlowMatch: types.FlowMatch{
+						Labels:         match.Exclude.Labels,
+						ContainerNames: match.Exclude.ContainerNames,
+						Hosts:          match.Exclude.Hosts,
+						Namespaces:     []string{flow.Namespace},
+						Negate:         true,
+					},
+					Annotations:      match.Exclude.Annotations,
+					LabelExpressions: match.Exclude.LabelExpressions,
 				})
 			}
 		}
@@ -260,22 +298,61 @@ func FlowForFlow(flow v1beta1.Flow, clusterOutputs ClusterOutputs, outputs Outpu
 
 	flowID := fmt.Sprintf("flow:%s:%s", flow.Namespace, flow.Name)
 
+	var matchFilters []types.Filter
+	if selectors != nil {
+		var err error
+		matches, matchFilters, err = matchSelectors(selectors, types.FlowMatch{Namespaces: []string{flow.Namespace}}, flowID)
+		if err != nil {
+			return nil, errors.WrapIff(err, "flow %s", utils.ObjectKeyFromObjectMeta(&flow).String())
+		}
+	}
+	if flow.Spec.MatchExpression != nil {
+		var err error
+		matches, matchFilters, err = matchExpression(flow.Spec.MatchExpression, types.FlowMatch{Namespaces: []string{flow.Namespace}}, flowID)
+		if err != nil {
+			return nil, errors.WrapIff(err, "flow %s", utils.ObjectKeyFromObjectMeta(&flow).String())
+		}
+	}
+
 	result, err := types.NewFlow(matches, flowID, flow.Name, flow.Namespace, flow.Spec.FlowLabel, flow.Spec.IncludeLabelInRouter)
 	if err != nil {
 		return nil, err
 	}
 
-	var errs error
+	allOutputs, errs := outputsForRefs(flowID, flow, flow.Spec.GlobalOutputRefs, flow.Spec.LocalOutputRefs, clusterOutputs, outputs, secrets)
 
-	var allOutputs []types.Output
-	for _, outputRef := range flow.Spec.GlobalOutputRefs {
+	filters, err := filtersForFilters(flowID, flow.Name, secrets.OutputSecretLoaderForNamespace(flow.Namespace), flow.Spec.Filters)
+	errs = errors.Append(errs, err)
+	result.WithFilters(append(matchFilters, filters...)...)
+
+	if len(flow.Spec.Routes) == 0 {
+		result.WithOutputs(allOutputs...)
+		return result, errs
+	}
+	routeFilter, routes, err := routesForFlow(result, flow, allOutputs, clusterOutputs, outputs, secrets)
+	errs = errors.Append(errs, err)
+	if routeFilter != nil {
+		result.WithFilters(routeFilter)
+	}
+	result.WithRoutes(routes...)
+
+	return result, errs
+}
+
+// outputsForRefs creates the outputs referenced by a Flow or one of its routes
+func outputsForRefs(id string, flow v1beta1.Flow, globalOutputRefs []string, localOutputRefs []string, clusterOutputs ClusterOutputs, outputs Outputs, secrets SecretLoaderFactory) ([]types.Output, error) {
+	var (
+		allOutputs []types.Output
+		errs       error
+	)
+	for _, outputRef := range globalOutputRefs {
 		if clusterOutput := clusterOutputs.FindByName(outputRef); clusterOutput != nil {
 			if clusterOutput.Spec.Protected {
 				errs = errors.Append(errs, errors.Errorf("referenced clusteroutput is protected: %s", outputRef))
 				continue
 			}
-			outputID := fmt.Sprintf("%s:clusteroutput:%s:%s", flowID, clusterOutput.Namespace, clusterOutput.Name)
-			plugin, err := plugins.CreateOutput(clusterOutput.Spec.OutputSpec, outputID, secrets.OutputSecretLoaderForNamespace(clusterOutput.Namespace))
+			outputID := fmt.Sprintf("%s:clusteroutput:%s:%s", id, clusterOutput.Namespace, clusterOutput.Name)
+			plugin, err := createOutput(clusterOutput.Spec.OutputSpec, clusterOutput.Namespace, outputID, clusterOutputs, nil, secrets)
 			if err != nil {
 				errs = errors.Append(errs, errors.WrapIff(err, "failed to create configured output %s", outputRef))
 				continue
@@ -285,10 +362,10 @@ func FlowForFlow(flow v1beta1.Flow, clusterOutputs ClusterOutputs, outputs Outpu
 			errs = errors.Append(errs, errors.Errorf("referenced clusteroutput not found: %s", outputRef))
 		}
 	}
-	for _, outputRef := range flow.Spec.LocalOutputRefs {
+	for _, outputRef := range localOutputRefs {
 		if output := outputs.FindByNamespacedName(flow.Namespace, outputRef); output != nil {
-			outputID := fmt.Sprintf("%s:output:%s:%s", flowID, output.Namespace, output.Name)
-			plugin, err := plugins.CreateOutput(output.Spec, outputID, secrets.OutputSecretLoaderForNamespace(output.Namespace))
+			outputID := fmt.Sprintf("%s:output:%s:%s", id, output.Namespace, output.Name)
+			plugin, err := createOutput(output.Spec, output.Namespace, outputID, clusterOutputs, outputs, secrets)
 			if err != nil {
 				errs = errors.Append(errs, errors.WrapIff(err, "failed to create configured output %s/%s", output.Namespace, output.Name))
 				continue
@@ -298,13 +375,7 @@ func FlowForFlow(flow v1beta1.Flow, clusterOutputs ClusterOutputs, outputs Outpu
 			errs = errors.Append(errs, errors.Errorf("referenced output %s not found for flow %s/%s", outputRef, flow.Namespace, flow.Name))
 		}
 	}
-	result.WithOutputs(allOutputs...)
-
-	filters, err := filtersForFilters(flowID, flow.Name, secrets.OutputSecretLoaderForNamespace(flow.Namespace), flow.Spec.Filters)
-	errs = errors.Append(errs, err)
-	result.WithFilters(filters...)
-
-	return result, errs
+	return allOutputs, errs
 }
 
 func FlowForClusterFlow(flow v1beta1.ClusterFlow, clusterOutputs ClusterOutputs, secrets SecretLoaderFactory) (*types.Flow, error) {
@@ -312,7 +383,12 @@ func FlowForClusterFlow(flow v1beta1.ClusterFlow, clusterOutputs ClusterOutputs,
 		return nil, errors.Errorf("match and selectors cannot be defined simultaneously for clusterflow %s",
 			utils.ObjectKeyFromObjectMeta(&flow).String())
 	}
+	if flow.Spec.MatchExpression != nil && (flow.Spec.Match != nil || flow.Spec.Selectors != nil) {
+		return nil, errors.Errorf("matchExpression cannot be defined together with match or selectors for clusterflow %s",
+			utils.ObjectKeyFromObjectMeta(&flow).String())
+	}
 
+	var selectors []selector
 	var matches []types.FlowMatch
 	if flow.Spec.Match != nil {
 		for _, match := range flow.Spec.Match {
@@ -322,25 +398,33 @@ func FlowForClusterFlow(flow v1beta1.ClusterFlow, clusterOutputs ClusterOutputs,
 			}
 
 			if match.ClusterSelect != nil {
-				matches = append(matches, types.FlowMatch{
-					Labels:          match.ClusterSelect.Labels,
-					NamespaceLabels: match.ClusterSelect.NamespaceLabels,
-					ContainerNames:  match.ClusterSelect.ContainerNames,
-					Hosts:           match.ClusterSelect.Hosts,
-					Namespaces:      match.ClusterSelect.Namespaces,
-					NamespacesRegex: match.ClusterSelect.NamespacesRegex,
-					Negate:          false,
+				selectors = append(selectors, selector{
+					FlowMatch: types.FlowMatch{
+						Labels:          match.ClusterSelect.Labels,
+						NamespaceLabels: match.ClusterSelect.NamespaceLabels,
+						ContainerNames:  match.ClusterSelect.ContainerNames,
+						Hosts:           match.ClusterSelect.Hosts,
+						Namespaces:      match.ClusterSelect.Namespaces,
+						NamespacesRegex: match.ClusterSelect.NamespacesRegex,
+						Negate:          false,
+					},
+					Annotations:      match.ClusterSelect.Annotations,
+					LabelExpressions: match.ClusterSelect.LabelExpressions,
 				})
 			}
 			if match.ClusterExclude != nil {
-				matches = append(matches, types.FlowMatch{
-					Labels:          match.ClusterExclude.Labels,
-					NamespaceLabels: match.ClusterExclude.NamespaceLabels,
-					ContainerNames:  match.ClusterExclude.ContainerNames,
-					Hosts:           match.ClusterExclude.Hosts,
-					Namespaces:      match.ClusterExclude.Namespaces,
-					NamespacesRegex: match.ClusterExclude.NamespacesRegex,
-					Negate:          true,
+				selectors = append(selectors, selector{
+					FlowMatch: types.FlowMatch{
+						Labels:          match.ClusterExclude.Labels,
+						NamespaceLabels: match.ClusterExclude.NamespaceLabels,
+						ContainerNames:  match.ClusterExclude.ContainerNames,
+						Hosts:           match.ClusterExclude.Hosts,
+						Namespaces:      match.ClusterExclude.Namespaces,
+						NamespacesRegex: match.ClusterExclude.NamespacesRegex,
+						Negate:          true,
+					},
+					Annotations:      match.ClusterExclude.Annotations,
+					LabelExpressions: match.ClusterExclude.LabelExpressions,
 				})
 			}
 		}
@@ -356,6 +440,22 @@ func FlowForClusterFlow(flow v1beta1.ClusterFlow, clusterOutputs ClusterOutputs,
 
 	flowID := fmt.Sprintf("clusterflow:%s:%s", flow.Namespace, flow.Name)
 
+	var matchFilters []types.Filter
+	if selectors != nil {
+		var err error
+		matches, matchFilters, err = matchSelectors(selectors, types.FlowMatch{}, flowID)
+		if err != nil {
+			return nil, errors.WrapIff(err, "clusterflow %s", utils.ObjectKeyFromObjectMeta(&flow).String())
+		}
+	}
+	if flow.Spec.MatchExpression != nil {
+		var err error
+		matches, matchFilters, err = matchExpression(flow.Spec.MatchExpression, types.FlowMatch{}, flowID)
+		if err != nil {
+			return nil, errors.WrapIff(err, "clusterflow %s", utils.ObjectKeyFromObjectMeta(&flow).String())
+		}
+	}
+
 	result, err := types.NewFlow(matches, flowID, flow.Name, flow.Namespace, flow.Spec.FlowLabel, flow.Spec.IncludeLabelInRouter)
 	if err != nil {
 		return nil, err
@@ -367,7 +467,7 @@ func FlowForClusterFlow(flow v1beta1.ClusterFlow, clusterOutputs ClusterOutputs,
 	for _, outputRef := range flow.Spec.GlobalOutputRefs {
 		if clusterOutput := clusterOutputs.FindByName(outputRef); clusterOutput != nil {
 			outputID := fmt.Sprintf("%s:clusteroutput:%s:%s", flowID, clusterOutput.Namespace, clusterOutput.Name)
-			plugin, err := plugins.CreateOutput(clusterOutput.Spec.OutputSpec, outputID, secrets.OutputSecretLoaderForNamespace(clusterOutput.Namespace))
+			plugin, err := createOutput(clusterOutput.Spec.OutputSpec, clusterOutput.Namespace, outputID, clusterOutputs, nil, secrets)
 			if err != nil {
 				errs = errors.Append(errs, errors.WrapIff(err, "failed to create configured output %q", outputRef))
 				continue
@@ -381,7 +481,7 @@ func FlowForClusterFlow(flow v1beta1.ClusterFlow, clusterOutputs ClusterOutputs,
 
 	filters, err := filtersForFilters(flowID, flow.Name, secrets.OutputSecretLoaderForNamespace(flow.Namespace), flow.Spec.Filters)
 	errs = errors.Append(errs, err)
-	result.WithFilters(filters...)
+	result.WithFilters(append(matchFilters, filters...)...)
 
 	return result, errs
 }
@@ -404,7 +504,7 @@ func FlowForDefaultFlow(logging v1beta1.Logging, clusterOutputs ClusterOutputs,
 	for _, outputRef := range logging.Spec.DefaultFlowSpec.GlobalOutputRefs {
 		if clusterOutput := clusterOutputs.FindByName(outputRef); clusterOutput != nil {
 			outputID := fmt.Sprintf("%s:clusteroutput:%s:%s", flowID, clusterOutput.Namespace, clusterOutput.Name)
-			plugin, err := plugins.CreateOutput(clusterOutput.Spec.OutputSpec, outputID, secrets.OutputSecretLoaderForNamespace(clusterOutput.Namespace))
+			plugin, err := createOutput(clusterOutput.Spec.OutputSpec, clusterOutput.Namespace, outputID, clusterOutputs, nil, secrets)
 			if err != nil {
 				errs = errors.Append(errs, errors.WrapIff(err, "failed to create configured output %q", outputRef))
 				continue
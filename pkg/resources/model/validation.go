@@ -0,0 +1,303 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/filter"
+)
+
+// ProblemKind tells apart the problems of a resource that may resolve without changing it
+type ProblemKind int
+
+const (
+	// ProblemInvalid is a problem of the resource itself
+	ProblemInvalid ProblemKind = iota
+	// ProblemMissingReference is caused by a referenced object that doesn't exist (yet)
+	ProblemMissingReference
+)
+
+// Problem is a problem of a resource reported in its status
+type Problem struct {
+	Kind    ProblemKind
+	Message string
+}
+
+func invalidProblem(format string, args ...any) Problem {
+	return Problem{Kind: ProblemInvalid, Message: fmt.Sprintf(format, args...)}
+}
+
+func missingReferenceProblem(format string, args ...any) Problem {
+	return Problem{Kind: ProblemMissingReference, Message: fmt.Sprintf(format, args...)}
+}
+
+// InvalidProblems returns the messages as problems of the resource itself
+func InvalidProblems(messages ...string) []Problem {
+	problems := make([]Problem, 0, len(messages))
+	for _, message := range messages {
+		problems = append(problems, Problem{Kind: ProblemInvalid, Message: message})
+	}
+	return problems
+}
+
+// ProblemMessages returns the messages of the problems
+func ProblemMessages(problems []Problem) []string {
+	var messages []string
+	for _, problem := range problems {
+		messages = append(messages, problem.Message)
+	}
+	return messages
+}
+
+// ClusterFlowOutputRefProblems checks the global output references of a ClusterFlow.
+// It returns the problems found and the referenced cluster outputs that are usable by the flow.
+func ClusterFlowOutputRefProblems(flow *v1beta1.ClusterFlow, clusterOutputs ClusterOutputs) (problems []Problem, valid []*v1beta1.ClusterOutput) {
+	for _, ref := range flow.Spec.GlobalOutputRefs {
+		switch output := clusterOutputs.FindByName(ref); {
+		case output == nil:
+			problems = append(problems, missingReferenceProblem("dangling global output reference: %s", ref))
+
+		case output.Status.ProblemsCount > 0:
+			problems = append(problems, invalidProblem("global output reference: %s has problems", output.Name))
+
+		default:
+			valid = append(valid, output)
+		}
+	}
+	return
+}
+
+// FlowOutputRefProblems checks the global and local output references of a Flow.
+// It returns the problems found and the referenced outputs that are usable by the flow.
+// The output references of the routes of the flow are checked as well.
+func FlowOutputRefProblems(flow *v1beta1.Flow, clusterOutputs ClusterOutputs, outputs Outputs) (problems []Problem, validClusterOutputs []*v1beta1.ClusterOutput, validOutputs []*v1beta1.Output) {
+	globalOutputRefs, localOutputRefs := slices.Clone(flow.Spec.GlobalOutputRefs), slices.Clone(flow.Spec.LocalOutputRefs)
+	for _, route := range flow.Spec.Routes {
+		globalOutputRefs = appendUnique(globalOutputRefs, route.GlobalOutputRefs...)
+		localOutputRefs = appendUnique(localOutputRefs, route.LocalOutputRefs...)
+	}
+
+	for _, ref := range globalOutputRefs {
+		switch output := clusterOutputs.FindByName(ref); {
+		case output == nil:
+			problems = append(problems, missingReferenceProblem("dangling global output reference: %s", ref))
+
+		case output.Spec.Protected:
+			problems = append(problems, invalidProblem("global output reference is protected: %s", ref))
+
+		case output.Status.ProblemsCount > 0:
+			problems = append(problems, invalidProblem("global output reference: %s has problems", output.Name))
+
+		default:
+			validClusterOutputs = append(validClusterOutputs, output)
+		}
+	}
+
+	for _, ref := range localOutputRefs {
+		switch output := outputs.FindByNamespacedName(flow.Namespace, ref); {
+		case output == nil:
+			problems = append(problems, missingReferenceProblem("dangling local output reference: %s", ref))
+
+		case output.Status.ProblemsCount > 0:
+			problems = append(problems, invalidProblem("local output reference: %s has problems", output.Name))
+
+		default:
+			validOutputs = append(validOutputs, output)
+		}
+	}
+
+	// Check if the flow has become dangling with no valid outputs.
+	// It resolves without changing the flow only if every reference is missing, not invalid or absent.
+	if len(validClusterOutputs)+len(validOutputs) == 0 {
+		problem := invalidProblem("flow has become dangling with no valid outputs")
+		if len(problems) > 0 && !slices.ContainsFunc(problems, func(p Problem) bool { return p.Kind != ProblemMissingReference }) {
+			problem.Kind = ProblemMissingReference
+		}
+		problems = append(problems, problem)
+	}
+	return
+}
+
+// FlowMatchProblems checks the label expressions of the Flow match statements and its match expression
+func FlowMatchProblems(flow *v1beta1.Flow) (problems []string) {
+	if flow.Spec.MatchExpression != nil && (flow.Spec.Match != nil || flow.Spec.Selectors != nil) {
+		problems = append(problems, "matchExpression cannot be defined together with match or selectors")
+	}
+	for _, match := range flow.Spec.Match {
+		if match.Select != nil {
+			problems = append(problems, LabelExpressionProblems(match.Select.LabelExpressions)...)
+		}
+		if match.Exclude != nil {
+			problems = append(problems, LabelExpressionProblems(match.Exclude.LabelExpressions)...)
+		}
+	}
+	return append(problems, MatchExpressionProblems(flow.Spec.MatchExpression)...)
+}
+
+var routeNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// FlowRouteProblems checks the names and conditions of the routes of a Flow
+func FlowRouteProblems(flow *v1beta1.Flow) (problems []string) {
+	names := make(map[string]bool)
+	for _, route := range flow.Spec.Routes {
+		if !routeNameRegexp.MatchString(route.Name) {
+			problems = append(problems, fmt.Sprintf("invalid route name %q", route.Name))
+		}
+		if names[route.Name] {
+			problems = append(problems, fmt.Sprintf("duplicate route name %q", route.Name))
+		}
+		names[route.Name] = true
+		if len(route.Conditions) == 0 {
+			problems = append(problems, fmt.Sprintf("route %q has no conditions", route.Name))
+		}
+		for _, c := range route.Conditions {
+			if c.Key == "" || c.Pattern == "" {
+				problems = append(problems, fmt.Sprintf("route %q has a condition without key or pattern", route.Name))
+			}
+		}
+	}
+	return
+}
+
+// OutputSecondaryProblems checks the secondary of an output: exactly one target is set, the referenced output exists
+// and the secrets of an inline target can be loaded. Cluster outputs cannot reference namespaced outputs.
+func OutputSecondaryProblems(spec v1beta1.OutputSpec, name string, clusterScoped bool, clusterOutputs ClusterOutputs, outputs Outputs, namespace string, secrets secret.SecretLoader) (problems []Problem) {
+	secondary := spec.Secondary
+	if secondary == nil {
+		return nil
+	}
+	if problems = InvalidProblems(secondaryTargetProblems(secondary)...); len(problems) > 0 {
+		return
+	}
+
+	switch {
+	case secondary.OutputRef != "":
+		switch {
+		case clusterScoped:
+			problems = append(problems, invalidProblem("secondary output reference is not available for cluster outputs"))
+		case secondary.OutputRef == name:
+			problems = append(problems, invalidProblem("secondary output reference points to the output itself"))
+		case outputs.FindByNamespacedName(namespace, secondary.OutputRef) == nil:
+			problems = append(problems, missingReferenceProblem("dangling secondary output reference: %s", secondary.OutputRef))
+		}
+	case secondary.ClusterOutputRef != "":
+		switch {
+		case clusterScoped && secondary.ClusterOutputRef == name:
+			problems = append(problems, invalidProblem("secondary output reference points to the output itself"))
+		case clusterOutputs.FindByName(secondary.ClusterOutputRef) == nil:
+			problems = append(problems, missingReferenceProblem("dangling secondary clusteroutput reference: %s", secondary.ClusterOutputRef))
+		}
+	default:
+		problems = append(problems, InvalidProblems(checkSecrets(reflect.ValueOf(secondary.File), secrets)...)...)
+		problems = append(problems, InvalidProblems(checkSecrets(reflect.ValueOf(secondary.S3), secrets)...)...)
+	}
+	return
+}
+
+// secondaryTargetProblems checks that exactly one target of the secondary is set
+func secondaryTargetProblems(secondary *v1beta1.OutputSecondary) (problems []string) {
+	var targets []string
+	if secondary.OutputRef != "" {
+		targets = append(targets, "outputRef")
+	}
+	if secondary.ClusterOutputRef != "" {
+		targets = append(targets, "clusterOutputRef")
+	}
+	if secondary.File != nil {
+		targets = append(targets, "file")
+	}
+	if secondary.S3 != nil {
+		targets = append(targets, "s3")
+	}
+
+	switch len(targets) {
+	case 0:
+		problems = append(problems, "no secondary target configured")
+	case 1:
+		// OK
+	default:
+		problems = append(problems, fmt.Sprintf("multiple secondary targets configured: %s", targets))
+	}
+	return
+}
+
+func appendUnique(values []string, newValues ...string) []string {
+	for _, v := range newValues {
+		if !slices.Contains(values, v) {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// ClusterFlowMatchProblems checks the namespace regular expressions and label expressions of the ClusterFlow match statements and its match expression
+func ClusterFlowMatchProblems(flow *v1beta1.ClusterFlow) (problems []string) {
+	if flow.Spec.MatchExpression != nil && (flow.Spec.Match != nil || flow.Spec.Selectors != nil) {
+		problems = append(problems, "matchExpression cannot be defined together with match or selectors")
+	}
+	check := func(expressions []string) {
+		for _, expr := range expressions {
+			if _, err := regexp.Compile(expr); err != nil {
+				problems = append(problems, fmt.Sprintf("invalid namespaces_regex %q: %s", expr, err))
+			}
+		}
+	}
+	for _, match := range flow.Spec.Match {
+		if match.ClusterSelect != nil {
+			check(match.ClusterSelect.NamespacesRegex)
+			problems = append(problems, LabelExpressionProblems(match.ClusterSelect.LabelExpressions)...)
+		}
+		if match.ClusterExclude != nil {
+			check(match.ClusterExclude.NamespacesRegex)
+			problems = append(problems, LabelExpressionProblems(match.ClusterExclude.LabelExpressions)...)
+		}
+	}
+	return append(problems, MatchExpressionProblems(flow.Spec.MatchExpression)...)
+}
+
+// SyslogNGMatchProblems checks the label expressions of the selectors of a syslog-ng flow match
+func SyslogNGMatchProblems(match *v1beta1.SyslogNGMatch) (problems []string) {
+	var check func(expr *filter.MatchExpr)
+	check = func(expr *filter.MatchExpr) {
+		if expr == nil {
+			return
+		}
+		if expr.Selector != nil {
+			for _, e := range expr.Selector.LabelExpressions {
+				problems = append(problems, LabelExpressionProblems([]v1beta1.LabelExpression{{
+					Key:      e.Key,
+					Operator: v1beta1.LabelOperator(e.Operator),
+					Values:   e.Values,
+				}})...)
+			}
+		}
+		for i := range expr.And {
+			check(&expr.And[i])
+		}
+		for i := range expr.Or {
+			check(&expr.Or[i])
+		}
+		check(expr.Not)
+	}
+	check((*filter.MatchExpr)(match))
+	return
+}
@@ -0,0 +1,131 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package podmetrics scrapes the metrics the pods of the aggregators expose in the Prometheus text format
+package podmetrics
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const scrapeTimeout = 5 * time.Second
+
+// Scraper fetches the metrics exposed on the same port and path by each pod
+type Scraper struct {
+	HTTPClient *http.Client
+	Port       int32
+	Path       string
+}
+
+// Families are the metric families by name
+type Families map[string]*dto.MetricFamily
+
+// RunningPods lists the running pods with an IP address, the ones the metrics can be scraped from
+func RunningPods(ctx context.Context, c client.Reader, namespace string, labels client.MatchingLabels) ([]corev1.Pod, error) {
+	var pods corev1.PodList
+	if err := c.List(ctx, &pods, client.InNamespace(namespace), labels); err != nil {
+		return nil, errors.WrapIf(err, "listing aggregator pods")
+	}
+	var running []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != "" {
+			running = append(running, pod)
+		}
+	}
+	return running, nil
+}
+
+// Scrape returns the metrics of the pod
+func (s Scraper) Scrape(ctx context.Context, pod corev1.Pod) (Families, error) {
+	url := fmt.Sprintf("http://%s/%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(s.Port))), strings.TrimPrefix(s.Path, "/"))
+	families, err := s.Get(ctx, url)
+	return families, errors.WrapIfWithDetails(err, "scraping metrics", "pod", pod.Name)
+}
+
+// Get returns the metrics exposed at the url
+func (s Scraper) Get(ctx context.Context, url string) (Families, error) {
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: scrapeTimeout}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, errors.WrapIf(err, "parsing metrics")
+	}
+	return families, nil
+}
+
+// Each calls fn with the value of every counter, gauge or untyped sample of the metric
+func (f Families) Each(name string, fn func(m *dto.Metric, value float64)) {
+	family, ok := f[name]
+	if !ok {
+		return
+	}
+	for _, m := range family.GetMetric() {
+		switch {
+		case m.Counter != nil:
+			fn(m, m.GetCounter().GetValue())
+		case m.Gauge != nil:
+			fn(m, m.GetGauge().GetValue())
+		case m.Untyped != nil:
+			fn(m, m.GetUntyped().GetValue())
+		}
+	}
+}
+
+// Sum returns the sum of the samples of the metrics
+func (f Families) Sum(names ...string) (sum float64) {
+	for _, name := range names {
+		f.Each(name, func(_ *dto.Metric, value float64) {
+			sum += value
+		})
+	}
+	return
+}
+
+// Label returns the value of the label of the sample, empty if it doesn't have the label
+func Label(m *dto.Metric, name string) string {
+	for _, label := range m.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podmetrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScrape(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `# TYPE fluentd_output_status_num_errors gauge`)
+		fmt.Fprintln(w, `fluentd_output_status_num_errors{plugin_id="a"} 2`)
+		fmt.Fprintln(w, `fluentd_output_status_num_errors{plugin_id="b"} 1`)
+		fmt.Fprintln(w, `# TYPE fluentd_output_status_retry_count gauge`)
+		fmt.Fprintln(w, `fluentd_output_status_retry_count{plugin_id="a"} 4`)
+		fmt.Fprintln(w, `# TYPE fluentd_output_status_emit_records gauge`)
+		fmt.Fprintln(w, `fluentd_output_status_emit_records{plugin_id="a"} 100`)
+	}))
+	defer server.Close()
+
+	families, err := Scraper{HTTPClient: server.Client()}.Get(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, float64(7), families.Sum("fluentd_output_status_num_errors", "fluentd_output_status_retry_count"))
+
+	byPlugin := map[string]float64{}
+	families.Each("fluentd_output_status_num_errors", func(m *dto.Metric, value float64) {
+		byPlugin[Label(m, "plugin_id")] += value
+	})
+	assert.Equal(t, map[string]float64{"a": 2, "b": 1}, byPlugin)
+
+	_, err = Scraper{HTTPClient: server.Client()}.Get(context.Background(), server.URL+"/%zz")
+	assert.Error(t, err)
+}
@@ -0,0 +1,302 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/configcheck"
+	"github.com/kube-logging/logging-operator/pkg/resources/podmetrics"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+const (
+	PhaseProgressing = "Progressing"
+	PhasePromoted    = "Promoted"
+	PhaseAborted     = "Aborted"
+
+	// EventReasonPromoted is the reason of the event emitted when a canary config is promoted to every replica
+	EventReasonPromoted = "ConfigPromoted"
+	// EventReasonAborted is the reason of the event emitted when a canary rollout is aborted
+	EventReasonAborted = "ConfigRolloutAborted"
+
+	defaultAnalysisSeconds = 300
+	requeueInterval        = 15 * time.Second
+)
+
+// Canary drives the canary rollout of an aggregator config using the partition of the StatefulSet rolling update.
+// The replicas with an ordinal at or above the partition run the canary config, the rest of them keep the stable one.
+type Canary struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+	Log      logr.Logger
+	Logging  *v1beta1.Logging
+	Spec     v1beta1.CanaryRollout
+	// StatefulSet of the aggregator
+	StatefulSet client.ObjectKey
+	// PodLabels select the pods of the aggregator
+	PodLabels client.MatchingLabels
+	// MetricsPort and MetricsPath of the aggregator pods, metrics are not checked when the port is 0
+	MetricsPort int32
+	MetricsPath string
+	// ErrorMetrics are used unless the spec overrides them
+	ErrorMetrics []string
+	HTTPClient   *http.Client
+}
+
+// Decision tells which configs the replicas of the StatefulSet should run
+type Decision struct {
+	// Hash of the config referenced by the pod template
+	TemplateHash string
+	// Hash of the config running on the replicas below the partition
+	StableHash string
+	// Partition of the rolling update
+	Partition int32
+	// RequeueAfter is set while the canary replicas are analyzed
+	RequeueAfter time.Duration
+}
+
+// Pending returns true if the config with the hash has not been promoted to every replica by a canary rollout
+func Pending(logging *v1beta1.Logging, hash string) bool {
+	status := logging.Status.ConfigRollout
+	return status != nil && status.CanaryHash == hash && status.Phase != PhasePromoted
+}
+
+// Reconcile advances the rollout of the config with the given hash and returns the desired state of the StatefulSet.
+// The result is only set without a decision, when the reconciliation should be restarted after a status update.
+func (c *Canary) Reconcile(ctx context.Context, patchBase client.Patch, hash string) (*Decision, *reconcile.Result, error) {
+	status := c.Logging.Status.ConfigRollout
+
+	switch {
+	case status == nil || status.StableHash == "":
+		// nothing to compare to, the first config is considered stable
+		c.Logging.Status.ConfigRollout = &v1beta1.ConfigRolloutStatus{
+			StableHash: hash,
+			Phase:      PhasePromoted,
+		}
+		return c.stable(hash), nil, c.patchStatus(ctx, patchBase)
+
+	case status.StableHash == hash:
+		if status.Phase == PhaseProgressing {
+			status.Phase = PhaseAborted
+			status.Message = "the rollout has been superseded by the stable config"
+			status.AnalysisStartTime = nil
+			if err := c.patchStatus(ctx, patchBase); err != nil {
+				return nil, nil, err
+			}
+		}
+		return c.stable(hash), nil, nil
+
+	case status.CanaryHash == hash && status.Phase == PhaseAborted:
+		// keep the stable config until the config changes
+		return c.stable(status.StableHash), nil, nil
+
+	case status.CanaryHash != hash:
+		status.CanaryHash = hash
+		status.Phase = PhaseProgressing
+		status.AnalysisStartTime = nil
+		status.BaselineErrors = 0
+		status.Message = "waiting for the canary replicas to become ready"
+		if err := c.patchStatus(ctx, patchBase); err != nil {
+			return nil, nil, err
+		}
+		return nil, &reconcile.Result{Requeue: true}, nil
+	}
+
+	return c.analyze(ctx, patchBase, status)
+}
+
+func (c *Canary) analyze(ctx context.Context, patchBase client.Patch, status *v1beta1.ConfigRolloutStatus) (*Decision, *reconcile.Result, error) {
+	sts := &appsv1.StatefulSet{}
+	if err := c.Client.Get(ctx, c.StatefulSet, sts); err != nil {
+		return nil, nil, errors.WrapIf(client.IgnoreNotFound(err), "getting statefulset")
+	}
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	decision := &Decision{
+		TemplateHash: status.CanaryHash,
+		StableHash:   status.StableHash,
+		Partition:    replicas - CanaryReplicas(c.Spec.Replicas, replicas),
+	}
+
+	var pods corev1.PodList
+	if err := c.Client.List(ctx, &pods, client.InNamespace(c.StatefulSet.Namespace), c.PodLabels); err != nil {
+		return nil, nil, errors.WrapIf(err, "listing statefulset pods")
+	}
+	canaries := canaryPods(pods.Items, c.StatefulSet.Name, decision.Partition)
+
+	if configcheck.CrashLooping(canaries) {
+		return c.abort(ctx, patchBase, status, "the canary replicas are crash-looping")
+	}
+	decision.RequeueAfter = requeueInterval
+	if !updated(canaries, sts.Status.UpdateRevision, replicas-decision.Partition) || !configcheck.AllReady(canaries) {
+		return decision, nil, nil
+	}
+
+	errorCount, err := c.errorCount(ctx, canaries)
+	if err != nil {
+		c.Log.Info("failed to scrape the metrics of the canary replicas, retrying", "error", err.Error())
+		return decision, nil, nil
+	}
+
+	if status.AnalysisStartTime == nil || errorCount < status.BaselineErrors {
+		// start the analysis, or restart it if the counters have been reset
+		now := metav1.Now()
+		status.AnalysisStartTime = &now
+		status.BaselineErrors = errorCount
+		status.Message = "analyzing the canary replicas"
+		if err := c.patchStatus(ctx, patchBase); err != nil {
+			return nil, nil, err
+		}
+		return decision, nil, nil
+	}
+
+	if increase := errorCount - status.BaselineErrors; increase > int64(c.Spec.ErrorThreshold) {
+		return c.abort(ctx, patchBase, status,
+			fmt.Sprintf("the canary replicas reported %d new output errors and retries, the threshold is %d", increase, c.Spec.ErrorThreshold))
+	}
+
+	analysis := time.Duration(c.analysisSeconds()) * time.Second
+	if elapsed := time.Since(status.AnalysisStartTime.Time); elapsed < analysis {
+		decision.RequeueAfter = min(requeueInterval, analysis-elapsed)
+		return decision, nil, nil
+	}
+
+	msg := fmt.Sprintf("configuration %s has been promoted to every replica", status.CanaryHash)
+	status.StableHash = status.CanaryHash
+	status.Phase = PhasePromoted
+	status.AnalysisStartTime = nil
+	status.Message = msg
+	if c.Recorder != nil {
+		c.Recorder.Event(c.Logging, corev1.EventTypeNormal, EventReasonPromoted, msg)
+	}
+	if err := c.patchStatus(ctx, patchBase); err != nil {
+		return nil, nil, err
+	}
+	return c.stable(status.StableHash), nil, nil
+}
+
+func (c *Canary) abort(ctx context.Context, patchBase client.Patch, status *v1beta1.ConfigRolloutStatus, reason string) (*Decision, *reconcile.Result, error) {
+	c.Log.Info("aborting the canary rollout", "hash", status.CanaryHash, "reason", reason)
+	status.Phase = PhaseAborted
+	status.AnalysisStartTime = nil
+	status.Message = reason
+	if c.Recorder != nil {
+		c.Recorder.Event(c.Logging, corev1.EventTypeWarning, EventReasonAborted,
+			fmt.Sprintf("rollout of configuration %s has been aborted: %s", status.CanaryHash, reason))
+	}
+	if err := c.patchStatus(ctx, patchBase); err != nil {
+		return nil, nil, err
+	}
+	return c.stable(status.StableHash), nil, nil
+}
+
+func (c *Canary) stable(hash string) *Decision {
+	return &Decision{
+		TemplateHash: hash,
+		StableHash:   hash,
+	}
+}
+
+func (c *Canary) patchStatus(ctx context.Context, patchBase client.Patch) error {
+	if err := c.Client.Status().Patch(ctx, c.Logging, patchBase); err != nil {
+		return errors.WrapWithDetails(err, "failed to patch status", "logging", c.Logging.Name)
+	}
+	return nil
+}
+
+func (c *Canary) analysisSeconds() int {
+	if c.Spec.AnalysisSeconds > 0 {
+		return c.Spec.AnalysisSeconds
+	}
+	return defaultAnalysisSeconds
+}
+
+// errorCount sums the error metrics of the pods, it returns zero if metrics are not enabled
+func (c *Canary) errorCount(ctx context.Context, pods []corev1.Pod) (int64, error) {
+	if c.MetricsPort == 0 {
+		return 0, nil
+	}
+	metrics := c.ErrorMetrics
+	if len(c.Spec.ErrorMetrics) > 0 {
+		metrics = c.Spec.ErrorMetrics
+	}
+	scraper := podmetrics.Scraper{HTTPClient: c.HTTPClient, Port: c.MetricsPort, Path: c.MetricsPath}
+
+	var total float64
+	for _, pod := range pods {
+		families, err := scraper.Scrape(ctx, pod)
+		if err != nil {
+			return 0, err
+		}
+		total += families.Sum(metrics...)
+	}
+	return int64(total), nil
+}
+
+// CanaryReplicas returns the number of replicas that get the canary config, at least one and at most every replica
+func CanaryReplicas(canary *intstr.IntOrString, replicas int32) int32 {
+	count := 1
+	if canary != nil {
+		if v, err := intstr.GetScaledValueFromIntOrPercent(canary, int(replicas), true); err == nil {
+			count = v
+		}
+	}
+	return max(1, min(int32(count), replicas))
+}
+
+// canaryPods returns the pods of the StatefulSet with an ordinal at or above the partition
+func canaryPods(pods []corev1.Pod, statefulSet string, partition int32) (res []corev1.Pod) {
+	for _, pod := range pods {
+		ordinal, err := strconv.Atoi(strings.TrimPrefix(pod.Name, statefulSet+"-"))
+		if err != nil {
+			continue
+		}
+		if int32(ordinal) >= partition {
+			res = append(res, pod)
+		}
+	}
+	return
+}
+
+// updated returns true if the expected number of pods run the update revision of the StatefulSet
+func updated(pods []corev1.Pod, revision string, expected int32) bool {
+	if revision == "" || int32(len(pods)) < expected {
+		return false
+	}
+	for _, pod := range pods {
+		if pod.Labels[appsv1.StatefulSetRevisionLabel] != revision {
+			return false
+		}
+	}
+	return true
+}
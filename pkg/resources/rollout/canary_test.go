@@ -0,0 +1,67 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+func TestCanaryReplicas(t *testing.T) {
+	percent := intstr.FromString("25%")
+	tooMany := intstr.FromInt32(5)
+
+	assert.Equal(t, int32(1), CanaryReplicas(nil, 4))
+	assert.Equal(t, int32(2), CanaryReplicas(&percent, 5))
+	assert.Equal(t, int32(3), CanaryReplicas(&tooMany, 3))
+}
+
+func TestReconcileStartsRollout(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	logging := &v1beta1.Logging{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(logging).WithStatusSubresource(logging).Build()
+
+	canary := Canary{Client: c, Log: logr.Discard(), Logging: logging}
+
+	decision, result, err := canary.Reconcile(context.Background(), client.MergeFrom(logging.DeepCopy()), "first")
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, &Decision{TemplateHash: "first", StableHash: "first"}, decision)
+
+	decision, result, err = canary.Reconcile(context.Background(), client.MergeFrom(logging.DeepCopy()), "second")
+	require.NoError(t, err)
+	assert.Nil(t, decision)
+	assert.True(t, result.Requeue)
+	assert.Equal(t, PhaseProgressing, logging.Status.ConfigRollout.Phase)
+	assert.True(t, Pending(logging, "second"))
+
+	decision, result, err = canary.Reconcile(context.Background(), client.MergeFrom(logging.DeepCopy()), "first")
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "first", decision.TemplateHash)
+	assert.Equal(t, PhaseAborted, logging.Status.ConfigRollout.Phase)
+}
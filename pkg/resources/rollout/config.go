@@ -0,0 +1,125 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/reconciler"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/configcheck"
+)
+
+// Aggregator provides the details of an aggregator that differ between fluentd and syslog-ng
+type Aggregator interface {
+	// Canary returns the canary rollout of the aggregator or nil if the canary strategy is not used
+	Canary() *Canary
+	// ConfigHash returns the hash of the desired config
+	ConfigHash() (string, error)
+	// ConfigRevision returns the secret of the desired config under the given name
+	ConfigRevision(name string) (runtime.Object, reconciler.DesiredState, error)
+	ReconcileResource(object runtime.Object, state reconciler.DesiredState) (*reconcile.Result, error)
+}
+
+// Config decides which config the replicas of an aggregator run when the canary rollout strategy is used.
+// Every config gets its own secret, so that the canary replicas can refer to a different one than the stable replicas.
+type Config struct {
+	Aggregator Aggregator
+	// SecretName is the name of the config secret, the revisions are named after it
+	SecretName string
+	// RevisionComponent is the component label of the revisions
+	RevisionComponent string
+}
+
+// Reconcile returns the decision of the canary rollout or nil if the canary strategy is not used.
+// The result is only set without a decision, when the reconciliation should be restarted.
+func (c *Config) Reconcile(ctx context.Context, patchBase client.Patch) (*Decision, *reconcile.Result, error) {
+	canary := c.Aggregator.Canary()
+	if canary == nil {
+		return nil, nil, nil
+	}
+
+	hash, err := c.Aggregator.ConfigHash()
+	if err != nil {
+		return nil, nil, err
+	}
+	revision, state, err := c.Aggregator.ConfigRevision(RevisionName(c.SecretName, hash))
+	if err != nil {
+		return nil, nil, err
+	}
+	if secret, ok := revision.(*corev1.Secret); ok {
+		configcheck.WithHashLabel(secret, hash)
+	}
+	if result, err := c.Aggregator.ReconcileResource(revision, state); result != nil || err != nil {
+		return nil, result, errors.WrapIf(err, "failed to reconcile config revision")
+	}
+
+	decision, result, err := canary.Reconcile(ctx, patchBase, hash)
+	if decision == nil || err != nil {
+		return nil, result, err
+	}
+
+	if err := c.revisionCleanup(ctx, canary, decision.StableHash, decision.TemplateHash); err != nil {
+		// Errors with the cleanup should not block the reconciliation, we just note it
+		canary.Log.Error(err, "issues during config revision cleanup, moving on")
+	}
+	return decision, nil, nil
+}
+
+// revisionCleanup removes the config revisions that are not used by any replica
+func (c *Config) revisionCleanup(ctx context.Context, canary *Canary, hashes ...string) (multierr error) {
+	var revisions corev1.SecretList
+	if err := canary.Client.List(ctx, &revisions, client.InNamespace(canary.Logging.Spec.ControlNamespace), client.MatchingLabels{
+		"app.kubernetes.io/component":  c.RevisionComponent,
+		"app.kubernetes.io/managed-by": canary.Logging.GetName(),
+	}); err != nil {
+		return errors.WrapIf(err, "failed to list config revisions")
+	}
+	for _, secret := range revisions.Items {
+		inUse := false
+		for _, hash := range hashes {
+			if secret.Labels[configcheck.HashLabel] == hash {
+				inUse = true
+			}
+		}
+		if inUse {
+			continue
+		}
+		if err := client.IgnoreNotFound(canary.Client.Delete(ctx, &secret)); err != nil {
+			multierr = errors.Combine(multierr,
+				errors.Wrapf(err, "failed to remove config revision %s", secret.Name))
+		}
+	}
+	return
+}
+
+// SecretName returns the name of the secret the pod template refers to for the config
+func SecretName(secretName string, decision *Decision) string {
+	if decision != nil {
+		return RevisionName(secretName, decision.TemplateHash)
+	}
+	return secretName
+}
+
+// RevisionName returns the name of the secret of the config with the given hash
+func RevisionName(secretName string, hash string) string {
+	return fmt.Sprintf("%s-%s", secretName, hash)
+}
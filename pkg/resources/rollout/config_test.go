@@ -0,0 +1,99 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/reconciler"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/configcheck"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+type testAggregator struct {
+	client client.Client
+	canary *Canary
+	hash   string
+}
+
+func (a testAggregator) Canary() *Canary {
+	return a.canary
+}
+
+func (a testAggregator) ConfigHash() (string, error) {
+	return a.hash, nil
+}
+
+func (a testAggregator) ConfigRevision(name string) (runtime.Object, reconciler.DesiredState, error) {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"app.kubernetes.io/component":  "config-rollout",
+				"app.kubernetes.io/managed-by": "test",
+			},
+		},
+	}, reconciler.StatePresent, nil
+}
+
+func (a testAggregator) ReconcileResource(object runtime.Object, _ reconciler.DesiredState) (*reconcile.Result, error) {
+	return nil, client.IgnoreAlreadyExists(a.client.Create(context.Background(), object.(client.Object)))
+}
+
+func TestConfigReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	logging := &v1beta1.Logging{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(logging).WithStatusSubresource(logging).Build()
+
+	config := Config{
+		Aggregator:        testAggregator{client: c, hash: "first"},
+		SecretName:        "config",
+		RevisionComponent: "config-rollout",
+	}
+	decision, result, err := config.Reconcile(context.Background(), client.MergeFrom(logging.DeepCopy()))
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Nil(t, decision, "no decision without the canary strategy")
+	assert.Equal(t, "config", SecretName(config.SecretName, decision))
+
+	stale, _, _ := config.Aggregator.ConfigRevision("config-stale")
+	require.NoError(t, c.Create(context.Background(), stale.(client.Object)))
+
+	config.Aggregator = testAggregator{client: c, hash: "first", canary: &Canary{Client: c, Log: logr.Discard(), Logging: logging}}
+	decision, result, err = config.Reconcile(context.Background(), client.MergeFrom(logging.DeepCopy()))
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "config-first", SecretName(config.SecretName, decision))
+
+	var revisions corev1.SecretList
+	require.NoError(t, c.List(context.Background(), &revisions))
+	require.Len(t, revisions.Items, 1, "the unused revision is removed")
+	assert.Equal(t, "config-first", revisions.Items[0].Name)
+	assert.Equal(t, "first", revisions.Items[0].Labels[configcheck.HashLabel])
+}
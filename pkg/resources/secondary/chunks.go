@@ -0,0 +1,73 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secondary collects the number of chunks written to the secondaries of the outputs from the metrics of the fluentd aggregators
+package secondary
+
+import (
+	"context"
+	"net/http"
+
+	"emperror.dev/errors"
+	dto "github.com/prometheus/client_model/go"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/model"
+	"github.com/kube-logging/logging-operator/pkg/resources/podmetrics"
+)
+
+const pluginIDLabel = "plugin_id"
+
+// Source is a fluentd aggregator whose pods expose the output metrics
+type Source struct {
+	Namespace   string
+	PodLabels   client.MatchingLabels
+	MetricsPort int32
+	MetricsPath string
+	// Metric counts the chunks written to the secondary by the plugin id of the primary output
+	Metric string
+}
+
+// Chunks is the number of chunks written to the secondary by output
+type Chunks map[model.OutputKey]float64
+
+// Collect sums the secondary chunks of the running pods of the aggregator by output.
+// An output referenced by multiple flows is rendered multiple times, the chunks of every occurrence are added up.
+func Collect(ctx context.Context, c client.Reader, httpClient *http.Client, source Source) (Chunks, error) {
+	chunks := make(Chunks)
+	pods, err := podmetrics.RunningPods(ctx, c, source.Namespace, source.PodLabels)
+	if err != nil {
+		return chunks, err
+	}
+	scraper := podmetrics.Scraper{HTTPClient: httpClient, Port: source.MetricsPort, Path: source.MetricsPath}
+	var errs error
+	for _, pod := range pods {
+		families, err := scraper.Scrape(ctx, pod)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+		chunks.add(families, source.Metric)
+	}
+	return chunks, errs
+}
+
+// add adds the samples of the metric to the chunks of the outputs
+func (chunks Chunks) add(families podmetrics.Families, metric string) {
+	families.Each(metric, func(m *dto.Metric, value float64) {
+		if key, found := model.OutputKeyFor(podmetrics.Label(m, pluginIDLabel)); found {
+			chunks[key] += value
+		}
+	})
+}
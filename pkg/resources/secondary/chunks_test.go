@@ -0,0 +1,48 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secondary
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/podmetrics"
+)
+
+func TestChunks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `# TYPE fluentd_output_status_write_secondary_count gauge`)
+		fmt.Fprintln(w, `fluentd_output_status_write_secondary_count{plugin_id="flow:ns:a:output:ns:loki",type="null"} 3`)
+		fmt.Fprintln(w, `fluentd_output_status_write_secondary_count{plugin_id="flow:ns:b:output:ns:loki",type="null"} 2`)
+		fmt.Fprintln(w, `fluentd_output_status_write_secondary_count{plugin_id="clusterflow:control:all:clusteroutput:control:es",type="null"} 1`)
+		fmt.Fprintln(w, `fluentd_output_status_write_secondary_count{plugin_id="main-fluentd-error",type="null"} 7`)
+	}))
+	defer server.Close()
+
+	families, err := podmetrics.Scraper{HTTPClient: server.Client()}.Get(context.Background(), server.URL)
+	require.NoError(t, err)
+	chunks := Chunks{}
+	chunks.add(families, "fluentd_output_status_write_secondary_count")
+	assert.Equal(t, Chunks{
+		{Namespace: "ns", Name: "loki"}:                         5,
+		{ClusterOutput: true, Namespace: "control", Name: "es"}: 1,
+	}, chunks)
+}
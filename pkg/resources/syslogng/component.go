@@ -15,7 +15,10 @@
 package syslogng
 
 const (
-	ComponentSyslogNG    = "syslog-ng"
-	ComponentConfigCheck = "syslog-ng-configcheck"
-	ComponentPlaceholder = "syslog-ng-placeholder"
+	ComponentSyslogNG      = "syslog-ng"
+	ComponentConfigCheck   = "syslog-ng-configcheck"
+	ComponentConfigDiff    = "syslog-ng-config-diff"
+	ComponentConfigHistory = "syslog-ng-config-history"
+	ComponentConfigRollout = "syslog-ng-config-rollout"
+	ComponentPlaceholder   = "syslog-ng-placeholder"
 )
@@ -39,6 +39,8 @@ type ConfigCheckResult struct {
 	Valid   bool
 	Ready   bool
 	Message string
+	// Output of the failed config check pod
+	Output string
 }
 
 func (r *Reconciler) configHash() (string, error) {
@@ -141,8 +143,9 @@ func (r *Reconciler) configCheck(ctx context.Context) (*ConfigCheckResult, error
 			return &ConfigCheckResult{}, nil
 		case corev1.PodFailed:
 			return &ConfigCheckResult{
-				Ready: true,
-				Valid: false,
+				Ready:  true,
+				Valid:  false,
+				Output: configcheck.FailureOutput(pod),
 			}, nil
 		case corev1.PodUnknown:
 			fallthrough
@@ -250,6 +253,8 @@ func (r *Reconciler) newCheckPod(hashKey string) (*corev1.Pod, error) {
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					Command:         containerCommand,
 					Args:            containerArgs,
+					// Keep the tail of the log as termination message to report why the check failed
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 					Resources: corev1.ResourceRequirements{
 						Limits: corev1.ResourceList{
 							corev1.ResourceMemory: resource.MustParse("400M"),
@@ -0,0 +1,56 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogng
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/configcheck"
+	"github.com/kube-logging/logging-operator/pkg/resources/rollout"
+)
+
+// reconcileConfigHistory keeps the configs that ran on stable syslog-ng pods as known good revisions
+// and rolls back to the last good one if the pods crash-loop, see configcheck.Aggregator.
+func (r *Reconciler) reconcileConfigHistory(ctx context.Context, patchBase client.Patch) (*reconcile.Result, error) {
+	hash, err := r.configHash()
+	if err != nil {
+		return nil, err
+	}
+	meta := r.SyslogNGObjectMeta(configSecretName, ComponentSyslogNG)
+	aggregator := configcheck.Aggregator{
+		Kind:             "syslog-ng",
+		Client:           r.Client,
+		Recorder:         r.eventRecorder,
+		Log:              r.Log,
+		Logging:          r.Logging,
+		HistoryComponent: ComponentConfigHistory,
+		PodLabels:        r.Logging.GetSyslogNGLabels(ComponentSyslogNG),
+		ConfigSecret:     client.ObjectKey{Namespace: meta.Namespace, Name: meta.Name},
+		ConfigKey:        configKey,
+		RevisionMeta: func(name string) metav1.ObjectMeta {
+			return r.SyslogNGObjectMeta(name, ComponentConfigHistory)
+		},
+		RolloutPending: func(hash string) bool {
+			return rollout.Pending(r.Logging, hash)
+		},
+	}
+	var result *reconcile.Result
+	r.configHistoryRequeue, result, err = aggregator.ReconcileHistory(ctx, patchBase, hash, &r.config)
+	return result, err
+}
@@ -19,6 +19,8 @@ import (
 	"github.com/cisco-open/operator-tools/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/configcheck"
 )
 
 func (r *Reconciler) configSecret() (runtime.Object, reconciler.DesiredState, error) {
@@ -33,5 +35,12 @@ func (r *Reconciler) configSecret() (runtime.Object, reconciler.DesiredState, er
 		map[string]string{"logging.banzaicloud.io/watch": "enabled"},
 	)
 
+	hash, err := r.configHash()
+	if err != nil {
+		return nil, nil, err
+	}
+	// the hash of the applied config is used to tell whether the pods run with it
+	configcheck.WithHashLabel(secret, hash)
+
 	return secret, reconciler.StatePresent, nil
 }
@@ -22,6 +22,8 @@ import (
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	syslogngconfig "github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config"
 )
 
 func (r *Reconciler) service() (runtime.Object, reconciler.DesiredState, error) {
@@ -38,14 +40,23 @@ func (r *Reconciler) service() (runtime.Object, reconciler.DesiredState, error)
 				{
 					Name:       "udp-syslog-ng",
 					Protocol:   corev1.ProtocolUDP,
-					Port:       514,
-					TargetPort: intstr.IntOrString{IntVal: 514},
+					Port:       serviceUDPPort,
+					TargetPort: intstr.IntOrString{IntVal: serviceUDPPort},
 				},
 			},
 			Selector: r.Logging.GetSyslogNGLabels(ComponentSyslogNG),
 			Type:     corev1.ServiceTypeClusterIP,
 		},
 	}
+	// the invalid sources are not rendered into the config, and their ports may clash with the other ports of the service
+	for _, source := range syslogngconfig.ValidSources(r.syslogNGSpec, ServicePort, ReservedPorts(r.syslogNGSpec)) {
+		desired.Spec.Ports = append(desired.Spec.Ports, corev1.ServicePort{
+			Name:       source.ServicePortName(),
+			Protocol:   source.ServiceProtocol(),
+			Port:       source.Port,
+			TargetPort: intstr.IntOrString{IntVal: source.Port},
+		})
+	}
 
 	beforeUpdateHook := reconciler.DesiredStateHook(func(current runtime.Object) error {
 		if s, ok := current.(*corev1.Service); ok {
@@ -202,8 +213,8 @@ func (r *Reconciler) headlessService() (runtime.Object, reconciler.DesiredState,
 				{
 					Name:       "udp-syslog-ng",
 					Protocol:   corev1.ProtocolUDP,
-					Port:       514,
-					TargetPort: intstr.IntOrString{IntVal: 514},
+					Port:       serviceUDPPort,
+					TargetPort: intstr.IntOrString{IntVal: serviceUDPPort},
 				},
 			},
 			Selector:  r.Logging.GetSyslogNGLabels(ComponentSyslogNG),
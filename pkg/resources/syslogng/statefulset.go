@@ -31,6 +31,7 @@ import (
 
 	"github.com/kube-logging/logging-operator/pkg/resources/kubetool"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	syslogngconfig "github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config"
 )
 
 func (r *Reconciler) statefulset() (runtime.Object, reconciler.DesiredState, error) {
@@ -75,6 +76,15 @@ func (r *Reconciler) statefulset() (runtime.Object, reconciler.DesiredState, err
 		return desired, reconciler.StatePresent, errors.WrapIf(err, "unable to merge overrides to base object")
 	}
 
+	if r.configRollout != nil {
+		desired.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+				Partition: util.IntPointer(r.configRollout.Partition),
+			},
+		}
+	}
+
 	// HACK: try to _guess_ if user has configured a persistent volume for buffers and move syslog-ng's persist file there
 	buffersVolumeName := "buffers"
 	if r.syslogNGSpec.BufferVolumeMetrics != nil {
@@ -103,11 +113,7 @@ func syslogNGContainer(spec *v1beta1.SyslogNGSpec) corev1.Container {
 		Name:            ContainerName,
 		Image:           spec.SyslogNGImage.RepositoryWithTag(),
 		ImagePullPolicy: corev1.PullIfNotPresent,
-		Ports: []corev1.ContainerPort{{
-			Name:          "syslog-ng-tcp",
-			ContainerPort: ServicePort,
-			Protocol:      corev1.ProtocolTCP,
-		}},
+		Ports:           generatePorts(spec),
 		Args: []string{
 			"--cfgfile=" + configDir + "/" + configKey,
 			"--control=" + socketPath,
@@ -142,6 +148,22 @@ func syslogNGContainer(spec *v1beta1.SyslogNGSpec) corev1.Container {
 	}
 }
 
+func generatePorts(spec *v1beta1.SyslogNGSpec) []corev1.ContainerPort {
+	ports := []corev1.ContainerPort{{
+		Name:          "syslog-ng-tcp",
+		ContainerPort: ServicePort,
+		Protocol:      corev1.ProtocolTCP,
+	}}
+	// the port names of the sources are not set, as container port names are limited to 15 characters
+	for _, source := range syslogngconfig.ValidSources(spec, ServicePort, ReservedPorts(spec)) {
+		ports = append(ports, corev1.ContainerPort{
+			ContainerPort: source.Port,
+			Protocol:      source.ServiceProtocol(),
+		})
+	}
+	return ports
+}
+
 func generatePortsBufferVolumeMetrics(spec *v1beta1.SyslogNGSpec) []corev1.ContainerPort {
 	port := int32(defaultBufferVolumeMetricsPort)
 	if spec.BufferVolumeMetrics.Port != 0 {
@@ -189,7 +211,7 @@ func (r *Reconciler) generateVolume() (v []corev1.Volume) {
 			Name: configVolumeName,
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: r.Logging.QualifiedName(configSecretName),
+					SecretName: r.configSecretQualifiedName(),
 				},
 			},
 		},
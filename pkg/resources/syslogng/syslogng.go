@@ -16,6 +16,7 @@ package syslogng
 
 import (
 	"context"
+	"time"
 
 	"emperror.dev/errors"
 	"github.com/cisco-open/operator-tools/pkg/reconciler"
@@ -26,18 +27,21 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/kube-logging/logging-operator/pkg/resources"
 	"github.com/kube-logging/logging-operator/pkg/resources/configcheck"
+	"github.com/kube-logging/logging-operator/pkg/resources/rollout"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 )
 
 const (
 	ServiceName                    = "syslog-ng"
 	ServicePort                    = 601
+	serviceUDPPort                 = 514
 	configSecretName               = "syslog-ng"
 	configKey                      = "syslog-ng.conf"
 	StatefulSetName                = "syslog-ng"
@@ -66,8 +70,13 @@ type Reconciler struct {
 	syslogNGSpec   *v1beta1.SyslogNGSpec
 	syslogNGConfig *v1beta1.SyslogNGConfig
 	*reconciler.GenericResourceReconciler
-	config  string
-	secrets *secret.MountSecrets
+	config        string
+	secrets       *secret.MountSecrets
+	eventRecorder record.EventRecorder
+	// configRollout is set when the canary rollout strategy is used
+	configRollout *rollout.Decision
+	// configHistoryRequeue is set while the pods are not stable with a config that is not known to be good yet
+	configHistoryRequeue time.Duration
 }
 
 type Desire struct {
@@ -81,6 +90,7 @@ type Desire struct {
 func New(
 	client client.Client,
 	log logr.Logger,
+	eventRecorder record.EventRecorder,
 	logging *v1beta1.Logging,
 	syslogNGSPec *v1beta1.SyslogNGSpec,
 	syslogNGCOnfig *v1beta1.SyslogNGConfig,
@@ -95,6 +105,7 @@ func New(
 		GenericResourceReconciler: reconciler.NewGenericReconciler(client, log, opts),
 		config:                    config,
 		secrets:                   secrets,
+		eventRecorder:             eventRecorder,
 	}
 }
 
@@ -137,7 +148,7 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*reconcile.Result, error) {
 				return nil, errors.WrapIf(err, "current config is invalid")
 			}
 			// clean the status so that we can rerun the check
-			return r.statusUpdate(ctx, patchBase, nil)
+			return r.statusUpdate(ctx, patchBase, hash, nil)
 		}
 
 		// Cleanup previous configcheck results
@@ -152,7 +163,7 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*reconcile.Result, error) {
 				// Errors with the cleanup should not block the reconciliation, we just note it
 				r.Log.Error(err, "issues during configcheck cleanup, moving on")
 			} else if len(r.Logging.Status.ConfigCheckResults) > 1 {
-				return r.statusUpdate(ctx, patchBase, map[string]bool{
+				return r.statusUpdate(ctx, patchBase, hash, map[string]bool{
 					hash: result,
 				})
 			}
@@ -166,6 +177,10 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*reconcile.Result, error) {
 			}
 			if result.Ready {
 				r.Logging.Status.ConfigCheckResults[hash] = result.Valid
+				if !result.Valid {
+					configcheck.RecordFailure(r.eventRecorder, r.Logging, hash, result.Output)
+				}
+				configcheck.SetCondition(r.Logging, hash)
 				if err := r.Client.Status().Patch(ctx, r.Logging, patchBase); err != nil {
 					return nil, errors.WrapWithDetails(err, "failed to patch status", "logging", r.Logging)
 				} else {
@@ -182,6 +197,17 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*reconcile.Result, error) {
 			}
 		}
 	}
+	// Roll back to the last known good config if the current one crash-loops the pods
+	if res, err := r.reconcileConfigHistory(ctx, patchBase); res != nil || err != nil {
+		return res, err
+	}
+	if res, err := r.reconcileConfigRollout(ctx, patchBase); res != nil || err != nil {
+		return res, err
+	}
+	if err := r.reconcileConfigDiff(ctx); err != nil {
+		// Errors with the config diff should not block the reconciliation, we just note it
+		r.Log.Error(err, "issues during config diff, moving on")
+	}
 	// Prepare output secret
 	outputSecret, outputSecretDesiredState, err := r.outputSecret(r.secrets)
 	if err != nil {
@@ -239,6 +265,15 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*reconcile.Result, error) {
 		}
 	}
 
+	if r.configRollout != nil && r.configRollout.RequeueAfter > 0 {
+		// check the canary replicas again
+		return &reconcile.Result{RequeueAfter: r.configRollout.RequeueAfter}, nil
+	}
+	if r.configHistoryRequeue > 0 {
+		// confirm the config once the pods are stable
+		return &reconcile.Result{RequeueAfter: r.configHistoryRequeue}, nil
+	}
+
 	return nil, nil
 }
 
@@ -257,8 +292,10 @@ func (r *Reconciler) hasConfigCheckPod(ctx context.Context, hashKey string) (boo
 	return true, nil
 }
 
-func (r *Reconciler) statusUpdate(ctx context.Context, patchBase client.Patch, result map[string]bool) (*reconcile.Result, error) {
+func (r *Reconciler) statusUpdate(ctx context.Context, patchBase client.Patch, hash string, result map[string]bool) (*reconcile.Result, error) {
 	r.Logging.Status.ConfigCheckResults = result
+	configcheck.PruneFailures(r.Logging)
+	configcheck.SetCondition(r.Logging, hash)
 	if err := r.Client.Status().Patch(ctx, r.Logging, patchBase); err != nil {
 		return nil, errors.WrapWithDetails(err, "failed to patch status", "logging", r.Logging)
 	} else {
@@ -271,6 +308,25 @@ func (r *Reconciler) getServiceAccountName() string {
 	return r.Logging.QualifiedName(serviceAccountName)
 }
 
+// ReservedPorts returns the ports of the syslog-ng pods and service that the additional sources must not use, by their users
+func ReservedPorts(spec *v1beta1.SyslogNGSpec) map[int32]string {
+	ports := map[int32]string{serviceUDPPort: "the udp port of the service"}
+	if spec == nil {
+		return ports
+	}
+	if spec.Metrics != nil {
+		ports[metricsPortNumber] = "the metrics exporter"
+	}
+	if spec.BufferVolumeMetrics != nil {
+		port := int32(defaultBufferVolumeMetricsPort)
+		if spec.BufferVolumeMetrics.Port != 0 {
+			port = spec.BufferVolumeMetrics.Port
+		}
+		ports[port] = "the buffer volume metrics exporter"
+	}
+	return ports
+}
+
 func RegisterWatches(builder *builder.Builder) *builder.Builder {
 	return builder.
 		Owns(&corev1.ConfigMap{}).
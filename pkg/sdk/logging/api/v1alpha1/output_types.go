@@ -63,6 +63,16 @@ type OutputStatus struct {
 	Active        *bool    `json:"active,omitempty"`
 	Problems      []string `json:"problems,omitempty"`
 	ProblemsCount int      `json:"problemsCount,omitempty"`
+	// Number of chunks written to the secondary output by the running fluentd pods
+	SecondaryChunks int64 `json:"secondaryChunks,omitempty"`
+	// Size limit of each buffer of the output computed from the size of the buffer volume, set if the buffer limits are enabled
+	BufferLimit string `json:"bufferLimit,omitempty"`
+	// Generation of the resource that was last processed by the operator.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Standard conditions of the resource.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
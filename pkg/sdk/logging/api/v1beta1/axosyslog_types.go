@@ -14,7 +14,14 @@
 
 package v1beta1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	"fmt"
+
+	"github.com/cisco-open/operator-tools/pkg/typeoverride"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/kubetool"
+)
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
@@ -38,6 +45,15 @@ type AxoSyslogSpec struct {
 
 	// Destinations is a list of destinations to be rendered in the AxoSyslog configuration
 	Destinations []Destination `json:"destinations,omitempty"`
+
+	// Image of the AxoSyslog container
+	Image *BasicImageSpec `json:"image,omitempty"`
+	// ConfigReloadImage is the image of the sidecar that reloads AxoSyslog when the configuration changes
+	ConfigReloadImage *BasicImageSpec `json:"configReloadImage,omitempty"`
+	// StatefulSetOverrides are merged into the generated StatefulSet
+	StatefulSetOverrides *typeoverride.StatefulSet `json:"statefulSet,omitempty"`
+	// ServiceAccountOverrides are merged into the generated ServiceAccount
+	ServiceAccountOverrides *typeoverride.ServiceAccount `json:"serviceAccount,omitempty"`
 }
 
 // LogPath defines a single log path that will be rendered in the AxoSyslog configuration
@@ -98,6 +114,34 @@ func (a *AxoSyslog) SetDefaults() error {
 	if a.Spec.Destinations == nil {
 		a.Spec.Destinations = []Destination{}
 	}
+	if a.Spec.Image == nil {
+		a.Spec.Image = &BasicImageSpec{}
+	}
+	if a.Spec.Image.Repository == "" {
+		a.Spec.Image.Repository = defaultSyslogngImageRepository
+	}
+	if a.Spec.Image.Tag == "" {
+		a.Spec.Image.Tag = defaultSyslogngImageTag
+	}
+	if a.Spec.ConfigReloadImage == nil {
+		a.Spec.ConfigReloadImage = &BasicImageSpec{}
+	}
+	if a.Spec.ConfigReloadImage.Repository == "" {
+		a.Spec.ConfigReloadImage.Repository = defaultConfigReloaderImageRepository
+	}
+	if a.Spec.ConfigReloadImage.Tag == "" {
+		if Version == "" {
+			a.Spec.ConfigReloadImage.Tag = defaultConfigReloaderImageTag
+		} else {
+			a.Spec.ConfigReloadImage.Tag = Version
+		}
+	}
 
 	return nil
 }
+
+// QualifiedName is the "axosyslog-resource" name combined
+func (a *AxoSyslog) QualifiedName(name string) string {
+	baseName := fmt.Sprintf("%s-%s", a.Name, name)
+	return kubetool.FixQualifiedNameIfInvalid(baseName)
+}
@@ -53,27 +53,41 @@ type ClusterSelect struct {
 	Namespaces      []string          `json:"namespaces,omitempty"`
 	NamespacesRegex []string          `json:"namespaces_regex,omitempty"`
 	Labels          map[string]string `json:"labels,omitempty"`
+	// Namespace labels that must match exactly. The records carry no namespace labels, so they can't be used
+	// if a selector of the flow sets annotations or label_expressions.
 	NamespaceLabels map[string]string `json:"namespace_labels,omitempty"`
 	Hosts           []string          `json:"hosts,omitempty"`
 	ContainerNames  []string          `json:"container_names,omitempty"`
+	// Pod annotations that must match exactly
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Set-based and regular expression requirements on pod labels
+	LabelExpressions []LabelExpression `json:"label_expressions,omitempty"`
 }
 
 type ClusterExclude struct {
 	Namespaces      []string          `json:"namespaces,omitempty"`
 	NamespacesRegex []string          `json:"namespaces_regex,omitempty"`
 	Labels          map[string]string `json:"labels,omitempty"`
+	// Namespace labels that must match exactly. The records carry no namespace labels, so they can't be used
+	// if a selector of the flow sets annotations or label_expressions.
 	NamespaceLabels map[string]string `json:"namespace_labels,omitempty"`
 	Hosts           []string          `json:"hosts,omitempty"`
 	ContainerNames  []string          `json:"container_names,omitempty"`
+	// Pod annotations that must match exactly
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Set-based and regular expression requirements on pod labels
+	LabelExpressions []LabelExpression `json:"label_expressions,omitempty"`
 }
 
 // ClusterFlowSpec is the Kubernetes spec for ClusterFlows
 type ClusterFlowSpec struct {
 	// Deprecated
-	Selectors  map[string]string `json:"selectors,omitempty"`
-	Match      []ClusterMatch    `json:"match,omitempty"`
-	Filters    []Filter          `json:"filters,omitempty"`
-	LoggingRef string            `json:"loggingRef,omitempty"`
+	Selectors map[string]string `json:"selectors,omitempty"`
+	Match     []ClusterMatch    `json:"match,omitempty"`
+	// Boolean expression of selectors, cannot be used together with match or selectors
+	MatchExpression *FlowMatchExpression `json:"matchExpression,omitempty"`
+	Filters         []Filter             `json:"filters,omitempty"`
+	LoggingRef      string               `json:"loggingRef,omitempty"`
 	// Deprecated
 	OutputRefs           []string `json:"outputRefs,omitempty"`
 	GlobalOutputRefs     []string `json:"globalOutputRefs,omitempty"`
@@ -17,6 +17,7 @@ package v1beta1
 import (
 	v1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -63,6 +64,17 @@ func RepositoryWithTag(repository, tag string) string {
 	return res
 }
 
+// BufferLimits enables sizing the buffers of the outputs automatically, so that they fit on the buffer volume together.
+// The space left by the explicitly limited buffers is shared by the other buffers in proportion to the `bufferWeight` of their outputs.
+type BufferLimits struct {
+	// Size of the buffer volume. Defaults to the storage request of the claim of the buffer volume, or to the size limit of an emptyDir buffer volume.
+	VolumeSize *resource.Quantity `json:"volumeSize,omitempty"`
+	// Percentage of the buffer volume the buffers may use together, the rest is kept free for the other files on the volume. (default: 90)
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	UsagePercent int32 `json:"usagePercent,omitempty"`
+}
+
 // Metrics defines the service monitor endpoints
 type Metrics struct {
 	Interval                string                    `json:"interval,omitempty"`
@@ -172,3 +184,41 @@ type ReadinessDefaultCheck struct {
 	SuccessThreshold         int32 `json:"successThreshold,omitempty"`
 	FailureThreshold         int32 `json:"failureThreshold,omitempty"`
 }
+
+type ConfigRolloutStrategy string
+
+const (
+	ConfigRolloutStrategyAllAtOnce ConfigRolloutStrategy = "AllAtOnce"
+	ConfigRolloutStrategyCanary    ConfigRolloutStrategy = "Canary"
+)
+
+// ConfigRollout defines how a new aggregator configuration reaches the replicas
+type ConfigRollout struct {
+	// Select the rollout strategy to use.
+	// `AllAtOnce`: Every replica reloads the new configuration at once.
+	// `Canary`: The new configuration is rolled out to a subset of the replicas first and promoted to every replica
+	// after their output errors and retries have been watched for the analysis window. Replicas are restarted to switch configurations.
+	// Default: `AllAtOnce`
+	// +kubebuilder:validation:Enum=AllAtOnce;Canary
+	Strategy ConfigRolloutStrategy `json:"strategy,omitempty"`
+	// Settings of the Canary strategy
+	Canary *CanaryRollout `json:"canary,omitempty"`
+}
+
+// IsCanary returns true if the canary strategy is selected
+func (c *ConfigRollout) IsCanary() bool {
+	return c != nil && c.Strategy == ConfigRolloutStrategyCanary
+}
+
+type CanaryRollout struct {
+	// Number or percentage of the replicas that get the new configuration first. (default: 1)
+	Replicas *intstr.IntOrString `json:"replicas,omitempty"`
+	// Seconds to watch the canary replicas before promoting the configuration. (default: 300)
+	AnalysisSeconds int `json:"analysisSeconds,omitempty"`
+	// Number of new output errors and retries tolerated from the canary replicas during the analysis. (default: 0)
+	ErrorThreshold int `json:"errorThreshold,omitempty"`
+	// Metrics summed to count the output errors and retries of the canary replicas.
+	// Defaults to the output error and retry count metrics of the aggregator.
+	// Metrics are only checked if metrics are enabled for the aggregator, otherwise only the health of the canary replicas is watched.
+	ErrorMetrics []string `json:"errorMetrics,omitempty"`
+}
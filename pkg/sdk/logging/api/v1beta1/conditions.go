@@ -0,0 +1,73 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+// +name:"Conditions"
+// +weight:"200"
+type _hugoConditions interface{} //nolint:deadcode,unused
+
+// +docName:"Conditions"
+/*
+The logging resources report their state in the standard `status.conditions` list, next to the `problems` of the resource. The `observedGeneration` of a condition is the generation of the resource it was computed for.
+
+| Type | Resources | Status | Reason | Meaning |
+|---|---|---|---|---|
+| `Ready` | all resources | `True` | `Ready` | The resource has no problems. |
+| | | `False` | `ProblemsFound` | The message lists the problems of the resource. |
+| `OutputsResolved` | flows | `True` | `OutputsResolved` | Every output referenced by the flow exists and is valid. |
+| | | `False` | `OutputsUnresolved` | The message lists the missing or invalid outputs. |
+| `AggregatorAvailable` | Logging | `True` | `AggregatorConfigured` | A single fluentd or syslog-ng aggregator is configured. |
+| | | `False` | `AggregatorMissing` | No aggregator is configured. |
+| | | `False` | `AggregatorAmbiguous` | Multiple aggregator configurations are found, or both fluentd and syslog-ng are enabled. |
+| `ConfigCheckPassed` | Logging | `True` | `ConfigCheckPassed` | The current aggregator configuration passed the config check. |
+| | | `True` | `ConfigCheckDisabled` | The config check is disabled with `flowConfigCheckDisabled`. |
+| | | `False` | `ConfigCheckFailed` | The current configuration failed the config check. The message contains the failing directive, if it could be found. |
+| | | `Unknown` | `ConfigCheckPending` | The config check of the current configuration has not finished yet. |
+
+The `ConfigCheckPassed` condition is only reported for Loggings that have an aggregator.
+*/
+type _docConditions interface{} //nolint:deadcode,unused
+
+// +name:"Conditions"
+// +version:"v1beta1"
+// +description:"Standard conditions of the logging resources"
+type _metaConditions interface{} //nolint:deadcode,unused
+
+// Condition types reported in the status of the logging resources.
+const (
+	// ConditionReady is true when the resource has no problems.
+	ConditionReady = "Ready"
+	// ConditionConfigCheckPassed is true when the current aggregator configuration passed the config check.
+	ConditionConfigCheckPassed = "ConfigCheckPassed"
+	// ConditionOutputsResolved is true when every output referenced by a flow exists and is valid.
+	ConditionOutputsResolved = "OutputsResolved"
+	// ConditionAggregatorAvailable is true when a single aggregator (fluentd or syslog-ng) is configured for the logging.
+	ConditionAggregatorAvailable = "AggregatorAvailable"
+)
+
+// Condition reasons reported in the status of the logging resources.
+const (
+	ReasonReady                = "Ready"
+	ReasonProblemsFound        = "ProblemsFound"
+	ReasonOutputsResolved      = "OutputsResolved"
+	ReasonOutputsUnresolved    = "OutputsUnresolved"
+	ReasonConfigCheckPassed    = "ConfigCheckPassed"
+	ReasonConfigCheckFailed    = "ConfigCheckFailed"
+	ReasonConfigCheckPending   = "ConfigCheckPending"
+	ReasonConfigCheckDisabled  = "ConfigCheckDisabled"
+	ReasonAggregatorConfigured = "AggregatorConfigured"
+	ReasonAggregatorMissing    = "AggregatorMissing"
+	ReasonAggregatorAmbiguous  = "AggregatorAmbiguous"
+)
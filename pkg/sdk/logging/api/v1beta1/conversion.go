@@ -18,6 +18,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 var Log = ctrl.Log.WithName("Defaulter:v1beta1")
@@ -41,6 +42,19 @@ func SetupWebhookWithManager(mgr ctrl.Manager, apiTypes ...runtime.Object) error
 	return nil
 }
 
+// +kubebuilder:webhook:path=/validate-logging-banzaicloud-io-v1beta1-flow,mutating=false,failurePolicy=ignore,sideEffects=None,groups=logging.banzaicloud.io,resources=flows,verbs=create;update,versions=v1beta1,name=vflow.logging.banzaicloud.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-logging-banzaicloud-io-v1beta1-clusterflow,mutating=false,failurePolicy=ignore,sideEffects=None,groups=logging.banzaicloud.io,resources=clusterflows,verbs=create;update,versions=v1beta1,name=vclusterflow.logging.banzaicloud.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-logging-banzaicloud-io-v1beta1-output,mutating=false,failurePolicy=ignore,sideEffects=None,groups=logging.banzaicloud.io,resources=outputs,verbs=create;update,versions=v1beta1,name=voutput.logging.banzaicloud.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-logging-banzaicloud-io-v1beta1-clusteroutput,mutating=false,failurePolicy=ignore,sideEffects=None,groups=logging.banzaicloud.io,resources=clusteroutputs,verbs=create;update,versions=v1beta1,name=vclusteroutput.logging.banzaicloud.io,admissionReviewVersions=v1
+
+// SetupValidatingWebhookWithManager registers a validating webhook for the api type next to its conversion webhook
+func SetupValidatingWebhookWithManager(mgr ctrl.Manager, apiType runtime.Object, validator admission.CustomValidator) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(apiType).
+		WithValidator(validator).
+		Complete()
+}
+
 func APITypes() []runtime.Object {
 	return []runtime.Object{&Logging{}, &Output{}, &ClusterOutput{}, &Flow{}, &ClusterFlow{}}
 }
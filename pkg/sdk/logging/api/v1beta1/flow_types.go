@@ -32,16 +32,43 @@ type _metaFlowSpec interface{} //nolint:deadcode,unused
 // FlowSpec is the Kubernetes spec for Flows
 type FlowSpec struct {
 	// Deprecated
-	Selectors  map[string]string `json:"selectors,omitempty"`
-	Match      []Match           `json:"match,omitempty"`
-	Filters    []Filter          `json:"filters,omitempty"`
-	LoggingRef string            `json:"loggingRef,omitempty"`
+	Selectors map[string]string `json:"selectors,omitempty"`
+	Match     []Match           `json:"match,omitempty"`
+	// Boolean expression of selectors, cannot be used together with match or selectors.
+	// The flow only receives the logs of its own namespace.
+	MatchExpression *FlowMatchExpression `json:"matchExpression,omitempty"`
+	Filters         []Filter             `json:"filters,omitempty"`
+	LoggingRef      string               `json:"loggingRef,omitempty"`
 	// Deprecated
 	OutputRefs           []string `json:"outputRefs,omitempty"`
 	GlobalOutputRefs     []string `json:"globalOutputRefs,omitempty"`
 	LocalOutputRefs      []string `json:"localOutputRefs,omitempty"`
 	FlowLabel            string   `json:"flowLabel,omitempty"`
 	IncludeLabelInRouter *bool    `json:"includeLabelInRouter,omitempty"`
+	// Routes send the records to different outputs depending on their content.
+	// A record is sent to the first matching route, the records matching no route are sent to the outputs of the flow.
+	Routes []FlowRoute `json:"routes,omitempty"`
+}
+
+// FlowRoute sends the matching records of a Flow to its own filters and outputs
+type FlowRoute struct {
+	// Name of the route, unique within the flow
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// A record is routed here if all the conditions match
+	// +kubebuilder:validation:MinItems=1
+	Conditions       []RouteCondition `json:"conditions"`
+	Filters          []Filter         `json:"filters,omitempty"`
+	GlobalOutputRefs []string         `json:"globalOutputRefs,omitempty"`
+	LocalOutputRefs  []string         `json:"localOutputRefs,omitempty"`
+}
+
+// RouteCondition matches the value of a key of the record
+type RouteCondition struct {
+	// Top-level key of the record or a record accessor, for example `level` or `$.kubernetes.labels.app`
+	Key string `json:"key"`
+	// Regular expression (Ruby syntax) of the value
+	Pattern string `json:"pattern"`
 }
 
 type Match struct {
@@ -53,13 +80,74 @@ type Select struct {
 	Labels         map[string]string `json:"labels,omitempty"`
 	Hosts          []string          `json:"hosts,omitempty"`
 	ContainerNames []string          `json:"container_names,omitempty"`
+	// Pod annotations that must match exactly
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Set-based and regular expression requirements on pod labels
+	LabelExpressions []LabelExpression `json:"label_expressions,omitempty"`
 }
 
 type Exclude struct {
-	Labels          map[string]string `json:"labels,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+	// Namespace labels that must match exactly. The records carry no namespace labels, so they can't be used
+	// if a selector of the flow sets annotations or label_expressions.
+	NamespaceLabels map[string]string `json:"namespace_labels,omitempty"`
+	Hosts           []string          `json:"hosts,omitempty"`
+	ContainerNames  []string          `json:"container_names,omitempty"`
+	// Pod annotations that must match exactly
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Set-based and regular expression requirements on pod labels
+	LabelExpressions []LabelExpression `json:"label_expressions,omitempty"`
+}
+
+// FlowMatchExpression is a boolean expression of selectors.
+// Set either one of and, or, not, or the selector fields. Every selector field that is set must match.
+type FlowMatchExpression struct {
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	And []FlowMatchExpression `json:"and,omitempty"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Or []FlowMatchExpression `json:"or,omitempty"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Not    *FlowMatchExpression `json:"not,omitempty"`
+	Labels map[string]string    `json:"labels,omitempty"`
+	// Namespace labels that must match exactly. The records carry no namespace labels, so they can only be used
+	// if the route evaluates the expression: at most 4 distinct selectors, without annotations or label_expressions.
 	NamespaceLabels map[string]string `json:"namespace_labels,omitempty"`
+	Namespaces      []string          `json:"namespaces,omitempty"`
+	NamespacesRegex []string          `json:"namespaces_regex,omitempty"`
 	Hosts           []string          `json:"hosts,omitempty"`
 	ContainerNames  []string          `json:"container_names,omitempty"`
+	// Pod annotations that must match exactly
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Set-based and regular expression requirements on pod labels
+	LabelExpressions []LabelExpression `json:"label_expressions,omitempty"`
+}
+
+// IsSelector returns true if the expression is a selector, i.e. none of and, or and not is set
+func (e *FlowMatchExpression) IsSelector() bool {
+	return len(e.And) == 0 && len(e.Or) == 0 && e.Not == nil
+}
+
+// +kubebuilder:validation:Enum=In;NotIn;Exists;DoesNotExist;Regex
+type LabelOperator string
+
+const (
+	LabelOpIn           LabelOperator = "In"
+	LabelOpNotIn        LabelOperator = "NotIn"
+	LabelOpExists       LabelOperator = "Exists"
+	LabelOpDoesNotExist LabelOperator = "DoesNotExist"
+	LabelOpRegex        LabelOperator = "Regex"
+)
+
+// LabelExpression is a requirement on the value of a label, similar to the match expressions of a Kubernetes label selector.
+// In and NotIn compare the value to the values, Regex matches it against any of the regular expressions in values.
+// Exists and DoesNotExist require values to be empty.
+type LabelExpression struct {
+	Key      string        `json:"key"`
+	Operator LabelOperator `json:"operator"`
+	Values   []string      `json:"values,omitempty"`
 }
 
 // Filter definition for FlowSpec
@@ -79,6 +167,8 @@ type Filter struct {
 	Prometheus          *filter.PrometheusConfig          `json:"prometheus,omitempty"`
 	Throttle            *filter.Throttle                  `json:"throttle,omitempty"`
 	KubeEventsTimestamp *filter.KubeEventsTimestampConfig `json:"kube_events_timestamp,omitempty"`
+	Redact              *filter.Redact                    `json:"redact,omitempty"`
+	Sample              *filter.Sample                    `json:"sample,omitempty"`
 }
 
 // FlowStatus defines the observed state of Flow
@@ -86,6 +176,12 @@ type FlowStatus struct {
 	Active        *bool    `json:"active,omitempty"`
 	Problems      []string `json:"problems,omitempty"`
 	ProblemsCount int      `json:"problemsCount,omitempty"`
+	// Generation of the resource that was last processed by the operator.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Standard conditions of the resource.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -39,6 +39,12 @@ type _metaFluentbitSpec interface{} //nolint:deadcode,unused
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=fluentbitagents,scope=Cluster,categories=logging-all
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="LoggingRef",type="string",JSONPath=".spec.loggingRef",description="Logging reference"
+// +kubebuilder:printcolumn:name="Desired",type="integer",JSONPath=".status.desiredNumberScheduled",description="Number of nodes that should be running the fluent-bit pod"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.numberReady",description="Number of nodes running a ready fluent-bit pod"
+// +kubebuilder:printcolumn:name="Updated",type="integer",JSONPath=".status.updatedNumberScheduled",description="Number of nodes running the updated fluent-bit pod"
+// +kubebuilder:printcolumn:name="ConfigHash",type="string",JSONPath=".status.configHash",description="Hash of the active configuration",priority=1
+// +kubebuilder:printcolumn:name="Problems",type="integer",JSONPath=".status.problemsCount",description="Number of problems"
 
 // FluentbitAgent is the Schema for the loggings API
 type FluentbitAgent struct {
@@ -148,6 +154,22 @@ type FluentbitSpec struct {
 
 // FluentbitStatus defines the resource status for FluentbitAgent
 type FluentbitStatus struct {
+	// Number of nodes that should be running the fluent-bit pod
+	DesiredNumberScheduled int32 `json:"desiredNumberScheduled,omitempty"`
+	// Number of nodes that are running a ready fluent-bit pod
+	NumberReady int32 `json:"numberReady,omitempty"`
+	// Number of nodes that are running the updated fluent-bit pod
+	UpdatedNumberScheduled int32 `json:"updatedNumberScheduled,omitempty"`
+	// Hash of the configuration Secret the DaemonSet is running with
+	ConfigHash string `json:"configHash,omitempty"`
+	// Aggregator endpoints (host:port) the logs are forwarded to
+	Targets []string `json:"targets,omitempty"`
+	// Tenants the logs are routed to based on the LoggingRoutes of the referenced Logging
+	Tenants []Tenant `json:"tenants,omitempty"`
+	// Problems with the fluentbit agent
+	Problems []string `json:"problems,omitempty"`
+	// Count of problems for printcolumn
+	ProblemsCount int `json:"problemsCount,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
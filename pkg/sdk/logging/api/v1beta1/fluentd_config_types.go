@@ -47,6 +47,12 @@ type FluentdConfigStatus struct {
 	Active        *bool    `json:"active,omitempty"`
 	Problems      []string `json:"problems,omitempty"`
 	ProblemsCount int      `json:"problemsCount,omitempty"`
+	// Generation of the resource that was last processed by the operator.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Standard conditions of the resource.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -55,7 +55,9 @@ type FluentdSpec struct {
 	// BufferStorageVolume is by default configured as PVC using FluentdPvcSpec
 	// +docLink:"volume.KubernetesVolume,https://github.com/cisco-open/operator-tools/tree/master/docs/types"
 	BufferStorageVolume volume.KubernetesVolume `json:"bufferStorageVolume,omitempty"`
-	ExtraVolumes        []ExtraVolume           `json:"extraVolumes,omitempty"`
+	// Compute the `total_limit_size` of the file buffers without an explicit limit from the size of the buffer volume
+	BufferLimits *BufferLimits `json:"bufferLimits,omitempty"`
+	ExtraVolumes []ExtraVolume `json:"extraVolumes,omitempty"`
 	// Deprecated, use bufferStorageVolume
 	FluentdPvcSpec                         *volume.KubernetesVolume    `json:"fluentdPvcSpec,omitempty"`
 	VolumeMountChmod                       bool                        `json:"volumeMountChmod,omitempty"`
@@ -119,6 +121,8 @@ type FluentdSpec struct {
 	// Overrides the default logging level configCheck setup
 	// This field is not used directly, just copied over the field in the logging resource if defined
 	ConfigCheck *ConfigCheck `json:"configCheck,omitempty"`
+	// Configure how a new configuration is rolled out to the fluentd replicas
+	ConfigRollout *ConfigRollout `json:"configRollout,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
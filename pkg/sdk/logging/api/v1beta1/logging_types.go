@@ -52,6 +52,9 @@ type LoggingSpec struct {
 	// ConfigCheck settings that apply to both fluentd or syslog-ng.
 	// Can be overridden on the fluentd / syslog-ng level.
 	ConfigCheck ConfigCheck `json:"configCheck,omitempty"`
+	// Keep the changes of the rendered fluentd or syslog-ng configuration in a ConfigMap in the control namespace.
+	// An event about the changed flows, filters and outputs is emitted on every config change regardless of this setting.
+	ConfigDiffHistory *ConfigDiffHistory `json:"configDiffHistory,omitempty"`
 	// FluentbitAgent daemonset configuration.
 	// DEPRECATED: Migrate to the standalone FluentBitAgent resource
 	FluentbitSpec *FluentbitSpec `json:"fluentbit,omitempty"`
@@ -121,6 +124,12 @@ type ConfigCheck struct {
 	Labels map[string]string `json:"labels,omitempty"`
 }
 
+type ConfigDiffHistory struct {
+	// Number of config changes to keep in the history. Default: 10
+	// +kubebuilder:validation:Minimum=1
+	Limit int `json:"limit,omitempty"`
+}
+
 type RouteConfig struct {
 	// If DisableLoggingRoute is set to true, the logging route controller
 	// should remove the given tenant from the status of the logging resource.
@@ -134,10 +143,44 @@ type RouteConfig struct {
 	TenantLabels map[string]string `json:"tenantLabels,omitempty"`
 }
 
+// ConfigRollback describes an automatic rollback of the aggregator config
+type ConfigRollback struct {
+	// Hash of the config that made the aggregator pods crash-loop
+	FailedHash string `json:"failedHash"`
+	// Hash of the last known good config that has been restored
+	RestoredHash string `json:"restoredHash"`
+	// Time of the rollback
+	Time metav1.Time `json:"time"`
+}
+
+// ConfigRolloutStatus describes the canary rollout of the aggregator config
+type ConfigRolloutStatus struct {
+	// Hash of the config running on every replica
+	StableHash string `json:"stableHash,omitempty"`
+	// Hash of the config being rolled out to the canary replicas
+	CanaryHash string `json:"canaryHash,omitempty"`
+	// Phase of the rollout: Progressing, Promoted or Aborted
+	Phase string `json:"phase,omitempty"`
+	// Time the canary replicas became ready and the analysis started
+	AnalysisStartTime *metav1.Time `json:"analysisStartTime,omitempty"`
+	// Output errors and retries reported by the canary replicas when the analysis started
+	BaselineErrors int64 `json:"baselineErrors,omitempty"`
+	// Human readable details about the state of the rollout
+	Message string `json:"message,omitempty"`
+}
+
 // LoggingStatus defines the observed state of Logging
 type LoggingStatus struct {
 	// Result of the config check. Under normal conditions there is a single item in the map with a bool value.
 	ConfigCheckResults map[string]bool `json:"configCheckResults,omitempty"`
+	// Output of the failed config checks keyed by the config hash.
+	// It is either the termination message or the log tail of the config check pod.
+	ConfigCheckFailures map[string]string `json:"configCheckFailures,omitempty"`
+	// Set when the aggregator pods crash-looped after a config change and the last known good config was restored.
+	// It is cleared once a new config is applied successfully.
+	ConfigRollback *ConfigRollback `json:"configRollback,omitempty"`
+	// State of the canary rollout of the aggregator config, if the Canary rollout strategy is used.
+	ConfigRollout *ConfigRolloutStatus `json:"configRollout,omitempty"`
 	// Available in Logging operator version 4.5 and later. Name of the matched detached fluentd configuration object.
 	FluentdConfigName string `json:"fluentdConfigName,omitempty"`
 	// Available in Logging operator version 4.5 and later. Name of the matched detached SyslogNG configuration object.
@@ -150,6 +193,12 @@ type LoggingStatus struct {
 	// List of namespaces that watchNamespaces + watchNamespaceSelector is resolving to.
 	// Not set means all namespaces.
 	WatchNamespaces []string `json:"watchNamespaces,omitempty"`
+	// Generation of the resource that was last processed by the operator.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Standard conditions of the resource.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,139 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +name:"LogQuotaSpec"
+// +weight:"200"
+type _hugoLogQuotaSpec interface{} //nolint:deadcode,unused
+
+// +name:"LogQuotaSpec"
+// +version:"v1beta1"
+// +description:"LogQuotaSpec defines the limits of the logs the aggregator accepts from namespaces"
+type _metaLogQuotaSpec interface{} //nolint:deadcode,unused
+
+// LogQuotaSpec defines the limits of the logs the aggregator accepts from namespaces.
+// Every namespace has its own budget, the limits are not shared between the namespaces of the quota.
+type LogQuotaSpec struct {
+	// Reference to the logging system the quota is enforced by
+	LoggingRef string `json:"loggingRef,omitempty"`
+	// Namespaces the quota applies to
+	Namespaces []string `json:"namespaces,omitempty"`
+	// The quota applies to the namespaces with matching labels as well
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Number of records per second a namespace can send
+	RecordsPerSecond int `json:"recordsPerSecond,omitempty"`
+	// Number of bytes per second a namespace can send.
+	// Neither fluentd nor syslog-ng can measure the size of the records, so the limit is turned into records with averageRecordSize.
+	BytesPerSecond *resource.Quantity `json:"bytesPerSecond,omitempty"`
+	// Number of records a namespace can send a day. Only enforced by fluentd.
+	DailyRecords int64 `json:"dailyRecords,omitempty"`
+	// Volume of logs a namespace can send a day, turned into records with averageRecordSize. Only enforced by fluentd.
+	DailyBytes *resource.Quantity `json:"dailyBytes,omitempty"`
+	// The average size of a record used to turn the byte limits into record limits (default: 1Ki)
+	AverageRecordSize *resource.Quantity `json:"averageRecordSize,omitempty"`
+}
+
+// RecordsPerSecondLimit returns the strictest of the per second limits in records or 0 if there is none
+func (s LogQuotaSpec) RecordsPerSecondLimit() int64 {
+	return strictest(int64(s.RecordsPerSecond), s.bytesToRecords(s.BytesPerSecond))
+}
+
+// DailyRecordsLimit returns the strictest of the daily limits in records or 0 if there is none
+func (s LogQuotaSpec) DailyRecordsLimit() int64 {
+	return strictest(s.DailyRecords, s.bytesToRecords(s.DailyBytes))
+}
+
+func (s LogQuotaSpec) bytesToRecords(bytes *resource.Quantity) int64 {
+	if bytes == nil || bytes.IsZero() {
+		return 0
+	}
+	recordSize := int64(1024)
+	if s.AverageRecordSize != nil && s.AverageRecordSize.Value() > 0 {
+		recordSize = s.AverageRecordSize.Value()
+	}
+	// a limit that is set should never turn into no limit
+	return max(bytes.Value()/recordSize, 1)
+}
+
+func strictest(a, b int64) int64 {
+	switch {
+	case a <= 0:
+		return b
+	case b <= 0:
+		return a
+	default:
+		return min(a, b)
+	}
+}
+
+// LogQuotaStatus defines the observed state of LogQuota
+type LogQuotaStatus struct {
+	// Namespaces the quota is enforced on
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Consumption of the namespaces as reported by the metrics of the aggregator
+	Usage []NamespaceQuotaUsage `json:"usage,omitempty"`
+	// Last time the usage was updated
+	UsageUpdateTime *metav1.Time `json:"usageUpdateTime,omitempty"`
+	// Problems with the quota
+	Problems []string `json:"problems,omitempty"`
+	// Number of problems
+	ProblemsCount int `json:"problemsCount,omitempty"`
+}
+
+type NamespaceQuotaUsage struct {
+	Namespace string `json:"namespace"`
+	// Number of records received from the namespace since the aggregator pods started
+	Records int64 `json:"records"`
+	// Number of records dropped since the aggregator pods started, because the namespace exceeded the quota
+	ThrottledRecords int64 `json:"throttledRecords"`
+	// Whether records have been dropped since the previous update
+	Throttled bool `json:"throttled"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=logquotas,scope=Cluster,shortName=lq,categories=logging-all
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Logging",type="string",JSONPath=".spec.loggingRef",description="Logging the quota is enforced by"
+// +kubebuilder:printcolumn:name="Records/s",type="integer",JSONPath=".spec.recordsPerSecond",description="Records per second limit"
+// +kubebuilder:printcolumn:name="Problems",type="integer",JSONPath=".status.problemsCount",description="Number of problems"
+// +kubebuilder:storageversion
+
+// LogQuota limits the logs the aggregator accepts from namespaces.
+// The limits are enforced before the filters of the flows, so tenants cannot remove them.
+type LogQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LogQuotaSpec   `json:"spec,omitempty"`
+	Status LogQuotaStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LogQuotaList contains a list of LogQuota
+type LogQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LogQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LogQuota{}, &LogQuotaList{})
+}
@@ -31,7 +31,11 @@ type _metaOutputSpec interface{} //nolint:deadcode,unused
 
 // OutputSpec defines the desired state of Output
 type OutputSpec struct {
-	LoggingRef                        string                                    `json:"loggingRef,omitempty"`
+	LoggingRef string           `json:"loggingRef,omitempty"`
+	Secondary  *OutputSecondary `json:"secondary,omitempty"`
+	// Share of the buffer volume of the output relative to the other outputs, used if the buffer limits are enabled in the fluentd spec (default: 1)
+	// +kubebuilder:validation:Minimum=1
+	BufferWeight                      int32                                     `json:"bufferWeight,omitempty"`
 	S3OutputConfig                    *output.S3OutputConfig                    `json:"s3,omitempty"`
 	AzureStorage                      *output.AzureStorage                      `json:"azurestorage,omitempty"`
 	GCSOutput                         *output.GCSOutput                         `json:"gcs,omitempty"`
@@ -62,6 +66,21 @@ type OutputSpec struct {
 	VMwareLogInsightConfig            *output.VMwareLogInsightOutput            `json:"vmwareLogInsight,omitempty"`
 	VMwareLogIntelligenceOutputConfig *output.VMwareLogIntelligenceOutputConfig `json:"vmwareLogIntelligence,omitempty"`
 	LMLogsOutputConfig                *output.LMLogsOutputConfig                `json:"lmLogs,omitempty"`
+	OpenTelemetryOutput               *output.OpenTelemetryOutputConfig         `json:"opentelemetry,omitempty"`
+	ClickHouseOutput                  *output.ClickHouseOutputConfig            `json:"clickhouse,omitempty"`
+}
+
+// OutputSecondary receives the chunks of the output that exhausted their retries instead of discarding them.
+// Exactly one of the targets must be set. Use the `retry_secondary_threshold` buffer option to switch to the secondary earlier.
+type OutputSecondary struct {
+	// Name of an Output in the namespace of the Output, not available for ClusterOutputs
+	OutputRef string `json:"outputRef,omitempty"`
+	// Name of a ClusterOutput
+	ClusterOutputRef string `json:"clusterOutputRef,omitempty"`
+	// Write the chunks to files
+	File *output.FileOutputConfig `json:"file,omitempty"`
+	// Write the chunks to S3
+	S3 *output.S3OutputConfig `json:"s3,omitempty"`
 }
 
 // OutputStatus defines the observed state of Output
@@ -69,6 +88,16 @@ type OutputStatus struct {
 	Active        *bool    `json:"active,omitempty"`
 	Problems      []string `json:"problems,omitempty"`
 	ProblemsCount int      `json:"problemsCount,omitempty"`
+	// Number of chunks written to the secondary output by the running fluentd pods
+	SecondaryChunks int64 `json:"secondaryChunks,omitempty"`
+	// Size limit of each buffer of the output computed from the size of the buffer volume, set if the buffer limits are enabled
+	BufferLimit string `json:"bufferLimit,omitempty"`
+	// Generation of the resource that was last processed by the operator.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Standard conditions of the resource.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
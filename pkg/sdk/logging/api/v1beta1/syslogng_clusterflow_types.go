@@ -54,6 +54,8 @@ type SyslogNGClusterFlowSpec struct {
 	// Output metrics are applied before the log reaches the destination and contain output metadata like: `name,` `namespace` and `scope`. Scope shows whether the output is a local or global one.
 	// Available in Logging operator version 4.5 and later.
 	OutputMetrics []filter.MetricsProbe `json:"outputMetrics,omitempty"`
+	// Names of the sources of the syslog-ng spec the flow receives records from. The logs of the cluster are received by the `main` source. (default: [main])
+	SourceRefs []string `json:"sourceRefs,omitempty"`
 }
 
 type SyslogNGClusterMatch SyslogNGMatch
@@ -54,6 +54,9 @@ type SyslogNGFilter struct {
 	Match   *filter.MatchConfig    `json:"match,omitempty" syslog-ng:"xform-kind=filter"`
 	Rewrite []filter.RewriteConfig `json:"rewrite,omitempty" syslog-ng:"xform-kind=rewrite"`
 	Parser  *filter.ParserConfig   `json:"parser,omitempty" syslog-ng:"xform-kind=parser"`
+	Redact  *filter.RedactConfig   `json:"redact,omitempty" syslog-ng:"xform-kind=redact"`
+	Sample  *filter.SampleConfig   `json:"sample,omitempty" syslog-ng:"xform-kind=sample"`
+	FilterX *filter.FilterXConfig  `json:"filterx,omitempty" syslog-ng:"xform-kind=filterx"`
 }
 
 type SyslogNGFlowStatus FlowStatus
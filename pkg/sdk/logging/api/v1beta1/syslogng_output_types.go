@@ -31,7 +31,10 @@ type _metaSyslogNGOutputSpec interface{} //nolint:deadcode,unused
 
 // SyslogNGOutputSpec defines the desired state of SyslogNGOutput
 type SyslogNGOutputSpec struct {
-	LoggingRef              string                                `json:"loggingRef,omitempty"`
+	LoggingRef string `json:"loggingRef,omitempty"`
+	// Share of the buffer volume of the disk-buffer of the output relative to the other outputs, used if the buffer limits are enabled in the syslog-ng spec (default: 1)
+	// +kubebuilder:validation:Minimum=1
+	BufferWeight            int32                                 `json:"bufferWeight,omitempty"`
 	Loggly                  *output.Loggly                        `json:"loggly,omitempty" syslog-ng:"dest-drv"`
 	Syslog                  *output.SyslogOutput                  `json:"syslog,omitempty" syslog-ng:"dest-drv"`
 	File                    *output.FileOutput                    `json:"file,omitempty" syslog-ng:"dest-drv"`
@@ -53,6 +56,8 @@ type SyslogNGOutputSpec struct {
 	Openobserve *output.OpenobserveOutput `json:"openobserve,omitempty" syslog-ng:"dest-drv,name=openobserve-log"`
 	// Available in Logging operator version 4.8 and later
 	OpenTelemetry *output.OpenTelemetryOutput `json:"opentelemetry,omitempty" syslog-ng:"dest-drv,name=opentelemetry"`
+	ClickHouse    *output.ClickHouseOutput    `json:"clickhouse,omitempty" syslog-ng:"dest-drv,name=http"`
+	Kafka         *output.KafkaOutput         `json:"kafka,omitempty" syslog-ng:"dest-drv,name=kafka-c"`
 }
 
 type SyslogNGOutputStatus OutputStatus
@@ -64,9 +64,12 @@ type SyslogNGSpec struct {
 	BufferVolumeMetricsServiceOverrides *typeoverride.Service        `json:"bufferVolumeMetricsService,omitempty"`
 	BufferVolumeMetricsResources        corev1.ResourceRequirements  `json:"bufferVolumeMetricsResources,omitempty"`
 	BufferVolumeMetricsLivenessProbe    *corev1.Probe                `json:"bufferVolumeMetricsLivenessProbe,omitempty"`
-	GlobalOptions                       *GlobalOptions               `json:"globalOptions,omitempty"`
-	JSONKeyPrefix                       string                       `json:"jsonKeyPrefix,omitempty"`
-	JSONKeyDelimiter                    string                       `json:"jsonKeyDelim,omitempty"`
+	// Compute the `disk_buf_size` of the disk-buffers of the outputs without an explicit size from the size of the buffer volume.
+	// The buffer volume is the volume claim template of the `buffers` volume, or the one named in `bufferVolumeMetrics.mount_name`.
+	BufferLimits     *BufferLimits  `json:"bufferLimits,omitempty"`
+	GlobalOptions    *GlobalOptions `json:"globalOptions,omitempty"`
+	JSONKeyPrefix    string         `json:"jsonKeyPrefix,omitempty"`
+	JSONKeyDelimiter string         `json:"jsonKeyDelim,omitempty"`
 	// Available in Logging operator version 4.5 and later.
 	// Parses date automatically from the timestamp registered by the container runtime.
 	// Note: `jsonKeyPrefix` and `jsonKeyDelim` are respected.
@@ -81,6 +84,12 @@ type SyslogNGSpec struct {
 	// Overrides the default logging level configCheck setup.
 	// This field is not used directly, just copied over the field in the logging resource if defined.
 	ConfigCheck *ConfigCheck `json:"configCheck,omitempty"`
+	// Configure how a new configuration is rolled out to the syslog-ng replicas.
+	ConfigRollout *ConfigRollout `json:"configRollout,omitempty"`
+	// Additional sources receiving logs from outside the cluster, for example from network appliances.
+	// The ports of the sources are added to the syslog-ng service. SyslogNGClusterFlows select the sources in their `sourceRefs`, SyslogNGFlows receive the logs of the cluster only.
+	// The records of the sources are not parsed as JSON, so they have no `json.kubernetes` metadata.
+	Sources []SyslogNGSource `json:"sources,omitempty"`
 }
 
 //
@@ -107,6 +116,111 @@ type SyslogNGTLS struct {
 	SharedKey  string `json:"sharedKey,omitempty"`
 }
 
+// MainSourceName is the name of the source receiving the logs of the cluster from fluent-bit
+const MainSourceName = "main"
+
+// +kubebuilder:object:generate=true
+
+// SyslogNGSource is a source receiving logs from outside the cluster, exactly one of its drivers must be set
+type SyslogNGSource struct {
+	// Name of the source, referenced in the `sourceRefs` of the SyslogNGClusterFlows. The name `main` is reserved for the logs of the cluster.
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +kubebuilder:validation:MaxLength=50
+	Name string `json:"name"`
+	// Port of the source on the syslog-ng pods and service. It must differ from the ports of the other sources, including the main source (601),
+	// the udp port of the service (514) and the ports of the metrics exporters. Sources with a clashing port are not exposed.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// Receive syslog messages over UDP, TCP or TLS
+	Syslog *SyslogNGSyslogSource `json:"syslog,omitempty"`
+	// Receive logs over the OpenTelemetry protocol (OTLP/gRPC)
+	OpenTelemetry *SyslogNGOpenTelemetrySource `json:"opentelemetry,omitempty"`
+	// Receive logs in HTTP requests with the `webhook()` source
+	HTTP *SyslogNGHTTPSource `json:"http,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type SyslogNGSyslogSource struct {
+	// Transport of the messages, `tls` is used if `tls` is set. (default: tcp)
+	// +kubebuilder:validation:Enum=udp;tcp
+	Transport string `json:"transport,omitempty"`
+	// Format of the messages: `rfc5424` (IETF syslog, octet-counted framing on TCP) or `rfc3164` (BSD syslog, newline-separated). (default: rfc5424)
+	// +kubebuilder:validation:Enum=rfc5424;rfc3164
+	Format string `json:"format,omitempty"`
+	// Flags of the source, for example `no-parse` or `store-raw-message`
+	Flags []string `json:"flags,omitempty"`
+	// Maximum number of parallel connections. (default: 10)
+	MaxConnections int `json:"maxConnections,omitempty"`
+	// Receive the messages over TLS
+	TLS *SyslogNGSourceTLS `json:"tls,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type SyslogNGOpenTelemetrySource struct {
+	// Maximum number of requests processed in parallel
+	ConcurrentRequests int `json:"concurrentRequests,omitempty"`
+	// Receive the logs over TLS
+	TLS *SyslogNGSourceTLS `json:"tls,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type SyslogNGHTTPSource struct {
+	// Receive the requests over HTTPS
+	TLS *SyslogNGSourceTLS `json:"tls,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// SyslogNGSourceTLS configures a source to use the certificates of the syslog-ng `tls` secret:
+// `tls.key`, `tls.crt` and `ca.crt`. It requires `tls.enabled`.
+type SyslogNGSourceTLS struct {
+	// Verification of the certificates of the clients. (default: required-trusted)
+	// +kubebuilder:validation:Enum=optional-untrusted;optional-trusted;required-untrusted;required-trusted
+	PeerVerify string `json:"peerVerify,omitempty"`
+}
+
+// ServiceProtocol returns the protocol of the port of the source
+func (s SyslogNGSource) ServiceProtocol() corev1.Protocol {
+	if s.Syslog != nil && s.Syslog.Transport == "udp" {
+		return corev1.ProtocolUDP
+	}
+	return corev1.ProtocolTCP
+}
+
+// ServicePortName returns the name of the port of the source on the service
+func (s SyslogNGSource) ServicePortName() string {
+	switch {
+	case s.OpenTelemetry != nil:
+		return "grpc-" + s.Name
+	case s.HTTP != nil:
+		return "http-" + s.Name
+	case s.ServiceProtocol() == corev1.ProtocolUDP:
+		return "udp-" + s.Name
+	default:
+		return "tcp-" + s.Name
+	}
+}
+
+// HasSource returns true if the name is the main source or one of the additional sources
+func (s *SyslogNGSpec) HasSource(name string) bool {
+	if name == MainSourceName {
+		return true
+	}
+	if s == nil {
+		return false
+	}
+	for _, source := range s.Sources {
+		if source.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 type GlobalOptions struct {
 	// Deprecated. Use stats/level from 4.1+
 	StatsLevel *int `json:"stats_level,omitempty"`
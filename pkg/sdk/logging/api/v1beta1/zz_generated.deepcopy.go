@@ -106,6 +106,26 @@ func (in *AxoSyslogSpec) DeepCopyInto(out *AxoSyslogSpec) {
 		*out = make([]Destination, len(*in))
 		copy(*out, *in)
 	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(BasicImageSpec)
+		**out = **in
+	}
+	if in.ConfigReloadImage != nil {
+		in, out := &in.ConfigReloadImage, &out.ConfigReloadImage
+		*out = new(BasicImageSpec)
+		**out = **in
+	}
+	if in.StatefulSetOverrides != nil {
+		in, out := &in.StatefulSetOverrides, &out.StatefulSetOverrides
+		*out = new(typeoverride.StatefulSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountOverrides != nil {
+		in, out := &in.ServiceAccountOverrides, &out.ServiceAccountOverrides
+		*out = new(typeoverride.ServiceAccount)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AxoSyslogSpec.
@@ -160,6 +180,26 @@ func (in *BasicImageSpec) DeepCopy() *BasicImageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BufferLimits) DeepCopyInto(out *BufferLimits) {
+	*out = *in
+	if in.VolumeSize != nil {
+		in, out := &in.VolumeSize, &out.VolumeSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BufferLimits.
+func (in *BufferLimits) DeepCopy() *BufferLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(BufferLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BufferMetrics) DeepCopyInto(out *BufferMetrics) {
 	*out = *in
@@ -191,6 +231,31 @@ func (in *BufferStorage) DeepCopy() *BufferStorage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryRollout) DeepCopyInto(out *CanaryRollout) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.ErrorMetrics != nil {
+		in, out := &in.ErrorMetrics, &out.ErrorMetrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryRollout.
+func (in *CanaryRollout) DeepCopy() *CanaryRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterExclude) DeepCopyInto(out *ClusterExclude) {
 	*out = *in
@@ -228,6 +293,20 @@ func (in *ClusterExclude) DeepCopyInto(out *ClusterExclude) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LabelExpressions != nil {
+		in, out := &in.LabelExpressions, &out.LabelExpressions
+		*out = make([]LabelExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExclude.
@@ -316,6 +395,11 @@ func (in *ClusterFlowSpec) DeepCopyInto(out *ClusterFlowSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MatchExpression != nil {
+		in, out := &in.MatchExpression, &out.MatchExpression
+		*out = new(FlowMatchExpression)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Filters != nil {
 		in, out := &in.Filters, &out.Filters
 		*out = make([]Filter, len(*in))
@@ -487,6 +571,20 @@ func (in *ClusterSelect) DeepCopyInto(out *ClusterSelect) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LabelExpressions != nil {
+		in, out := &in.LabelExpressions, &out.LabelExpressions
+		*out = make([]LabelExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSelect.
@@ -521,6 +619,76 @@ func (in *ConfigCheck) DeepCopy() *ConfigCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigDiffHistory) DeepCopyInto(out *ConfigDiffHistory) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigDiffHistory.
+func (in *ConfigDiffHistory) DeepCopy() *ConfigDiffHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigDiffHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigRollback) DeepCopyInto(out *ConfigRollback) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigRollback.
+func (in *ConfigRollback) DeepCopy() *ConfigRollback {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigRollback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigRollout) DeepCopyInto(out *ConfigRollout) {
+	*out = *in
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryRollout)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigRollout.
+func (in *ConfigRollout) DeepCopy() *ConfigRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigRolloutStatus) DeepCopyInto(out *ConfigRolloutStatus) {
+	*out = *in
+	if in.AnalysisStartTime != nil {
+		in, out := &in.AnalysisStartTime, &out.AnalysisStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigRolloutStatus.
+func (in *ConfigRolloutStatus) DeepCopy() *ConfigRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DefaultFlowSpec) DeepCopyInto(out *DefaultFlowSpec) {
 	*out = *in
@@ -600,6 +768,20 @@ func (in *Exclude) DeepCopyInto(out *Exclude) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LabelExpressions != nil {
+		in, out := &in.LabelExpressions, &out.LabelExpressions
+		*out = make([]LabelExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Exclude.
@@ -710,6 +892,16 @@ func (in *Filter) DeepCopyInto(out *Filter) {
 		*out = new(filter.KubeEventsTimestampConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Redact != nil {
+		in, out := &in.Redact, &out.Redact
+		*out = new(filter.Redact)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sample != nil {
+		in, out := &in.Sample, &out.Sample
+		*out = new(filter.Sample)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Filter.
@@ -1060,6 +1252,125 @@ func (in *FlowList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowMatchExpression) DeepCopyInto(out *FlowMatchExpression) {
+	*out = *in
+	if in.And != nil {
+		in, out := &in.And, &out.And
+		*out = make([]FlowMatchExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Or != nil {
+		in, out := &in.Or, &out.Or
+		*out = make([]FlowMatchExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Not != nil {
+		in, out := &in.Not, &out.Not
+		*out = new(FlowMatchExpression)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NamespaceLabels != nil {
+		in, out := &in.NamespaceLabels, &out.NamespaceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespacesRegex != nil {
+		in, out := &in.NamespacesRegex, &out.NamespacesRegex
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContainerNames != nil {
+		in, out := &in.ContainerNames, &out.ContainerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LabelExpressions != nil {
+		in, out := &in.LabelExpressions, &out.LabelExpressions
+		*out = make([]LabelExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowMatchExpression.
+func (in *FlowMatchExpression) DeepCopy() *FlowMatchExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowMatchExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowRoute) DeepCopyInto(out *FlowRoute) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]RouteCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]Filter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GlobalOutputRefs != nil {
+		in, out := &in.GlobalOutputRefs, &out.GlobalOutputRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LocalOutputRefs != nil {
+		in, out := &in.LocalOutputRefs, &out.LocalOutputRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowRoute.
+func (in *FlowRoute) DeepCopy() *FlowRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlowSpec) DeepCopyInto(out *FlowSpec) {
 	*out = *in
@@ -1077,6 +1388,11 @@ func (in *FlowSpec) DeepCopyInto(out *FlowSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MatchExpression != nil {
+		in, out := &in.MatchExpression, &out.MatchExpression
+		*out = new(FlowMatchExpression)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Filters != nil {
 		in, out := &in.Filters, &out.Filters
 		*out = make([]Filter, len(*in))
@@ -1104,6 +1420,13 @@ func (in *FlowSpec) DeepCopyInto(out *FlowSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]FlowRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowSpec.
@@ -1129,6 +1452,13 @@ func (in *FlowStatus) DeepCopyInto(out *FlowStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowStatus.
@@ -1162,7 +1492,7 @@ func (in *FluentbitAgent) DeepCopyInto(out *FluentbitAgent) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentbitAgent.
@@ -1458,6 +1788,23 @@ func (in *FluentbitSpec) DeepCopy() *FluentbitSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FluentbitStatus) DeepCopyInto(out *FluentbitStatus) {
 	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tenants != nil {
+		in, out := &in.Tenants, &out.Tenants
+		*out = make([]Tenant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Problems != nil {
+		in, out := &in.Problems, &out.Problems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentbitStatus.
@@ -1582,6 +1929,13 @@ func (in *FluentdConfigStatus) DeepCopyInto(out *FluentdConfigStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdConfigStatus.
@@ -1692,6 +2046,11 @@ func (in *FluentdSpec) DeepCopyInto(out *FluentdSpec) {
 	out.TLS = in.TLS
 	in.Image.DeepCopyInto(&out.Image)
 	in.BufferStorageVolume.DeepCopyInto(&out.BufferStorageVolume)
+	if in.BufferLimits != nil {
+		in, out := &in.BufferLimits, &out.BufferLimits
+		*out = new(BufferLimits)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExtraVolumes != nil {
 		in, out := &in.ExtraVolumes, &out.ExtraVolumes
 		*out = make([]ExtraVolume, len(*in))
@@ -1820,6 +2179,11 @@ func (in *FluentdSpec) DeepCopyInto(out *FluentdSpec) {
 		*out = new(ConfigCheck)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConfigRollout != nil {
+		in, out := &in.ConfigRollout, &out.ConfigRollout
+		*out = new(ConfigRollout)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdSpec.
@@ -1955,51 +2319,204 @@ func (in *ImageSpec) DeepCopy() *ImageSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InputTail) DeepCopyInto(out *InputTail) {
+func (in *InputTail) DeepCopyInto(out *InputTail) {
+	*out = *in
+	if in.DB != nil {
+		in, out := &in.DB, &out.DB
+		*out = new(string)
+		**out = **in
+	}
+	if in.DBLocking != nil {
+		in, out := &in.DBLocking, &out.DBLocking
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ParserN != nil {
+		in, out := &in.ParserN, &out.ParserN
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MultilineParser != nil {
+		in, out := &in.MultilineParser, &out.MultilineParser
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InputTail.
+func (in *InputTail) DeepCopy() *InputTail {
+	if in == nil {
+		return nil
+	}
+	out := new(InputTail)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LabelExpression) DeepCopyInto(out *LabelExpression) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelExpression.
+func (in *LabelExpression) DeepCopy() *LabelExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(LabelExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogPath) DeepCopyInto(out *LogPath) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogPath.
+func (in *LogPath) DeepCopy() *LogPath {
+	if in == nil {
+		return nil
+	}
+	out := new(LogPath)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogQuota) DeepCopyInto(out *LogQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogQuota.
+func (in *LogQuota) DeepCopy() *LogQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(LogQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LogQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogQuotaList) DeepCopyInto(out *LogQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LogQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogQuotaList.
+func (in *LogQuotaList) DeepCopy() *LogQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(LogQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LogQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogQuotaSpec) DeepCopyInto(out *LogQuotaSpec) {
 	*out = *in
-	if in.DB != nil {
-		in, out := &in.DB, &out.DB
-		*out = new(string)
-		**out = **in
-	}
-	if in.DBLocking != nil {
-		in, out := &in.DBLocking, &out.DBLocking
-		*out = new(bool)
-		**out = **in
-	}
-	if in.ParserN != nil {
-		in, out := &in.ParserN, &out.ParserN
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.MultilineParser != nil {
-		in, out := &in.MultilineParser, &out.MultilineParser
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BytesPerSecond != nil {
+		in, out := &in.BytesPerSecond, &out.BytesPerSecond
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DailyBytes != nil {
+		in, out := &in.DailyBytes, &out.DailyBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.AverageRecordSize != nil {
+		in, out := &in.AverageRecordSize, &out.AverageRecordSize
+		x := (*in).DeepCopy()
+		*out = &x
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InputTail.
-func (in *InputTail) DeepCopy() *InputTail {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogQuotaSpec.
+func (in *LogQuotaSpec) DeepCopy() *LogQuotaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InputTail)
+	out := new(LogQuotaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogPath) DeepCopyInto(out *LogPath) {
+func (in *LogQuotaStatus) DeepCopyInto(out *LogQuotaStatus) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = make([]NamespaceQuotaUsage, len(*in))
+		copy(*out, *in)
+	}
+	if in.UsageUpdateTime != nil {
+		in, out := &in.UsageUpdateTime, &out.UsageUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Problems != nil {
+		in, out := &in.Problems, &out.Problems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogPath.
-func (in *LogPath) DeepCopy() *LogPath {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogQuotaStatus.
+func (in *LogQuotaStatus) DeepCopy() *LogQuotaStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LogPath)
+	out := new(LogQuotaStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2174,6 +2691,11 @@ func (in *LoggingRouteStatus) DeepCopy() *LoggingRouteStatus {
 func (in *LoggingSpec) DeepCopyInto(out *LoggingSpec) {
 	*out = *in
 	in.ConfigCheck.DeepCopyInto(&out.ConfigCheck)
+	if in.ConfigDiffHistory != nil {
+		in, out := &in.ConfigDiffHistory, &out.ConfigDiffHistory
+		*out = new(ConfigDiffHistory)
+		**out = **in
+	}
 	if in.FluentbitSpec != nil {
 		in, out := &in.FluentbitSpec, &out.FluentbitSpec
 		*out = new(FluentbitSpec)
@@ -2243,6 +2765,23 @@ func (in *LoggingStatus) DeepCopyInto(out *LoggingStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.ConfigCheckFailures != nil {
+		in, out := &in.ConfigCheckFailures, &out.ConfigCheckFailures
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ConfigRollback != nil {
+		in, out := &in.ConfigRollback, &out.ConfigRollback
+		*out = new(ConfigRollback)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigRollout != nil {
+		in, out := &in.ConfigRollout, &out.ConfigRollout
+		*out = new(ConfigRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Problems != nil {
 		in, out := &in.Problems, &out.Problems
 		*out = make([]string, len(*in))
@@ -2253,6 +2792,13 @@ func (in *LoggingStatus) DeepCopyInto(out *LoggingStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingStatus.
@@ -2313,6 +2859,21 @@ func (in *Metrics) DeepCopy() *Metrics {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceQuotaUsage) DeepCopyInto(out *NamespaceQuotaUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceQuotaUsage.
+func (in *NamespaceQuotaUsage) DeepCopy() *NamespaceQuotaUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceQuotaUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OTLPSource) DeepCopyInto(out *OTLPSource) {
 	*out = *in
@@ -2402,9 +2963,39 @@ func (in *OutputList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputSecondary) DeepCopyInto(out *OutputSecondary) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(output.FileOutputConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(output.S3OutputConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputSecondary.
+func (in *OutputSecondary) DeepCopy() *OutputSecondary {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputSecondary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OutputSpec) DeepCopyInto(out *OutputSpec) {
 	*out = *in
+	if in.Secondary != nil {
+		in, out := &in.Secondary, &out.Secondary
+		*out = new(OutputSecondary)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.S3OutputConfig != nil {
 		in, out := &in.S3OutputConfig, &out.S3OutputConfig
 		*out = new(output.S3OutputConfig)
@@ -2555,6 +3146,16 @@ func (in *OutputSpec) DeepCopyInto(out *OutputSpec) {
 		*out = new(output.LMLogsOutputConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OpenTelemetryOutput != nil {
+		in, out := &in.OpenTelemetryOutput, &out.OpenTelemetryOutput
+		*out = new(output.OpenTelemetryOutputConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClickHouseOutput != nil {
+		in, out := &in.ClickHouseOutput, &out.ClickHouseOutput
+		*out = new(output.ClickHouseOutputConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputSpec.
@@ -2580,6 +3181,13 @@ func (in *OutputStatus) DeepCopyInto(out *OutputStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputStatus.
@@ -2681,6 +3289,21 @@ func (in *ReadinessDefaultCheck) DeepCopy() *ReadinessDefaultCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteCondition) DeepCopyInto(out *RouteCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteCondition.
+func (in *RouteCondition) DeepCopy() *RouteCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteConfig) DeepCopyInto(out *RouteConfig) {
 	*out = *in
@@ -2758,6 +3381,20 @@ func (in *Select) DeepCopyInto(out *Select) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LabelExpressions != nil {
+		in, out := &in.LabelExpressions, &out.LabelExpressions
+		*out = make([]LabelExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Select.
@@ -2967,6 +3604,11 @@ func (in *SyslogNGClusterFlowSpec) DeepCopyInto(out *SyslogNGClusterFlowSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SourceRefs != nil {
+		in, out := &in.SourceRefs, &out.SourceRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGClusterFlowSpec.
@@ -3006,6 +3648,11 @@ func (in *SyslogNGClusterMatch) DeepCopyInto(out *SyslogNGClusterMatch) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(syslogngfilter.SelectorMatchExpr)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGClusterMatch.
@@ -3165,6 +3812,13 @@ func (in *SyslogNGConfigStatus) DeepCopyInto(out *SyslogNGConfigStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGConfigStatus.
@@ -3197,6 +3851,21 @@ func (in *SyslogNGFilter) DeepCopyInto(out *SyslogNGFilter) {
 		*out = new(syslogngfilter.ParserConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Redact != nil {
+		in, out := &in.Redact, &out.Redact
+		*out = new(syslogngfilter.RedactConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sample != nil {
+		in, out := &in.Sample, &out.Sample
+		*out = new(syslogngfilter.SampleConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FilterX != nil {
+		in, out := &in.FilterX, &out.FilterX
+		*out = new(syslogngfilter.FilterXConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGFilter.
@@ -3325,6 +3994,13 @@ func (in *SyslogNGFlowStatus) DeepCopyInto(out *SyslogNGFlowStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGFlowStatus.
@@ -3337,6 +4013,26 @@ func (in *SyslogNGFlowStatus) DeepCopy() *SyslogNGFlowStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyslogNGHTTPSource) DeepCopyInto(out *SyslogNGHTTPSource) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(SyslogNGSourceTLS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGHTTPSource.
+func (in *SyslogNGHTTPSource) DeepCopy() *SyslogNGHTTPSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SyslogNGHTTPSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyslogNGMatch) DeepCopyInto(out *SyslogNGMatch) {
 	*out = *in
@@ -3364,6 +4060,11 @@ func (in *SyslogNGMatch) DeepCopyInto(out *SyslogNGMatch) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(syslogngfilter.SelectorMatchExpr)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGMatch.
@@ -3376,6 +4077,26 @@ func (in *SyslogNGMatch) DeepCopy() *SyslogNGMatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyslogNGOpenTelemetrySource) DeepCopyInto(out *SyslogNGOpenTelemetrySource) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(SyslogNGSourceTLS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGOpenTelemetrySource.
+func (in *SyslogNGOpenTelemetrySource) DeepCopy() *SyslogNGOpenTelemetrySource {
+	if in == nil {
+		return nil
+	}
+	out := new(SyslogNGOpenTelemetrySource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyslogNGOutput) DeepCopyInto(out *SyslogNGOutput) {
 	*out = *in
@@ -3523,6 +4244,16 @@ func (in *SyslogNGOutputSpec) DeepCopyInto(out *SyslogNGOutputSpec) {
 		*out = new(syslogngoutput.OpenTelemetryOutput)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ClickHouse != nil {
+		in, out := &in.ClickHouse, &out.ClickHouse
+		*out = new(syslogngoutput.ClickHouseOutput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(syslogngoutput.KafkaOutput)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGOutputSpec.
@@ -3548,6 +4279,13 @@ func (in *SyslogNGOutputStatus) DeepCopyInto(out *SyslogNGOutputStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGOutputStatus.
@@ -3560,6 +4298,51 @@ func (in *SyslogNGOutputStatus) DeepCopy() *SyslogNGOutputStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyslogNGSource) DeepCopyInto(out *SyslogNGSource) {
+	*out = *in
+	if in.Syslog != nil {
+		in, out := &in.Syslog, &out.Syslog
+		*out = new(SyslogNGSyslogSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenTelemetry != nil {
+		in, out := &in.OpenTelemetry, &out.OpenTelemetry
+		*out = new(SyslogNGOpenTelemetrySource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(SyslogNGHTTPSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGSource.
+func (in *SyslogNGSource) DeepCopy() *SyslogNGSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SyslogNGSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyslogNGSourceTLS) DeepCopyInto(out *SyslogNGSourceTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGSourceTLS.
+func (in *SyslogNGSourceTLS) DeepCopy() *SyslogNGSourceTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(SyslogNGSourceTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyslogNGSpec) DeepCopyInto(out *SyslogNGSpec) {
 	*out = *in
@@ -3631,6 +4414,11 @@ func (in *SyslogNGSpec) DeepCopyInto(out *SyslogNGSpec) {
 		*out = new(v1.Probe)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BufferLimits != nil {
+		in, out := &in.BufferLimits, &out.BufferLimits
+		*out = new(BufferLimits)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.GlobalOptions != nil {
 		in, out := &in.GlobalOptions, &out.GlobalOptions
 		*out = new(GlobalOptions)
@@ -3653,6 +4441,18 @@ func (in *SyslogNGSpec) DeepCopyInto(out *SyslogNGSpec) {
 		*out = new(ConfigCheck)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConfigRollout != nil {
+		in, out := &in.ConfigRollout, &out.ConfigRollout
+		*out = new(ConfigRollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]SyslogNGSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGSpec.
@@ -3665,6 +4465,31 @@ func (in *SyslogNGSpec) DeepCopy() *SyslogNGSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyslogNGSyslogSource) DeepCopyInto(out *SyslogNGSyslogSource) {
+	*out = *in
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(SyslogNGSourceTLS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogNGSyslogSource.
+func (in *SyslogNGSyslogSource) DeepCopy() *SyslogNGSyslogSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SyslogNGSyslogSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyslogNGTLS) DeepCopyInto(out *SyslogNGTLS) {
 	*out = *in
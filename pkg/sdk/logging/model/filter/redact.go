@@ -0,0 +1,192 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/secret"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/redact"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/ruby"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+// +name:"Redact"
+// +weight:"200"
+type _hugoRedact interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+// +docName:"Redact"
+/*
+Redact sensitive data in the fields of the records.
+
+Built-in detectors:
+
+- `email`: email addresses
+- `credit_card`: credit card numbers, validated with the Luhn algorithm
+- `jwt`: JSON Web Tokens
+- `bearer_token`: bearer tokens of HTTP authorization headers
+- `aws_access_key`: AWS access key IDs
+- `ipv4`, `ipv6`: IP addresses
+
+The matching data is replaced with a mask, replaced with its salted SHA-256 hash, or dropped.
+The filter is rendered as a `record_modifier` filter.
+*/
+type _docRedact interface{} //nolint:deadcode,unused
+
+// +name:"Redact"
+// +url:"https://github.com/repeatedly/fluent-plugin-record-modifier"
+// +version:"2.1.0"
+// +description:"Redact sensitive data in the records"
+// +status:"Testing"
+type _metaRedact interface{} //nolint:deadcode,unused
+
+// redactKey holds the result of the redaction, removed from the record after it has been redacted
+const redactKey = "_redact"
+
+// +kubebuilder:object:generate=true
+type Redact struct {
+	// Top-level keys of the record to redact (default: log, message)
+	Keys []string `json:"keys,omitempty"`
+	// Built-in detectors to use: email, credit_card, jwt, bearer_token, aws_access_key, ipv4, ipv6
+	Detectors []string `json:"detectors,omitempty"`
+	// Additional regular expressions (Ruby syntax) of the sensitive data
+	Patterns []string `json:"patterns,omitempty"`
+	// What to do with the sensitive data: mask, hash or drop (default: mask)
+	// +kubebuilder:validation:Enum=mask;hash;drop
+	Action string `json:"action,omitempty"`
+	// Redact only the matching substrings, or the whole field if any part of it matches: substring or field (default: substring).
+	// Dropping a field removes it from the record.
+	// +kubebuilder:validation:Enum=substring;field
+	Scope string `json:"scope,omitempty"`
+	// Replacement of the sensitive data for the mask action (default: [REDACTED])
+	Mask string `json:"mask,omitempty"`
+	// Salt prepended to the sensitive data before hashing it. It is rendered into the configuration, so it can't be mounted with `mountFrom`.
+	// +docLink:"Secret,../secret/"
+	HashSalt *secret.Secret `json:"hash_salt,omitempty"`
+}
+
+//
+/*
+## Example `Redact` filter configurations
+
+{{< highlight yaml >}}
+apiVersion: logging.banzaicloud.io/v1beta1
+kind: Flow
+metadata:
+  name: demo-flow
+spec:
+  filters:
+    - redact:
+        keys: ["message"]
+        detectors: ["email", "credit_card"]
+        patterns: ["customer-\\d+"]
+        action: hash
+        hash_salt:
+          valueFrom:
+            secretKeyRef:
+              name: redact
+              key: salt
+  selectors: {}
+  localOutputRefs:
+    - demo-output
+{{</ highlight >}}
+*/
+type _expRedact interface{} //nolint:deadcode,unused
+
+func (r *Redact) ToDirective(secretLoader secret.SecretLoader, id string) (types.Directive, error) {
+	if err := redact.Validate(r.Detectors, r.Patterns, r.Action, r.Scope); err != nil {
+		return nil, errors.WrapIf(err, "invalid redact filter")
+	}
+	salt, err := redact.LoadHashSalt(r.HashSalt, secretLoader)
+	if err != nil {
+		return nil, err
+	}
+	code, err := r.rubyCode(salt)
+	if err != nil {
+		return nil, err
+	}
+	modifier := &RecordModifier{
+		PrepareValues: code,
+		Records:       []Record{{redactKey: "${@redact.call(record)}"}},
+		RemoveKeys:    redactKey,
+	}
+	return modifier.ToDirective(secretLoader, id)
+}
+
+// rubyCode returns the code preparing the @redact lambda, which redacts the keys of the record in place.
+// The code is a single line without comment characters, so it can be used as a parameter value.
+func (r *Redact) rubyCode(salt string) (string, error) {
+	var patterns []string
+	for _, d := range r.Detectors {
+		pattern, err := redact.Pattern(d)
+		if err != nil {
+			return "", err
+		}
+		check := "nil"
+		if d == redact.CreditCard {
+			check = "@redact_luhn"
+		}
+		patterns = append(patterns, fmt.Sprintf("[Regexp.new(%s), %s]", ruby.Quote(pattern), check))
+	}
+	for _, p := range r.Patterns {
+		patterns = append(patterns, fmt.Sprintf("[Regexp.new(%s), nil]", ruby.Quote(p)))
+	}
+
+	keys := r.Keys
+	if len(keys) == 0 {
+		keys = []string{"log", "message"}
+	}
+	var quotedKeys []string
+	for _, k := range keys {
+		quotedKeys = append(quotedKeys, ruby.Quote(k))
+	}
+
+	mask := r.Mask
+	if mask == "" {
+		mask = redact.DefaultMask
+	}
+	var replace string
+	switch r.Action {
+	case redact.ActionHash:
+		replace = fmt.Sprintf("Digest::SHA256.hexdigest(%s + m)", ruby.Quote(salt))
+	case redact.ActionDrop:
+		replace = `""`
+	default:
+		replace = ruby.Quote(mask)
+	}
+
+	var body string
+	switch {
+	case r.Scope == redact.ScopeField && r.Action == redact.ActionDrop:
+		body = "found = false; @redact_patterns.each do |re, check| v.gsub(re) do |m| found ||= check.nil? || check.call(m); m end end; record.delete(key) if found"
+	case r.Scope == redact.ScopeField:
+		body = "found = false; @redact_patterns.each do |re, check| v.gsub(re) do |m| found ||= check.nil? || check.call(m); m end end; record[key] = @redact_replace.call(v) if found"
+	default:
+		body = "@redact_patterns.each do |re, check| v = v.gsub(re) do |m| check.nil? || check.call(m) ? @redact_replace.call(m) : m end end; record[key] = v"
+	}
+
+	return strings.Join([]string{
+		"require 'digest'",
+		"@redact_luhn = lambda do |m| d = m.delete('^0-9').chars.map(&:to_i).reverse; t = 0; d.each_with_index do |x, i| x *= 2 if i.odd?; t += x > 9 ? x - 9 : x end; t % 10 == 0 end",
+		fmt.Sprintf("@redact_patterns = [%s]", strings.Join(patterns, ", ")),
+		fmt.Sprintf("@redact_keys = [%s]", strings.Join(quotedKeys, ", ")),
+		fmt.Sprintf("@redact_replace = lambda do |m| %s end", replace),
+		fmt.Sprintf("@redact = lambda do |record| @redact_keys.each do |key| v = record[key]; next unless v.is_a?(String); %s end; nil end", body),
+	}, "; "), nil
+}
@@ -0,0 +1,66 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/filter"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/render"
+)
+
+func TestRedact(t *testing.T) {
+	CONFIG := []byte(`
+keys: ["message"]
+detectors: ["credit_card"]
+patterns: ["id-#\\d+"]
+scope: field
+action: drop
+`)
+	expected := `
+<filter **>
+  @type record_modifier
+  @id test
+  prepare_value require 'digest'; @redact_luhn = lambda do |m| d = m.delete('^0-9').chars.map(&:to_i).reverse; t = 0; d.each_with_index do |x, i| x *= 2 if i.odd?; t += x > 9 ? x - 9 : x end; t % 10 == 0 end; @redact_patterns = [[Regexp.new("\\b(?:\\d[ -]?){12,18}\\d\\b"), @redact_luhn], [Regexp.new("id-\x23\\d+"), nil]]; @redact_keys = ["message"]; @redact_replace = lambda do |m| "" end; @redact = lambda do |record| @redact_keys.each do |key| v = record[key]; next unless v.is_a?(String); found = false; @redact_patterns.each do |re, check| v.gsub(re) do |m| found ||= check.nil? || check.call(m); m end end; record.delete(key) if found end; nil end
+  remove_keys _redact
+  <record>
+    _redact ${@redact.call(record)}
+  </record>
+</filter>
+`
+	parser := &filter.Redact{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, parser))
+	test := render.NewOutputPluginTest(t, parser)
+	test.DiffResult(expected)
+}
+
+func TestRedactMountedHashSalt(t *testing.T) {
+	CONFIG := []byte(`
+detectors: ["email"]
+action: hash
+hash_salt:
+  mountFrom:
+    secretKeyRef:
+      name: redact
+      key: salt
+`)
+	parser := &filter.Redact{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, parser))
+	_, err := parser.ToDirective(nil, "test")
+	require.EqualError(t, err, "hash_salt can't be mounted from a secret, use value or valueFrom instead")
+}
@@ -0,0 +1,188 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/secret"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/ruby"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/sample"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+// +name:"Sample"
+// +weight:"200"
+type _hugoSample interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+// +docName:"Sample"
+/*
+Keep a representative sample of the records.
+
+- Without a `key`, every record is kept with the given percentage.
+- With a `key`, the decision is consistent for the value of the key, so for example every line of a trace is either kept or dropped together.
+  Records without the key are sampled randomly.
+- Records matching any of the `always_keep` rules are never sampled out.
+
+The decisions are counted by the `fluentd_sampled_records_total` Prometheus counter with the `decision` (`kept` or `dropped`), `namespace` and `sampler` labels.
+
+The filter is rendered as `record_modifier`, `prometheus` and `grep` filters.
+*/
+type _docSample interface{} //nolint:deadcode,unused
+
+// +name:"Sample"
+// +url:"https://github.com/repeatedly/fluent-plugin-record-modifier"
+// +version:"2.1.0"
+// +description:"Keep a representative sample of the records"
+// +status:"Testing"
+type _metaSample interface{} //nolint:deadcode,unused
+
+const (
+	// sampleKey holds the decision of the sampler, removed from the record after the decision has been applied
+	sampleKey = "_sample"
+	// SampledRecordsMetric counts the decisions of the samplers
+	SampledRecordsMetric = "fluentd_sampled_records_total"
+)
+
+// +kubebuilder:object:generate=true
+type Sample struct {
+	// Percentage of the records to keep
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage int `json:"percentage"`
+	// Key of the record to sample consistently, for example `trace_id` or `$.kubernetes.labels.app`
+	Key string `json:"key,omitempty"`
+	// Records matching any of the rules are always kept
+	// +docLink:"Sample Rule,#sample-rule"
+	AlwaysKeep []SampleRule `json:"always_keep,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+type SampleRule struct {
+	// Key of the record, for example `level` or `$.kubernetes.labels.tier`
+	Key string `json:"key"`
+	// Regular expression (Ruby syntax) of the value to keep
+	Pattern string `json:"pattern"`
+}
+
+//
+/*
+## Example `Sample` filter configurations
+
+{{< highlight yaml >}}
+apiVersion: logging.banzaicloud.io/v1beta1
+kind: Flow
+metadata:
+  name: demo-flow
+spec:
+  filters:
+    - sample:
+        percentage: 10
+        key: trace_id
+        always_keep:
+          - key: level
+            pattern: "^(ERROR|FATAL)$"
+  selectors: {}
+  localOutputRefs:
+    - demo-output
+{{</ highlight >}}
+*/
+type _expSample interface{} //nolint:deadcode,unused
+
+// ToDirectives returns the filters deciding, counting and dropping the sampled out records
+func (s *Sample) ToDirectives(secretLoader secret.SecretLoader, id string) ([]types.Directive, error) {
+	if err := s.validate(); err != nil {
+		return nil, errors.WrapIf(err, "invalid sample filter")
+	}
+
+	decide := &RecordModifier{
+		PrepareValues: s.rubyCode(),
+		Records:       []Record{{sampleKey: "${@sample.call(record)}"}},
+	}
+	count := &PrometheusConfig{
+		Metrics: []MetricSection{{
+			Name: SampledRecordsMetric,
+			Type: "counter",
+			Desc: "The total number of records decided by the sampler.",
+		}},
+		Labels: Label{
+			"decision":  "$." + sampleKey,
+			"namespace": "$.kubernetes.namespace_name",
+			"sampler":   id,
+		},
+	}
+	drop := &GrepConfig{
+		Exclude: []ExcludeSection{{Key: sampleKey, Pattern: fmt.Sprintf("/^%s$/", sample.DecisionDropped)}},
+	}
+	cleanup := &RecordModifier{RemoveKeys: sampleKey}
+
+	decideDirective, err := decide.ToDirective(secretLoader, id)
+	if err != nil {
+		return nil, err
+	}
+	countDirective, err := count.ToDirective(secretLoader, id+":metrics")
+	if err != nil {
+		return nil, err
+	}
+	dropDirective, err := drop.ToDirective(secretLoader, id+":drop")
+	if err != nil {
+		return nil, err
+	}
+	cleanupDirective, err := cleanup.ToDirective(secretLoader, id+":cleanup")
+	if err != nil {
+		return nil, err
+	}
+	return []types.Directive{decideDirective, countDirective, dropDirective, cleanupDirective}, nil
+}
+
+func (s *Sample) validate() error {
+	errs := sample.Validate(s.Percentage)
+	for _, rule := range s.AlwaysKeep {
+		if rule.Key == "" {
+			errs = errors.Append(errs, errors.New("always_keep rule without key"))
+		}
+		if rule.Pattern == "" {
+			errs = errors.Append(errs, errors.Errorf("always_keep rule on %q without pattern", rule.Key))
+		}
+	}
+	return errs
+}
+
+// rubyCode returns the code preparing the @sample lambda, which returns the decision for the record.
+// The code is a single line without comment characters, so it can be used as a parameter value.
+func (s *Sample) rubyCode() string {
+	var rules []string
+	for _, rule := range s.AlwaysKeep {
+		rules = append(rules, fmt.Sprintf("[%s, Regexp.new(%s)]", ruby.Path(rule.Key), ruby.Quote(rule.Pattern)))
+	}
+
+	bucket := fmt.Sprintf("rand(%d)", sample.Buckets)
+	if s.Key != "" {
+		bucket = fmt.Sprintf("(v = @sample_dig.call(record, %s)).nil? ? %s : Digest::SHA1.hexdigest(v.to_s)[0, %d].to_i(16)",
+			ruby.Path(s.Key), bucket, sample.BucketDigits)
+	}
+
+	return strings.Join([]string{
+		"require 'digest'",
+		"@sample_dig = lambda do |record, path| path.reduce(record) do |v, k| v.is_a?(Hash) ? v[k] : nil end end",
+		fmt.Sprintf("@sample_keep = [%s]", strings.Join(rules, ", ")),
+		fmt.Sprintf("@sample = lambda do |record| next %q if @sample_keep.any? do |path, re| re.match?(@sample_dig.call(record, path).to_s) end; (%s) < %d ? %q : %q end",
+			sample.DecisionKept, bucket, sample.Threshold(s.Percentage), sample.DecisionKept, sample.DecisionDropped),
+	}, "; ")
+}
@@ -0,0 +1,92 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/andreyvit/diff"
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/filter"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/render"
+)
+
+func TestSample(t *testing.T) {
+	CONFIG := []byte(`
+percentage: 10
+key: $.trace.id
+always_keep:
+  - key: level
+    pattern: ^ERROR$
+`)
+	expected := `
+<filter **>
+  @type record_modifier
+  @id test
+  prepare_value require 'digest'; @sample_dig = lambda do |record, path| path.reduce(record) do |v, k| v.is_a?(Hash) ? v[k] : nil end end; @sample_keep = [[["level"], Regexp.new("^ERROR$")]]; @sample = lambda do |record| next "kept" if @sample_keep.any? do |path, re| re.match?(@sample_dig.call(record, path).to_s) end; ((v = @sample_dig.call(record, ["trace", "id"])).nil? ? rand(65536) : Digest::SHA1.hexdigest(v.to_s)[0, 4].to_i(16)) < 6553 ? "kept" : "dropped" end
+  <record>
+    _sample ${@sample.call(record)}
+  </record>
+</filter>
+<filter **>
+  @type prometheus
+  @id test:metrics
+  <metric>
+    desc The total number of records decided by the sampler.
+    name fluentd_sampled_records_total
+    type counter
+  </metric>
+  <labels>
+    decision $._sample
+    namespace $.kubernetes.namespace_name
+    sampler test
+  </labels>
+</filter>
+<filter **>
+  @type grep
+  @id test:drop
+  <exclude>
+    key _sample
+    pattern /^dropped$/
+  </exclude>
+</filter>
+<filter **>
+  @type record_modifier
+  @id test:cleanup
+  remove_keys _sample
+</filter>
+`
+	sample := &filter.Sample{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, sample))
+	directives, err := sample.ToDirectives(secret.NewSecretLoader(nil, "", "", nil), "test")
+	require.NoError(t, err)
+
+	b := &bytes.Buffer{}
+	require.NoError(t, (&render.FluentRender{Out: b, Indent: 2}).RenderDirectives(directives, 0))
+	if a, e := diff.TrimLinesInString(b.String()), diff.TrimLinesInString(expected); a != e {
+		t.Errorf("Result does not match (-actual vs +expected):\n%v", diff.LineDiff(a, e))
+	}
+}
+
+func TestSampleInvalid(t *testing.T) {
+	sample := &filter.Sample{Percentage: 120, AlwaysKeep: []filter.SampleRule{{Key: "level"}}}
+	_, err := sample.ToDirectives(secret.NewSecretLoader(nil, "", "", nil), "test")
+	assert.EqualError(t, err, `invalid sample filter: percentage should be between 0 and 100, got 120; always_keep rule on "level" without pattern`)
+}
@@ -441,6 +441,41 @@ func (in *RecordTransformer) DeepCopy() *RecordTransformer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Redact) DeepCopyInto(out *Redact) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Detectors != nil {
+		in, out := &in.Detectors, &out.Detectors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HashSalt != nil {
+		in, out := &in.HashSalt, &out.HashSalt
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Redact.
+func (in *Redact) DeepCopy() *Redact {
+	if in == nil {
+		return nil
+	}
+	out := new(Redact)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegexpSection) DeepCopyInto(out *RegexpSection) {
 	*out = *in
@@ -471,6 +506,41 @@ func (in *Replace) DeepCopy() *Replace {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Sample) DeepCopyInto(out *Sample) {
+	*out = *in
+	if in.AlwaysKeep != nil {
+		in, out := &in.AlwaysKeep, &out.AlwaysKeep
+		*out = make([]SampleRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Sample.
+func (in *Sample) DeepCopy() *Sample {
+	if in == nil {
+		return nil
+	}
+	out := new(Sample)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleRule) DeepCopyInto(out *SampleRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleRule.
+func (in *SampleRule) DeepCopy() *SampleRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SingleParseSection) DeepCopyInto(out *SingleParseSection) {
 	*out = *in
@@ -0,0 +1,297 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/secret"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/ruby"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+// +name:"ClickHouse"
+// +weight:"200"
+type _hugoClickHouse interface{} //nolint:deadcode,unused
+
+// +docName:"ClickHouse output plugin for Fluentd"
+/*
+Inserts logs into a [ClickHouse](https://clickhouse.com/) table through the [HTTP interface](https://clickhouse.com/docs/en/interfaces/http) of ClickHouse, using the built-in [http output](https://docs.fluentd.org/output/http) of Fluentd.
+
+Every record becomes a row of the table in the `JSONEachRow` format. The rows are built in a label of their own before the output:
+
+- The event time is written to the `timestamp_column` column.
+- The other columns are filled from the fields of the record listed in `columns`. By default the `message` and the `kubernetes` metadata are mapped to the `message`, `namespace`, `pod`, `container`, `host` and `labels` columns.
+- The fields of the record that are not mapped to a column are dropped.
+
+The size of the inserted batches is controlled by the `chunk_limit_records` and `flush_interval` options of the buffer. Use `async_insert` to let ClickHouse batch small inserts on the server side.
+
+## Example output configurations
+
+```yaml
+spec:
+  clickhouse:
+    url: https://clickhouse.clickhouse.svc:8443
+    database: logs
+    table: kubernetes
+    username:
+      valueFrom:
+        secretKeyRef:
+          name: clickhouse
+          key: username
+    password:
+      valueFrom:
+        secretKeyRef:
+          name: clickhouse
+          key: password
+    columns:
+      message: message
+      namespace: $.kubernetes.namespace_name
+      pod: $.kubernetes.pod_name
+      app: $.kubernetes.labels.app
+    async_insert: true
+    buffer:
+      chunk_limit_records: 10000
+      flush_interval: 5s
+```
+*/
+type _docClickHouse interface{} //nolint:deadcode,unused
+
+// +name:"ClickHouse"
+// +url:"https://clickhouse.com/docs/en/interfaces/http"
+// +version:"more info"
+// +description:"Inserts logs into ClickHouse over its HTTP interface"
+// +status:"Testing"
+type _metaClickHouse interface{} //nolint:deadcode,unused
+
+const (
+	clickHouseDefaultDatabase        = "default"
+	clickHouseDefaultTimestampColumn = "timestamp"
+)
+
+// DefaultClickHouseColumns maps the message and the kubernetes metadata of the records to columns
+var DefaultClickHouseColumns = map[string]string{
+	"message":   "message",
+	"namespace": "$.kubernetes.namespace_name",
+	"pod":       "$.kubernetes.pod_name",
+	"container": "$.kubernetes.container_name",
+	"host":      "$.kubernetes.host",
+	"labels":    "$.kubernetes.labels",
+}
+
+var clickHouseIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// +kubebuilder:object:generate=true
+// +docName:"ClickHouse"
+type ClickHouseOutputConfig struct {
+	// URL of the HTTP interface of ClickHouse, for example `http://clickhouse:8123`
+	URL string `json:"url" plugin:"hidden"`
+	// Database of the table. (default: default)
+	Database string `json:"database,omitempty" plugin:"hidden"`
+	// Table the logs are inserted into
+	Table string `json:"table" plugin:"hidden"`
+	// Column of the event time, formatted as an ISO 8601 timestamp with nanoseconds. (default: timestamp)
+	TimestampColumn string `json:"timestamp_column,omitempty" plugin:"hidden"`
+	// Fields of the record by column name. The value is a top-level field of the record or a record accessor, for example `$.kubernetes.labels.app`.
+	// Nested fields keep their type, so a map like `$.kubernetes.labels` can be inserted into a `Map(String, String)` column.
+	// Replaces the default mapping of the message and the kubernetes metadata.
+	Columns map[string]string `json:"columns,omitempty" plugin:"hidden"`
+	// Username of the ClickHouse user
+	// +docLink:"Secret,../secret/"
+	Username *secret.Secret `json:"username,omitempty" plugin:"hidden"`
+	// Password of the ClickHouse user
+	// +docLink:"Secret,../secret/"
+	Password *secret.Secret `json:"password,omitempty" plugin:"hidden"`
+	// Let ClickHouse collect the inserted rows in a buffer and write them to the table in batches. (default: false)
+	AsyncInsert *bool `json:"async_insert,omitempty" plugin:"hidden"`
+	// Wait until the rows of an asynchronous insert are written to the table before responding. (default: true)
+	WaitForAsyncInsert *bool `json:"wait_for_async_insert,omitempty" plugin:"hidden"`
+	// Additional ClickHouse settings of the insert query, for example `async_insert_busy_timeout_ms`.
+	// The `date_time_input_format` setting is `best_effort` by default to accept the timestamps.
+	Settings map[string]string `json:"settings,omitempty" plugin:"hidden"`
+	// The option to compress HTTP request body. [text,gzip] (default: text)
+	Compress string `json:"compress,omitempty"`
+	// Connection open timeout in seconds.
+	OpenTimeout int `json:"open_timeout,omitempty"`
+	// Read timeout in seconds.
+	ReadTimeout int `json:"read_timeout,omitempty"`
+	// Try to reuse connection. This will improve performance. (default: false)
+	ReuseConnections bool `json:"reuse_connections,omitempty"`
+	// The CA certificate path for TLS.
+	TlsCACertPath *secret.Secret `json:"tls_ca_cert_path,omitempty"`
+	// The client certificate path for TLS.
+	TlsClientCertPath *secret.Secret `json:"tls_client_cert_path,omitempty"`
+	// The client private key path for TLS.
+	TlsPrivateKeyPath *secret.Secret `json:"tls_private_key_path,omitempty"`
+	// The verify mode of TLS. [peer, none] (default: peer)
+	TlsVerifyMode string `json:"tls_verify_mode,omitempty"`
+	// List of retryable response codes. If the response code is included in this list, the plugin retries the buffer flush. (default: [503])
+	RetryableResponseCodes []int `json:"retryable_response_codes,omitempty"`
+	// +docLink:"Buffer,../buffer/"
+	Buffer *Buffer `json:"buffer,omitempty"`
+	// The threshold for chunk flush performance check.
+	// Parameter type is float, not time, default: 20.0 (seconds)
+	// If chunk flush takes longer time than this threshold, fluentd logs warning message and increases metric fluentd_output_status_slow_flush_count.
+	SlowFlushLogThreshold string `json:"slow_flush_log_threshold,omitempty"`
+}
+
+func (c *ClickHouseOutputConfig) ToDirective(secretLoader secret.SecretLoader, id string) (types.Directive, error) {
+	const pluginType = "http"
+	clickhouse := &types.OutputPlugin{
+		PluginMeta: types.PluginMeta{
+			Type:      pluginType,
+			Directive: "match",
+			Tag:       "**",
+			Id:        id,
+		},
+	}
+	if params, err := types.NewStructToStringMapper(secretLoader).StringsMap(c); err != nil {
+		return nil, err
+	} else {
+		clickhouse.Params = params
+	}
+	endpoint, err := c.endpoint()
+	if err != nil {
+		return nil, err
+	}
+	clickhouse.Params["endpoint"] = endpoint
+
+	if c.Buffer == nil {
+		c.Buffer = &Buffer{}
+	}
+	if buffer, err := c.Buffer.ToDirective(secretLoader, id); err != nil {
+		return nil, err
+	} else {
+		clickhouse.SubDirectives = append(clickhouse.SubDirectives, buffer)
+	}
+	clickhouse.SubDirectives = append(clickhouse.SubDirectives, &types.GenericDirective{
+		PluginMeta: types.PluginMeta{
+			Directive: "format",
+			Type:      "json",
+		},
+	})
+	if c.Username != nil || c.Password != nil {
+		if c.Username == nil || c.Password == nil {
+			return nil, errors.New("username and password must be set together")
+		}
+		auth := &HTTPAuth{Username: c.Username, Password: c.Password}
+		if directive, err := auth.ToDirective(secretLoader, ""); err != nil {
+			return nil, err
+		} else {
+			clickhouse.SubDirectives = append(clickhouse.SubDirectives, directive)
+		}
+	}
+
+	filter, err := c.columnsFilter(id)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewRelabelledOutput("@"+id, []types.Filter{filter}, clickhouse), nil
+}
+
+// endpoint returns the URL of the insert query with its settings
+func (c *ClickHouseOutputConfig) endpoint() (string, error) {
+	if c.URL == "" {
+		return "", errors.New("url is required")
+	}
+	if c.Table == "" {
+		return "", errors.New("table is required")
+	}
+	database := c.Database
+	if database == "" {
+		database = clickHouseDefaultDatabase
+	}
+	for _, identifier := range []string{database, c.Table} {
+		if !clickHouseIdentifier.MatchString(identifier) {
+			return "", errors.Errorf("invalid database or table name %q", identifier)
+		}
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return "", errors.WrapIf(err, "invalid url")
+	}
+
+	query := u.Query()
+	query.Set("date_time_input_format", "best_effort")
+	for name, value := range c.Settings {
+		query.Set(name, value)
+	}
+	if c.AsyncInsert != nil {
+		query.Set("async_insert", clickHouseBool(*c.AsyncInsert))
+	}
+	if c.WaitForAsyncInsert != nil {
+		query.Set("wait_for_async_insert", clickHouseBool(*c.WaitForAsyncInsert))
+	}
+	query.Set("query", fmt.Sprintf("INSERT INTO %s.%s FORMAT JSONEachRow", database, c.Table))
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// columnsFilter returns the filter replacing every record with the row of the record
+func (c *ClickHouseOutputConfig) columnsFilter(id string) (types.Filter, error) {
+	timestampColumn := c.TimestampColumn
+	if timestampColumn == "" {
+		timestampColumn = clickHouseDefaultTimestampColumn
+	}
+	columns := c.Columns
+	if len(columns) == 0 {
+		columns = DefaultClickHouseColumns
+	}
+
+	record := map[string]string{
+		timestampColumn: "${time.getutc.iso8601(9)}",
+	}
+	for column, field := range columns {
+		if !clickHouseIdentifier.MatchString(column) {
+			return nil, errors.Errorf("invalid column name %q", column)
+		}
+		if column == timestampColumn {
+			return nil, errors.Errorf("column %q is already used for the timestamp", column)
+		}
+		record[column] = fmt.Sprintf("${record.dig(*%s)}", ruby.Path(field))
+	}
+
+	return &types.GenericDirective{
+		PluginMeta: types.PluginMeta{
+			Type:      "record_transformer",
+			Directive: "filter",
+			Tag:       "**",
+			Id:        id + ":convert",
+		},
+		Params: map[string]string{
+			// the values of nested fields are kept as they are instead of their string form
+			"enable_ruby":   "true",
+			"auto_typecast": "true",
+			"renew_record":  "true",
+		},
+		SubDirectives: []types.Directive{
+			&types.GenericDirective{
+				PluginMeta: types.PluginMeta{Directive: "record"},
+				Params:     record,
+			},
+		},
+	}, nil
+}
+
+func clickHouseBool(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,122 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output_test
+
+import (
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/output"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/render"
+)
+
+func TestClickHouseOutputConfig(t *testing.T) {
+	CONFIG := []byte(`
+url: https://clickhouse:8443
+database: logs
+table: kubernetes
+columns:
+  message: message
+  namespace: $.kubernetes.namespace_name
+  app: $.kubernetes.labels.app
+username:
+  value: logger
+password:
+  value: secret
+async_insert: true
+wait_for_async_insert: false
+settings:
+  async_insert_busy_timeout_ms: "1000"
+tls_verify_mode: none
+buffer:
+  chunk_limit_records: 1000
+  flush_interval: 5s
+`)
+	expected := `
+  <match **>
+    @type relabel
+    @id test:relabel
+    @label @test
+  </match>
+</label>
+<label @test>
+  <filter **>
+    @type record_transformer
+    @id test:convert
+    auto_typecast true
+    enable_ruby true
+    renew_record true
+    <record>
+      app ${record.dig(*["kubernetes", "labels", "app"])}
+      message ${record.dig(*["message"])}
+      namespace ${record.dig(*["kubernetes", "namespace_name"])}
+      timestamp ${time.getutc.iso8601(9)}
+    </record>
+  </filter>
+  <match **>
+    @type http
+    @id test
+    endpoint https://clickhouse:8443?async_insert=1&async_insert_busy_timeout_ms=1000&date_time_input_format=best_effort&query=INSERT+INTO+logs.kubernetes+FORMAT+JSONEachRow&wait_for_async_insert=0
+    tls_verify_mode none
+    <buffer tag,time>
+      @type file
+      chunk_limit_records 1000
+      flush_interval 5s
+      path /buffers/test.*.buffer
+      retry_forever true
+      timekey 10m
+      timekey_wait 1m
+    </buffer>
+    <format>
+      @type json
+    </format>
+    <auth>
+      password secret
+      username logger
+    </auth>
+  </match>
+`
+	clickhouse := &output.ClickHouseOutputConfig{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, clickhouse))
+	test := render.NewOutputPluginTest(t, clickhouse)
+	test.DiffResult(expected)
+}
+
+func TestClickHouseOutputConfigProblems(t *testing.T) {
+	secretLoader := secret.NewSecretLoader(nil, "", "", nil)
+
+	_, err := (&output.ClickHouseOutputConfig{URL: "http://clickhouse:8123"}).ToDirective(secretLoader, "test")
+	require.EqualError(t, err, "table is required")
+
+	_, err = (&output.ClickHouseOutputConfig{URL: "http://clickhouse:8123", Table: "logs; DROP TABLE logs"}).ToDirective(secretLoader, "test")
+	require.EqualError(t, err, `invalid database or table name "logs; DROP TABLE logs"`)
+
+	_, err = (&output.ClickHouseOutputConfig{
+		URL:     "http://clickhouse:8123",
+		Table:   "logs",
+		Columns: map[string]string{"timestamp": "time"},
+	}).ToDirective(secretLoader, "test")
+	require.EqualError(t, err, `column "timestamp" is already used for the timestamp`)
+
+	_, err = (&output.ClickHouseOutputConfig{
+		URL:      "http://clickhouse:8123",
+		Table:    "logs",
+		Username: &secret.Secret{Value: "logger"},
+	}).ToDirective(secretLoader, "test")
+	require.EqualError(t, err, "username and password must be set together")
+}
@@ -0,0 +1,322 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/secret"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/ruby"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/types"
+)
+
+// +name:"OpenTelemetry"
+// +weight:"200"
+type _hugoOpenTelemetry interface{} //nolint:deadcode,unused
+
+// +docName:"OpenTelemetry output plugin for Fluentd"
+/*
+Sends logs to an OpenTelemetry collector over OTLP/gRPC or OTLP/HTTP. For details, see [https://github.com/fluent/fluent-plugin-opentelemetry](https://github.com/fluent/fluent-plugin-opentelemetry).
+
+The plugin sends records in the OTLP format, so the records are converted before the output in a label of their own:
+
+- The fields of the records listed in `resource_attributes` become the resource attributes of the log. By default the `kubernetes` metadata is mapped to the `k8s.*` semantic conventions.
+- The `body_key` field (`message` by default) becomes the body, or the whole record if the field is missing.
+- The other top-level fields, except `kubernetes`, become the attributes of the log.
+
+## Example output configurations
+
+```yaml
+spec:
+  opentelemetry:
+    grpc:
+      endpoint: otel-gateway.observability.svc:4317
+    tls:
+      ca_path:
+        mountFrom:
+          secretKeyRef:
+            name: otel-gateway-tls
+            key: ca.crt
+    headers:
+    - name: Authorization
+      value:
+        valueFrom:
+          secretKeyRef:
+            name: otel-gateway-auth
+            key: token
+    resource_attributes:
+      k8s.namespace.name: $.kubernetes.namespace_name
+      k8s.pod.name: $.kubernetes.pod_name
+      service.name: $.kubernetes.labels.app
+    buffer:
+      flush_interval: 10s
+```
+*/
+type _docOpenTelemetry interface{} //nolint:deadcode,unused
+
+// +name:"OpenTelemetry"
+// +url:"https://github.com/fluent/fluent-plugin-opentelemetry"
+// +version:"more info"
+// +description:"Sends logs to an OpenTelemetry collector over OTLP"
+// +status:"Testing"
+type _metaOpenTelemetry interface{} //nolint:deadcode,unused
+
+const (
+	otlpLogsRecordType = "opentelemetry_logs"
+	otlpScopeName      = "logging-operator"
+)
+
+// DefaultOpenTelemetryResourceAttributes maps the kubernetes metadata of the records to the OpenTelemetry semantic conventions
+var DefaultOpenTelemetryResourceAttributes = map[string]string{
+	"k8s.namespace.name":   "$.kubernetes.namespace_name",
+	"k8s.pod.name":         "$.kubernetes.pod_name",
+	"k8s.pod.uid":          "$.kubernetes.pod_id",
+	"k8s.container.name":   "$.kubernetes.container_name",
+	"k8s.node.name":        "$.kubernetes.host",
+	"container.image.name": "$.kubernetes.container_image",
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"OpenTelemetry"
+type OpenTelemetryOutputConfig struct {
+	// OTLP/HTTP endpoint, exactly one of `http` and `grpc` must be set
+	// +docLink:"OTLP/HTTP,#otlp-http"
+	HTTP *OpenTelemetryHTTP `json:"http,omitempty"`
+	// OTLP/gRPC endpoint, exactly one of `http` and `grpc` must be set
+	// +docLink:"OTLP/gRPC,#otlp-grpc"
+	GRPC *OpenTelemetryGRPC `json:"grpc,omitempty"`
+	// TLS settings of the connection
+	// +docLink:"TLS,#tls"
+	TLS *OpenTelemetryTLS `json:"tls,omitempty"`
+	// Headers sent with every request, for example an authorization token
+	// +docLink:"Header,#header"
+	Headers []OpenTelemetryHeader `json:"headers,omitempty"`
+	// The option to compress the requests. [text, gzip] (default: text)
+	Compress string `json:"compress,omitempty"`
+	// Resource attributes of the logs by attribute name. The value is a top-level field of the record or a record accessor, for example `$.kubernetes.labels.app`.
+	// Replaces the default mapping of the kubernetes metadata.
+	ResourceAttributes map[string]string `json:"resource_attributes,omitempty" plugin:"hidden"`
+	// Field of the record sent as the body of the log (default: message)
+	BodyKey string `json:"body_key,omitempty" plugin:"hidden"`
+	// +docLink:"Buffer,../buffer/"
+	Buffer *Buffer `json:"buffer,omitempty"`
+	// The threshold for chunk flush performance check.
+	// Parameter type is float, not time, default: 20.0 (seconds)
+	// If chunk flush takes longer time than this threshold, fluentd logs warning message and increases metric fluentd_output_status_slow_flush_count.
+	SlowFlushLogThreshold string `json:"slow_flush_log_threshold,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"OTLP/HTTP"
+type OpenTelemetryHTTP struct {
+	// Base URL of the OTLP/HTTP receiver, for example `https://otel-collector:4318`
+	Endpoint string `json:"endpoint"`
+	// Proxy for the requests
+	Proxy string `json:"proxy,omitempty"`
+	// Raise UnrecoverableError when the response code is not retryable. (default: true)
+	ErrorResponseAsUnrecoverable *bool `json:"error_response_as_unrecoverable,omitempty"`
+	// Response codes the flush is retried for. (default: [429, 502, 503, 504])
+	RetryableResponseCodes []int `json:"retryable_response_codes,omitempty"`
+	// Timeout of reading the response in seconds. (default: 60)
+	ReadTimeout int `json:"read_timeout,omitempty"`
+	// Timeout of writing the request in seconds. (default: 60)
+	WriteTimeout int `json:"write_timeout,omitempty"`
+	// Timeout of opening the connection in seconds.
+	ConnectTimeout int `json:"connect_timeout,omitempty"`
+}
+
+func (h *OpenTelemetryHTTP) ToDirective(secretLoader secret.SecretLoader, id string) (types.Directive, error) {
+	return types.NewFlatDirective(types.PluginMeta{
+		Directive: "http",
+	}, h, secretLoader)
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"OTLP/gRPC"
+type OpenTelemetryGRPC struct {
+	// Address of the OTLP/gRPC receiver, for example `otel-collector:4317`
+	Endpoint string `json:"endpoint"`
+	// Interval of the keepalive pings in seconds.
+	KeepaliveTime int `json:"keepalive_time,omitempty"`
+	// Timeout of the keepalive pings in seconds.
+	KeepaliveTimeout int `json:"keepalive_timeout,omitempty"`
+}
+
+func (g *OpenTelemetryGRPC) ToDirective(secretLoader secret.SecretLoader, id string) (types.Directive, error) {
+	return types.NewFlatDirective(types.PluginMeta{
+		Directive: "grpc",
+	}, g, secretLoader)
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"TLS"
+type OpenTelemetryTLS struct {
+	// Skip the verification of the certificate of the server. (default: false)
+	Insecure *bool `json:"insecure,omitempty"`
+	// The CA certificate path for TLS.
+	// +docLink:"Secret,../secret/"
+	CAPath *secret.Secret `json:"ca_path,omitempty"`
+	// The client certificate path for TLS.
+	// +docLink:"Secret,../secret/"
+	CertPath *secret.Secret `json:"cert_path,omitempty"`
+	// The client private key path for TLS.
+	// +docLink:"Secret,../secret/"
+	PrivateKeyPath *secret.Secret `json:"private_key_path,omitempty"`
+	// The minimum TLS version. [TLS1_1, TLS1_2, TLS1_3]
+	MinVersion string `json:"min_version,omitempty"`
+	// The maximum TLS version. [TLS1_1, TLS1_2, TLS1_3]
+	MaxVersion string `json:"max_version,omitempty"`
+}
+
+func (t *OpenTelemetryTLS) ToDirective(secretLoader secret.SecretLoader, id string) (types.Directive, error) {
+	return types.NewFlatDirective(types.PluginMeta{
+		Directive: "transport",
+		Tag:       "tls",
+	}, t, secretLoader)
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"Header"
+type OpenTelemetryHeader struct {
+	// Name of the header
+	Name string `json:"name"`
+	// Value of the header
+	// +docLink:"Secret,../secret/"
+	Value *secret.Secret `json:"value"`
+}
+
+func (c *OpenTelemetryOutputConfig) ToDirective(secretLoader secret.SecretLoader, id string) (types.Directive, error) {
+	const pluginType = "opentelemetry"
+	otlp := &types.OutputPlugin{
+		PluginMeta: types.PluginMeta{
+			Type:      pluginType,
+			Directive: "match",
+			Tag:       "**",
+			Id:        id,
+		},
+	}
+	if (c.HTTP == nil) == (c.GRPC == nil) {
+		return nil, errors.New("exactly one of http and grpc must be set")
+	}
+	if params, err := types.NewStructToStringMapper(secretLoader).StringsMap(c); err != nil {
+		return nil, err
+	} else {
+		otlp.Params = params
+	}
+	if len(c.Headers) > 0 {
+		headers := make(map[string]string, len(c.Headers))
+		for _, h := range c.Headers {
+			value, err := secretLoader.Load(h.Value)
+			if err != nil {
+				return nil, errors.WrapIff(err, "failed to load header %q", h.Name)
+			}
+			headers[h.Name] = value
+		}
+		b, err := json.Marshal(headers)
+		if err != nil {
+			return nil, err
+		}
+		otlp.Params["headers"] = string(b)
+	}
+	var sections []interface {
+		ToDirective(secret.SecretLoader, string) (types.Directive, error)
+	}
+	if c.HTTP != nil {
+		sections = append(sections, c.HTTP)
+	}
+	if c.GRPC != nil {
+		sections = append(sections, c.GRPC)
+	}
+	if c.TLS != nil {
+		sections = append(sections, c.TLS)
+	}
+	for _, section := range sections {
+		if directive, err := section.ToDirective(secretLoader, ""); err != nil {
+			return nil, err
+		} else {
+			otlp.SubDirectives = append(otlp.SubDirectives, directive)
+		}
+	}
+	if c.Buffer == nil {
+		c.Buffer = &Buffer{}
+	}
+	if buffer, err := c.Buffer.ToDirective(secretLoader, id); err != nil {
+		return nil, err
+	} else {
+		otlp.SubDirectives = append(otlp.SubDirectives, buffer)
+	}
+
+	return types.NewRelabelledOutput("@"+id, []types.Filter{c.conversionFilter(id)}, otlp), nil
+}
+
+// conversionFilter returns the filter replacing every record with the OTLP logs request of the record
+func (c *OpenTelemetryOutputConfig) conversionFilter(id string) types.Filter {
+	attributes := c.ResourceAttributes
+	if len(attributes) == 0 {
+		attributes = DefaultOpenTelemetryResourceAttributes
+	}
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	resource := make([]string, 0, len(names))
+	for _, name := range names {
+		resource = append(resource, fmt.Sprintf("[%s, @otlp_dig.call(record, %s)]", ruby.Quote(name), ruby.Path(attributes[name])))
+	}
+	bodyKey := c.BodyKey
+	if bodyKey == "" {
+		bodyKey = "message"
+	}
+
+	prepare := strings.Join([]string{
+		"require 'json'",
+		"@otlp_dig = lambda do |record, path| path.reduce(record) do |v, k| v.is_a?(Hash) ? v[k] : nil end end",
+		"@otlp_value = lambda do |v| Hash['stringValue', v.is_a?(String) ? v : v.to_json] end",
+		"@otlp_attributes = lambda do |pairs| pairs.reject do |_, v| v.nil? end.map do |k, v| Hash['key', k, 'value', @otlp_value.call(v)] end end",
+		fmt.Sprintf("@otlp_logs = lambda do |record, time| body = record.fetch(%[1]s, record); attributes = record.reject do |k, _| [\"kubernetes\", %[1]s].include?(k) end; "+
+			"log = Hash['timeUnixNano', (time.to_r * 1000000000).to_i.to_s, 'body', @otlp_value.call(body), 'attributes', @otlp_attributes.call(attributes)]; "+
+			"JSON.generate(Hash['resourceLogs', [Hash['resource', Hash['attributes', @otlp_attributes.call([%[2]s])], 'scopeLogs', [Hash['scope', Hash['name', %[3]s], 'logRecords', [log]]]]]]) end",
+			ruby.Quote(bodyKey), strings.Join(resource, ", "), ruby.Quote(otlpScopeName)),
+	}, "; ")
+
+	return &types.GenericDirective{
+		PluginMeta: types.PluginMeta{
+			Type:      "record_modifier",
+			Directive: "filter",
+			Tag:       "**",
+			Id:        id + ":convert",
+		},
+		Params: map[string]string{
+			"prepare_value":  prepare,
+			"whitelist_keys": "message,type",
+		},
+		SubDirectives: []types.Directive{
+			&types.GenericDirective{
+				PluginMeta: types.PluginMeta{Directive: "record"},
+				Params: map[string]string{
+					// the message is set first, while the original fields are still available
+					"message": "${@otlp_logs.call(record, time)}",
+					"type":    otlpLogsRecordType,
+				},
+			},
+		},
+	}
+}
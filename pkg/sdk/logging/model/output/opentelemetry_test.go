@@ -0,0 +1,98 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output_test
+
+import (
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/output"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/render"
+)
+
+func TestOpenTelemetryOutputConfig(t *testing.T) {
+	CONFIG := []byte(`
+grpc:
+  endpoint: otel-gateway:4317
+tls:
+  insecure: true
+headers:
+- name: Authorization
+  value:
+    value: Bearer token
+compress: gzip
+resource_attributes:
+  k8s.namespace.name: $.kubernetes.namespace_name
+  service.name: $.kubernetes.labels.app
+body_key: log
+buffer:
+  flush_interval: 10s
+`)
+	expected := `
+  <match **>
+    @type relabel
+    @id test:relabel
+    @label @test
+  </match>
+</label>
+<label @test>
+  <filter **>
+    @type record_modifier
+    @id test:convert
+    prepare_value require 'json'; @otlp_dig = lambda do |record, path| path.reduce(record) do |v, k| v.is_a?(Hash) ? v[k] : nil end end; @otlp_value = lambda do |v| Hash['stringValue', v.is_a?(String) ? v : v.to_json] end; @otlp_attributes = lambda do |pairs| pairs.reject do |_, v| v.nil? end.map do |k, v| Hash['key', k, 'value', @otlp_value.call(v)] end end; @otlp_logs = lambda do |record, time| body = record.fetch("log", record); attributes = record.reject do |k, _| ["kubernetes", "log"].include?(k) end; log = Hash['timeUnixNano', (time.to_r * 1000000000).to_i.to_s, 'body', @otlp_value.call(body), 'attributes', @otlp_attributes.call(attributes)]; JSON.generate(Hash['resourceLogs', [Hash['resource', Hash['attributes', @otlp_attributes.call([["k8s.namespace.name", @otlp_dig.call(record, ["kubernetes", "namespace_name"])], ["service.name", @otlp_dig.call(record, ["kubernetes", "labels", "app"])]])], 'scopeLogs', [Hash['scope', Hash['name', "logging-operator"], 'logRecords', [log]]]]]]) end
+    whitelist_keys message,type
+    <record>
+      message ${@otlp_logs.call(record, time)}
+      type opentelemetry_logs
+    </record>
+  </filter>
+  <match **>
+    @type opentelemetry
+    @id test
+    compress gzip
+    headers {"Authorization":"Bearer token"}
+    <grpc>
+      endpoint otel-gateway:4317
+    </grpc>
+    <transport tls>
+      insecure true
+    </transport>
+    <buffer tag,time>
+      @type file
+      flush_interval 10s
+      path /buffers/test.*.buffer
+      retry_forever true
+      timekey 10m
+      timekey_wait 1m
+    </buffer>
+  </match>
+`
+	otlp := &output.OpenTelemetryOutputConfig{}
+	require.NoError(t, yaml.Unmarshal(CONFIG, otlp))
+	test := render.NewOutputPluginTest(t, otlp)
+	test.DiffResult(expected)
+}
+
+func TestOpenTelemetryOutputConfigEndpoint(t *testing.T) {
+	otlp := &output.OpenTelemetryOutputConfig{
+		HTTP: &output.OpenTelemetryHTTP{Endpoint: "http://otel-gateway:4318"},
+		GRPC: &output.OpenTelemetryGRPC{Endpoint: "otel-gateway:4317"},
+	}
+	_, err := otlp.ToDirective(secret.NewSecretLoader(nil, "", "", nil), "test")
+	require.EqualError(t, err, "exactly one of http and grpc must be set")
+}
@@ -118,6 +118,80 @@ func (in *Buffer) DeepCopy() *Buffer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClickHouseOutputConfig) DeepCopyInto(out *ClickHouseOutputConfig) {
+	*out = *in
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AsyncInsert != nil {
+		in, out := &in.AsyncInsert, &out.AsyncInsert
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WaitForAsyncInsert != nil {
+		in, out := &in.WaitForAsyncInsert, &out.WaitForAsyncInsert
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TlsCACertPath != nil {
+		in, out := &in.TlsCACertPath, &out.TlsCACertPath
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TlsClientCertPath != nil {
+		in, out := &in.TlsClientCertPath, &out.TlsClientCertPath
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TlsPrivateKeyPath != nil {
+		in, out := &in.TlsPrivateKeyPath, &out.TlsPrivateKeyPath
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetryableResponseCodes != nil {
+		in, out := &in.RetryableResponseCodes, &out.RetryableResponseCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Buffer != nil {
+		in, out := &in.Buffer, &out.Buffer
+		*out = new(Buffer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClickHouseOutputConfig.
+func (in *ClickHouseOutputConfig) DeepCopy() *ClickHouseOutputConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClickHouseOutputConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudWatchOutput) DeepCopyInto(out *CloudWatchOutput) {
 	*out = *in
@@ -1479,6 +1553,150 @@ func (in *OpenSearchOutput) DeepCopy() *OpenSearchOutput {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetryGRPC) DeepCopyInto(out *OpenTelemetryGRPC) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryGRPC.
+func (in *OpenTelemetryGRPC) DeepCopy() *OpenTelemetryGRPC {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetryGRPC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetryHTTP) DeepCopyInto(out *OpenTelemetryHTTP) {
+	*out = *in
+	if in.ErrorResponseAsUnrecoverable != nil {
+		in, out := &in.ErrorResponseAsUnrecoverable, &out.ErrorResponseAsUnrecoverable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RetryableResponseCodes != nil {
+		in, out := &in.RetryableResponseCodes, &out.RetryableResponseCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryHTTP.
+func (in *OpenTelemetryHTTP) DeepCopy() *OpenTelemetryHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetryHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetryHeader) DeepCopyInto(out *OpenTelemetryHeader) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryHeader.
+func (in *OpenTelemetryHeader) DeepCopy() *OpenTelemetryHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetryHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetryOutputConfig) DeepCopyInto(out *OpenTelemetryOutputConfig) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(OpenTelemetryHTTP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(OpenTelemetryGRPC)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(OpenTelemetryTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]OpenTelemetryHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceAttributes != nil {
+		in, out := &in.ResourceAttributes, &out.ResourceAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Buffer != nil {
+		in, out := &in.Buffer, &out.Buffer
+		*out = new(Buffer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryOutputConfig.
+func (in *OpenTelemetryOutputConfig) DeepCopy() *OpenTelemetryOutputConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetryOutputConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetryTLS) DeepCopyInto(out *OpenTelemetryTLS) {
+	*out = *in
+	if in.Insecure != nil {
+		in, out := &in.Insecure, &out.Insecure
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CAPath != nil {
+		in, out := &in.CAPath, &out.CAPath
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertPath != nil {
+		in, out := &in.CertPath, &out.CertPath
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrivateKeyPath != nil {
+		in, out := &in.PrivateKeyPath, &out.PrivateKeyPath
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryTLS.
+func (in *OpenTelemetryTLS) DeepCopy() *OpenTelemetryTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetryTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisOutputConfig) DeepCopyInto(out *RedisOutputConfig) {
 	*out = *in
@@ -0,0 +1,138 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redact holds the detectors and actions shared by the fluentd and syslog-ng redaction filters
+package redact
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/secret"
+)
+
+const (
+	Email        = "email"
+	CreditCard   = "credit_card"
+	JWT          = "jwt"
+	BearerToken  = "bearer_token"
+	AWSAccessKey = "aws_access_key"
+	IPv4         = "ipv4"
+	IPv6         = "ipv6"
+)
+
+const (
+	// ActionMask replaces the sensitive data with the mask
+	ActionMask = "mask"
+	// ActionHash replaces the sensitive data with its salted SHA-256 hash
+	ActionHash = "hash"
+	// ActionDrop removes the sensitive data
+	ActionDrop = "drop"
+)
+
+const (
+	// ScopeSubstring redacts the matching parts of a field
+	ScopeSubstring = "substring"
+	// ScopeField redacts the whole field if any part of it matches
+	ScopeField = "field"
+)
+
+// DefaultMask replaces the sensitive data if no mask is set
+const DefaultMask = "[REDACTED]"
+
+// detectors are regular expressions with the syntax shared by Ruby and PCRE
+var detectors = map[string]string{
+	Email:        `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`,
+	CreditCard:   `\b(?:\d[ -]?){12,18}\d\b`,
+	JWT:          `\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`,
+	BearerToken:  `(?i)\bbearer\s+[A-Za-z0-9._~+/-]{16,}=*`,
+	AWSAccessKey: `\b(?:AKIA|ASIA)[A-Z0-9]{16}\b`,
+	IPv4:         `\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`,
+	IPv6:         `(?i)(?:\b(?:[0-9a-f]{1,4}:){7}[0-9a-f]{1,4}\b|\b(?:[0-9a-f]{1,4}:){1,6}(?::[0-9a-f]{1,4}){1,6}\b|\b(?:[0-9a-f]{1,4}:){1,7}:|::(?:[0-9a-f]{1,4}:){0,5}[0-9a-f]{1,4}\b)`,
+}
+
+// Detectors returns the names of the built-in detectors
+func Detectors() []string {
+	names := make([]string, 0, len(detectors))
+	for name := range detectors {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Pattern returns the regular expression of a built-in detector
+func Pattern(detector string) (string, error) {
+	pattern, ok := detectors[detector]
+	if !ok {
+		return "", errors.Errorf("unknown detector %q, available detectors: %s", detector, strings.Join(Detectors(), ", "))
+	}
+	return pattern, nil
+}
+
+// Alternation returns a single regular expression that matches any of the patterns
+func Alternation(patterns []string) string {
+	groups := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		groups = append(groups, fmt.Sprintf("(?:%s)", p))
+	}
+	return strings.Join(groups, "|")
+}
+
+// Validate checks the detectors, the action and the scope of a redaction filter
+func Validate(detectorNames []string, patterns []string, action string, scope string) error {
+	var errs error
+	if len(detectorNames)+len(patterns) == 0 {
+		errs = errors.Append(errs, errors.New("at least one detector or pattern is required"))
+	}
+	for _, d := range detectorNames {
+		if _, err := Pattern(d); err != nil {
+			errs = errors.Append(errs, err)
+		}
+	}
+	for _, p := range patterns {
+		if p == "" {
+			errs = errors.Append(errs, errors.New("empty pattern"))
+		}
+	}
+	switch action {
+	case "", ActionMask, ActionHash, ActionDrop:
+	default:
+		errs = errors.Append(errs, errors.Errorf("unknown action %q", action))
+	}
+	switch scope {
+	case "", ScopeSubstring, ScopeField:
+	default:
+		errs = errors.Append(errs, errors.Errorf("unknown scope %q", scope))
+	}
+	return errs
+}
+
+// LoadHashSalt returns the value of the salt, which is rendered into the configuration.
+// A mounted salt is rejected, since the secret loader returns the path of the mounted file instead of its content.
+func LoadHashSalt(salt *secret.Secret, secretLoader secret.SecretLoader) (string, error) {
+	if salt == nil {
+		return "", nil
+	}
+	if salt.MountFrom != nil {
+		return "", errors.New("hash_salt can't be mounted from a secret, use value or valueFrom instead")
+	}
+	value, err := secretLoader.Load(salt)
+	if err != nil {
+		return "", errors.WrapIf(err, "loading hash salt")
+	}
+	return value, nil
+}
@@ -595,6 +595,141 @@ func TestRenderFullFluentConfigWithGlobalFilter(t *testing.T) {
 	}
 }
 
+func TestRenderFullFluentConfigWithQuota(t *testing.T) {
+	system := types.NewSystemBuilder(toDirective(t, input.NewTailInputConfig("input.log")), nil, types.NewRouter("test", types.Params{"metrics": "true"}))
+
+	flowObj, err := types.NewFlow([]types.FlowMatch{{Namespaces: []string{"ns-test"}}}, "flow:ns-test:flow", "", "", "", util.BoolPointer(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	flowObj.WithOutputs(toDirective(t, output.NewNullOutputConfig()))
+	if err := system.RegisterFlow(flowObj); err != nil {
+		t.Fatal(err)
+	}
+
+	allFlowObj, err := types.NewFlow(nil, "", "all", "", "", util.BoolPointer(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	allFlowObj.WithOutputs(toDirective(t, output.NewNullOutputConfig()))
+	if err := system.RegisterFlow(allFlowObj); err != nil {
+		t.Fatal(err)
+	}
+
+	quota := &types.Quota{
+		Label:      "@quota-test",
+		Namespaces: []string{"ns-test", "ns-other"},
+		Filters: []types.Filter{toDirective(t, &filter.Throttle{
+			GroupKey:                 "kubernetes.namespace_name",
+			GroupBucketPeriodSeconds: 60,
+			GroupBucketLimit:         600,
+		})},
+	}
+	if err := system.RegisterQuota(quota); err != nil {
+		t.Fatal(err)
+	}
+	if err := system.RegisterQuota(&types.Quota{Label: "@quota-other", Namespaces: []string{"ns-other"}}); err == nil {
+		t.Error("expected error for a namespace limited by two quotas")
+	}
+
+	fluentConfig, err := system.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := &bytes.Buffer{}
+	renderer := render.FluentRender{
+		Out:    b,
+		Indent: 2,
+	}
+	err = renderer.Render(fluentConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+		<source>
+		  @type tail
+		  @id test
+		  path input.log
+		</source>
+		<match **>
+		  @type label_router
+		  @id test
+		  metrics true
+		  <route>
+		    @label @a42fd8d29c181fcf9887280c4a51bd1e
+		    metrics_labels {"id":"flow:ns-test:flow"}
+		    <match>
+		      namespaces ns-test,ns-other
+		      negate true
+		    </match>
+		    <match>
+		      namespaces ns-test
+		      negate false
+		    </match>
+		  </route>
+		  <route>
+		    @label @a181a603769c1f98ad927e7367c7aa51
+		    <match>
+		      namespaces ns-test,ns-other
+		      negate true
+		    </match>
+		    <match>
+		      negate false
+		    </match>
+		  </route>
+		  <route>
+		    @label @quota-test
+		    <match>
+		      namespaces ns-test,ns-other
+		      negate false
+		    </match>
+		  </route>
+		</match>
+		<label @quota-test>
+		  <filter **>
+		    @type throttle
+		    @id test
+		    group_bucket_limit 600
+		    group_bucket_period_s 60
+		    group_key kubernetes.namespace_name
+		  </filter>
+		  <match **>
+		    @type label_router
+		    @id test:quota-test
+		    metrics false
+		    <route>
+		      @label @a42fd8d29c181fcf9887280c4a51bd1e
+		      metrics_labels {"id":"flow:ns-test:flow"}
+		      <match>
+		        namespaces ns-test
+		        negate false
+		      </match>
+		    </route>
+		    <route>
+		      @label @a181a603769c1f98ad927e7367c7aa51
+		    </route>
+		  </match>
+		</label>
+		<label @a42fd8d29c181fcf9887280c4a51bd1e>
+		  <match **>
+		    @type null
+		    @id test
+		  </match>
+		</label>
+		<label @a181a603769c1f98ad927e7367c7aa51>
+		  <match **>
+		    @type null
+		    @id test
+		  </match>
+		</label>`
+
+	if a, e := diff.TrimLinesInString(b.String()), diff.TrimLinesInString(expected); a != e {
+		t.Errorf("Result does not match (-actual vs +expected):\n%v\nActual: %s", diff.LineDiff(a, e), b.String())
+	}
+}
+
 func TestRenderS3(t *testing.T) {
 	table := []struct {
 		name     string
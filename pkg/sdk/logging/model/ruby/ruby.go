@@ -0,0 +1,40 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ruby renders values as Ruby code for the fluentd plugins evaluating Ruby expressions
+package ruby
+
+import "strings"
+
+// Quote returns the value as a double-quoted Ruby string, escaping the characters fluentd or Ruby would interpret
+func Quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "#", `\x23`, "\n", `\n`).Replace(s) + `"`
+}
+
+// Array returns the values as a Ruby array of strings
+func Array(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, Quote(v))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// Path returns the path of a top-level key or a record accessor (`$.a.b`) as a Ruby array
+func Path(key string) string {
+	if rest, ok := strings.CutPrefix(key, "$."); ok {
+		return Array(strings.Split(rest, "."))
+	}
+	return Array([]string{key})
+}
@@ -0,0 +1,31 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ruby
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuote(t *testing.T) {
+	assert.Equal(t, `"a\"b\\c\x23{d}\n"`, Quote("a\"b\\c#{d}\n"))
+}
+
+func TestPath(t *testing.T) {
+	assert.Equal(t, `["message"]`, Path("message"))
+	assert.Equal(t, `["kubernetes", "labels", "app"]`, Path("$.kubernetes.labels.app"))
+	assert.Equal(t, `[]`, Array(nil))
+}
@@ -0,0 +1,100 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sample holds the bucketing shared by the fluentd and syslog-ng sampling filters.
+//
+// Every record is assigned to one of the Buckets: consistently by the first BucketDigits hex digits of the
+// SHA-1 hash of its sampling key, or randomly if there is no key. A record is kept if its bucket is below the
+// threshold of the percentage, so both engines keep the same records of the same key.
+package sample
+
+import (
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+const (
+	// BucketDigits is the number of hex digits of the hash selecting the bucket
+	BucketDigits = 4
+	// Buckets is the number of buckets the records are distributed into
+	Buckets = 1 << (4 * BucketDigits)
+)
+
+const (
+	// DecisionKept marks a record passing the sampling filter
+	DecisionKept = "kept"
+	// DecisionDropped marks a record sampled out
+	DecisionDropped = "dropped"
+)
+
+// Threshold returns the number of buckets kept for the percentage
+func Threshold(percentage int) int {
+	return percentage * Buckets / 100
+}
+
+// BucketPattern returns a regular expression matching the lowercase hex buckets kept for the percentage
+func BucketPattern(percentage int) string {
+	threshold := Threshold(percentage)
+	if threshold >= Buckets {
+		return fmt.Sprintf("^[0-9a-f]{%d}$", BucketDigits)
+	}
+	digits := fmt.Sprintf("%0*x", BucketDigits, threshold)
+	var alternatives []string
+	for i := 0; i < BucketDigits; i++ {
+		d := digits[i]
+		if d == '0' {
+			continue
+		}
+		alt := digits[:i] + hexClass(d)
+		switch rest := BucketDigits - i - 1; rest {
+		case 0:
+		case 1:
+			alt += "[0-9a-f]"
+		default:
+			alt += fmt.Sprintf("[0-9a-f]{%d}", rest)
+		}
+		alternatives = append(alternatives, alt)
+	}
+	if len(alternatives) == 0 {
+		// no bucket is kept
+		return "^$"
+	}
+	return "^(?:" + strings.Join(alternatives, "|") + ")$"
+}
+
+// hexClass returns a character class of the hex digits below the digit
+func hexClass(digit byte) string {
+	switch {
+	case digit == '1':
+		return "0"
+	case digit <= '9':
+		return fmt.Sprintf("[0-%c]", digit-1)
+	case digit == 'a':
+		return "[0-9]"
+	case digit == 'b':
+		return "[0-9a]"
+	default:
+		return fmt.Sprintf("[0-9a-%c]", digit-1)
+	}
+}
+
+// Validate checks the percentage of a sampling filter
+func Validate(percentage int) error {
+	if percentage < 0 || percentage > 100 {
+		return errors.Errorf("percentage should be between 0 and 100, got %d", percentage)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sample
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketPattern(t *testing.T) {
+	assert.Equal(t, "^(?:0[0-9a-f]{3}|1[0-8][0-9a-f]{2}|19[0-8][0-9a-f]|199[0-8])$", BucketPattern(10))
+	// the threshold of 65% is 0xa666
+	assert.Equal(t, "^(?:[0-9][0-9a-f]{3}|a[0-5][0-9a-f]{2}|a6[0-5][0-9a-f]|a66[0-5])$", BucketPattern(65))
+
+	for _, percentage := range []int{0, 1, 10, 33, 50, 65, 68, 99, 100} {
+		t.Run(fmt.Sprintf("%d%%", percentage), func(t *testing.T) {
+			re := regexp.MustCompile(BucketPattern(percentage))
+			kept := 0
+			for bucket := 0; bucket < Buckets; bucket++ {
+				matches := re.MatchString(fmt.Sprintf("%04x", bucket))
+				assert.Equal(t, bucket < Threshold(percentage), matches, "bucket %04x", bucket)
+				if matches {
+					kept++
+				}
+			}
+			assert.Equal(t, Threshold(percentage), kept)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(0))
+	assert.NoError(t, Validate(100))
+	assert.EqualError(t, Validate(101), "percentage should be between 0 and 100, got 101")
+}
@@ -52,7 +52,8 @@ func renderValue(value reflect.Value, secretLoader secret.SecretLoader) []render
 	if value.CanConvert(matchExprType) {
 		matchExpr := value.Convert(matchExprType).Interface().(filter.MatchExpr)
 		return []render.Renderer{
-			filterExpr(filterExprFromMatchExpr(matchExpr)),
+			// conditions of drivers are rendered without the flow, selectors use the default key delimiter
+			filterExpr(filterExprFromMatchExpr(matchExpr, keyDelim(""))),
 		}
 	} else if value.Type() == arrowMapType {
 		arrowMap := value.Interface().(filter.ArrowMap)
@@ -60,6 +61,17 @@ func renderValue(value reflect.Value, secretLoader secret.SecretLoader) []render
 	} else if value.Type() == rawArrowMapType {
 		rawArrowMap := value.Interface().(filter.RawArrowMap)
 		return []render.Renderer{render.ArrowMap(rawArrowMap, render.String, render.String)}
+	} else if value.Type() == secretArrowMapType {
+		secretArrowMap := value.Interface().(output.SecretArrowMap)
+		arrowMap := make(map[string]string, len(secretArrowMap))
+		for key, val := range secretArrowMap {
+			sec, err := secretLoader.Load(&val)
+			if err != nil {
+				return []render.Renderer{render.Error(err)}
+			}
+			arrowMap[key] = sec
+		}
+		return []render.Renderer{render.ArrowMap(arrowMap, render.Literal[string], render.Literal[string])}
 	} else if value.Type() == stringListType {
 		stringList := value.Interface().(output.StringList)
 		return []render.Renderer{render.StringList(stringList.List)}
@@ -210,6 +222,7 @@ func goNameToSyslogName(s string) string {
 var matchExprType = reflect.TypeOf(filter.MatchExpr{})
 var arrowMapType = reflect.TypeOf(filter.ArrowMap{})
 var rawArrowMapType = reflect.TypeOf(filter.RawArrowMap{})
+var secretArrowMapType = reflect.TypeOf(output.SecretArrowMap{})
 var stringListType = reflect.TypeOf(output.StringList{})
 var rawStringType = reflect.TypeOf(*new(output.RawString))
 
@@ -54,6 +54,10 @@ type Input struct {
 	Flows               []v1beta1.SyslogNGFlow
 	SecretLoaderFactory SecretLoaderFactory
 	SourcePort          int
+	// ReservedPorts are the ports of the syslog-ng pods and service that the additional sources must not use, by their users
+	ReservedPorts map[int32]string
+	// Quotas limit the records of namespaces before they reach the flows
+	Quotas []Quota
 }
 
 type outputInfo struct {
@@ -78,6 +82,9 @@ func configRenderer(in Input) (render.Renderer, error) {
 	}
 
 	var errs error
+	if err := validateSources(in.SyslogNGSpec, in.SourcePort, in.ReservedPorts); err != nil {
+		errs = errors.Append(errs, err)
+	}
 
 	// TODO: this should happen at the spec level, in something like `SyslogNGSpec.FinalGlobalOptions() GlobalOptions`
 	if in.SyslogNGSpec.Metrics != nil {
@@ -116,7 +123,10 @@ func configRenderer(in Input) (render.Renderer, error) {
 		if err := validateClusterOutputs(clusterOutputRefs, client.ObjectKeyFromObject(&cf).String(), cf.Spec.GlobalOutputRefs, cf.Kind); err != nil {
 			errs = errors.Append(errs, err)
 		}
-		logDefs = append(logDefs, renderClusterFlow(in.Name, clusterOutputRefs, sourceName, cf, in.SecretLoaderFactory))
+		if err := validateSourceRefs(in.SyslogNGSpec, client.ObjectKeyFromObject(&cf).String(), cf.Spec.SourceRefs); err != nil {
+			errs = errors.Append(errs, err)
+		}
+		logDefs = append(logDefs, renderClusterFlow(in.Name, clusterOutputRefs, sourceName, keyDelim(in.SyslogNGSpec.JSONKeyDelimiter), cf, in.SecretLoaderFactory))
 	}
 	for _, f := range in.Flows {
 		if err := validateClusterOutputs(clusterOutputRefs, client.ObjectKeyFromObject(&f).String(), f.Spec.GlobalOutputRefs, f.Kind); err != nil {
@@ -172,6 +182,7 @@ func configRenderer(in Input) (render.Renderer, error) {
 					versionStmt(configVersion),
 					includeStmt("scl.conf"),
 					globalOptionsDefStmt(globalOptions...),
+					render.If(redactsCreditCardsInFlows(in.ClusterFlows, in.Flows), pythonDefStmt(redactCreditCardPython)),
 					sourceDefStmt(sourceName,
 						channelDefStmt(
 							sourceDefStmt("", renderDriver(Field{
@@ -183,12 +194,13 @@ func configRenderer(in Input) (render.Renderer, error) {
 									Flags:          []string{"no-parse"},
 								}),
 							}, nil)),
-							[]render.Renderer{
+							append([]render.Renderer{
 								parserDefStmt("", render.AllOf(sourceParsers...)),
-							},
+							}, quotaTransforms(in.Quotas, keyDelim(in.SyslogNGSpec.JSONKeyDelimiter), in.SyslogNGSpec.Metrics != nil, in.Name)...),
 						),
 					),
 				),
+				seqs.FromSlice(renderSources(in.SyslogNGSpec)),
 				seqs.FromSlice(destinationDefs),
 				seqs.FromSlice(logDefs),
 			),
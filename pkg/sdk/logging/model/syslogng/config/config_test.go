@@ -977,6 +977,216 @@ source "main_input" {
         };
     };
 };
+`),
+		},
+		"quotas": {
+			input: Input{
+				Namespace: "logging",
+				Name:      "test",
+				SyslogNGSpec: &v1beta1.SyslogNGSpec{
+					Metrics: &v1beta1.Metrics{},
+				},
+				Quotas: []Quota{
+					{Name: "a", Namespaces: []string{"ns-a", "ns-b"}, RecordsPerSecond: 100},
+					{Name: "b", Namespaces: []string{"ns-c"}},
+				},
+				SecretLoaderFactory: &TestSecretLoaderFactory{},
+				SourcePort:          601,
+			},
+			wantOut: Untab(`@version: current
+
+@include "scl.conf"
+
+options {
+    stats(level(2) freq(0));
+};
+
+source "main_input" {
+    channel {
+        source {
+            network(flags("no-parse") port(601) transport("tcp"));
+        };
+        parser {
+            json-parser(prefix("json."));
+        };
+        parser {
+            metrics-probe(key("quota_records_total") labels(
+				"logging" => "test"
+				"namespace" => "${json.kubernetes.namespace_name}"
+			));
+        };
+        filter {
+            (not (match("ns-a" value("json.kubernetes.namespace_name") type("string")) or match("ns-b" value("json.kubernetes.namespace_name") type("string")))) or rate-limit(template("${json.kubernetes.namespace_name}") rate(100));
+        };
+        parser {
+            metrics-probe(key("quota_accepted_records_total") labels(
+				"logging" => "test"
+				"namespace" => "${json.kubernetes.namespace_name}"
+			));
+        };
+    };
+};
+`),
+		},
+		"external sources": {
+			input: Input{
+				SourcePort: 601,
+				Name:       "test",
+				Namespace:  "logging",
+				SyslogNGSpec: &v1beta1.SyslogNGSpec{
+					TLS: v1beta1.SyslogNGTLS{Enabled: true, SecretName: "syslog-ng-tls"},
+					Sources: []v1beta1.SyslogNGSource{
+						{Name: "appliances", Port: 514, Syslog: &v1beta1.SyslogNGSyslogSource{Transport: "udp", Format: "rfc3164"}},
+						{Name: "secure", Port: 6514, Syslog: &v1beta1.SyslogNGSyslogSource{TLS: &v1beta1.SyslogNGSourceTLS{PeerVerify: "optional-untrusted"}}},
+						{Name: "otlp", Port: 4317, OpenTelemetry: &v1beta1.SyslogNGOpenTelemetrySource{}},
+						{Name: "apps", Port: 8080, HTTP: &v1beta1.SyslogNGHTTPSource{}},
+					},
+				},
+				ClusterOutputs: []v1beta1.SyslogNGClusterOutput{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "logging", Name: "archive"},
+						Spec: v1beta1.SyslogNGClusterOutputSpec{SyslogNGOutputSpec: v1beta1.SyslogNGOutputSpec{
+							Syslog: &output.SyslogOutput{Host: "archive.local", Transport: "tcp"},
+						}},
+					},
+				},
+				ClusterFlows: []v1beta1.SyslogNGClusterFlow{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "logging", Name: "all"},
+						Spec: v1beta1.SyslogNGClusterFlowSpec{
+							SourceRefs:       []string{"main", "appliances", "secure", "otlp", "apps"},
+							GlobalOutputRefs: []string{"archive"},
+						},
+					},
+				},
+				SecretLoaderFactory: &TestSecretLoaderFactory{},
+			},
+			wantOut: Untab(`@version: current
+
+@include "scl.conf"
+
+source "main_input" {
+    channel {
+        source {
+            network(flags("no-parse") port(601) transport("tcp"));
+        };
+        parser {
+            json-parser(prefix("json."));
+        };
+    };
+};
+
+source "appliances_input" {
+    network(port(514) transport("udp"));
+};
+
+source "secure_input" {
+    syslog(port(6514) transport("tls") tls(key-file("/syslog-ng/tls/tls.key") cert-file("/syslog-ng/tls/tls.crt") ca-file("/syslog-ng/tls/ca.crt") peer-verify("optional-untrusted")));
+};
+
+source "otlp_input" {
+    opentelemetry(port(4317));
+};
+
+source "apps_input" {
+    webhook(port(8080));
+};
+
+destination "clusteroutput_logging_archive" {
+    syslog("archive.local" transport("tcp") persist_name("clusteroutput_logging_archive"));
+};
+
+log {
+    source("main_input");
+    source("appliances_input");
+    source("secure_input");
+    source("otlp_input");
+    source("apps_input");
+    log {
+        destination("clusteroutput_logging_archive");
+    };
+};
+`),
+		},
+		"dangling source reference": {
+			input: Input{
+				SourcePort:   601,
+				SyslogNGSpec: &v1beta1.SyslogNGSpec{},
+				ClusterFlows: []v1beta1.SyslogNGClusterFlow{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "logging", Name: "all"},
+						Spec:       v1beta1.SyslogNGClusterFlowSpec{SourceRefs: []string{"appliances"}},
+					},
+				},
+				SecretLoaderFactory: &TestSecretLoaderFactory{},
+			},
+			wantErr: true,
+		},
+		"invalid sources": {
+			input: Input{
+				SourcePort: 601,
+				SyslogNGSpec: &v1beta1.SyslogNGSpec{
+					Sources: []v1beta1.SyslogNGSource{
+						{Name: "main", Port: 601, Syslog: &v1beta1.SyslogNGSyslogSource{}},
+						{Name: "secure", Port: 6514, Syslog: &v1beta1.SyslogNGSyslogSource{TLS: &v1beta1.SyslogNGSourceTLS{}}},
+					},
+				},
+				SecretLoaderFactory: &TestSecretLoaderFactory{},
+			},
+			wantErr: true,
+		},
+		"reserved source port": {
+			input: Input{
+				SourcePort:    601,
+				ReservedPorts: map[int32]string{9577: "the metrics exporter"},
+				SyslogNGSpec: &v1beta1.SyslogNGSpec{
+					Sources: []v1beta1.SyslogNGSource{
+						{Name: "appliances", Port: 9577, Syslog: &v1beta1.SyslogNGSyslogSource{}},
+					},
+				},
+				SecretLoaderFactory: &TestSecretLoaderFactory{},
+			},
+			wantErr: true,
+		},
+		"credit card redaction": {
+			input: Input{
+				SourcePort:   601,
+				Name:         "test",
+				Namespace:    "config-test",
+				SyslogNGSpec: &v1beta1.SyslogNGSpec{},
+				ClusterFlows: []v1beta1.SyslogNGClusterFlow{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "config-test", Name: "redact"},
+						Spec: v1beta1.SyslogNGClusterFlowSpec{
+							Filters: []v1beta1.SyslogNGFilter{{Redact: &filter.RedactConfig{Detectors: []string{"credit_card"}}}},
+						},
+					},
+				},
+				SecretLoaderFactory: &TestSecretLoaderFactory{},
+			},
+			wantOut: Untab(`@version: current
+
+@include "scl.conf"
+
+`) + "python {\n" + redactCreditCardPython + "};\n" + Untab(`
+source "main_input" {
+	channel {
+		source {
+			network(flags("no-parse") port(601) transport("tcp"));
+		};
+		parser {
+			json-parser(prefix("json."));
+		};
+	};
+};
+
+parser "clusterflow_config-test_redact_filters_0_credit_card" {
+	python(class("RedactCreditCard") options("action" "mask" "fields" "[\"MESSAGE\"]" "marker" ".redact.credit_card." "mask" "[REDACTED]" "pattern" "\\b(?:\\d[ -]?){12,18}\\d\\b" "salt" "" "scope" "substring"));
+};
+log {
+	source("main_input");
+	parser("clusterflow_config-test_redact_filters_0_credit_card");
+};
 `),
 		},
 	}
@@ -990,3 +1200,27 @@ source "main_input" {
 		})
 	}
 }
+
+func TestValidSources(t *testing.T) {
+	spec := &v1beta1.SyslogNGSpec{
+		Sources: []v1beta1.SyslogNGSource{
+			{Name: "appliances", Port: 5514, Syslog: &v1beta1.SyslogNGSyslogSource{Transport: "udp"}},
+			{Name: "main", Port: 6000, Syslog: &v1beta1.SyslogNGSyslogSource{}},
+			{Name: "metrics", Port: 9577, Syslog: &v1beta1.SyslogNGSyslogSource{}},
+			{Name: "otlp", Port: 5514, OpenTelemetry: &v1beta1.SyslogNGOpenTelemetrySource{}},
+			{Name: "empty", Port: 8080},
+			{Name: "apps", Port: 8081, HTTP: &v1beta1.SyslogNGHTTPSource{}},
+		},
+	}
+	reserved := map[int32]string{9577: "the metrics exporter"}
+
+	valid := ValidSources(spec, 601, reserved)
+	require.Equal(t, []v1beta1.SyslogNGSource{spec.Sources[0], spec.Sources[5]}, valid)
+
+	err := validateSources(spec, 601, reserved)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "source name main is not unique")
+	require.Contains(t, err.Error(), "port 9577 of source metrics is already used by the metrics exporter")
+	require.Contains(t, err.Error(), "port 5514 of source otlp is already used by source appliances")
+	require.Contains(t, err.Error(), "source empty: exactly one of syslog, opentelemetry and http must be specified")
+}
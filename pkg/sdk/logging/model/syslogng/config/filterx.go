@@ -0,0 +1,168 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/siliconbrain/go-seqs/seqs"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config/render"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/filter"
+)
+
+// filterXBlockStmt returns the FilterX block of the filter.
+// The block is rendered in the log path, since FilterX blocks are not referenced by name.
+func filterXBlockStmt(cfg filter.FilterXConfig, filterID string) render.Renderer {
+	if err := validateFilterX(cfg); err != nil {
+		return render.Error(errors.WrapIff(err, "invalid filterx filter %s", filterID))
+	}
+	if cfg.Code != "" {
+		return braceDefStmt("filterx", "", render.AllFrom(seqs.Map(seqs.FromSlice(filterXCodeLines(cfg.Code)), func(line string) render.Renderer {
+			return render.Line(render.String(line))
+		})))
+	}
+	return braceDefStmt("filterx", "", renderFilterXStatements(cfg.Statements))
+}
+
+func renderFilterXStatements(statements []filter.FilterXStatement) render.Renderer {
+	return render.AllFrom(seqs.Map(seqs.FromSlice(statements), renderFilterXStatement))
+}
+
+func renderFilterXStatement(statement filter.FilterXStatement) render.Renderer {
+	switch {
+	case statement.Set != nil:
+		value := filterXString(statement.Set.Value)
+		if statement.Set.Expr != "" {
+			value = statement.Set.Expr
+		}
+		return render.Line(render.Formatted("%s = %s;", filterXField(statement.Set.FieldName), value))
+	case statement.Unset != nil:
+		return render.Line(render.Formatted("unset(%s);", strings.Join(seqs.ToSlice(seqs.Map(seqs.FromSlice(statement.Unset.Fields), filterXField)), ", ")))
+	case statement.Rename != nil:
+		// the rename is conditional, so a missing field does not drop the record
+		oldName, newName := filterXField(statement.Rename.OldName), filterXField(statement.Rename.NewName)
+		return render.AllOf(
+			render.Line(render.Formatted("if (isset(%s)) {", oldName)),
+			render.Indented(render.AllOf(
+				render.Line(render.Formatted("%s = %s;", newName, oldName)),
+				render.Line(render.Formatted("unset(%s);", oldName)),
+			)),
+			render.Line(render.String("};")),
+		)
+	case statement.If != nil:
+		return render.AllOf(
+			render.Line(render.Formatted("if (%s) {", statement.If.Condition)),
+			render.Indented(renderFilterXStatements(statement.If.Then)),
+			render.If(len(statement.If.Else) > 0, render.AllOf(
+				render.Line(render.String("} else {")),
+				render.Indented(renderFilterXStatements(statement.If.Else)),
+			)),
+			render.Line(render.String("};")),
+		)
+	default:
+		return nil
+	}
+}
+
+func validateFilterX(cfg filter.FilterXConfig) error {
+	switch {
+	case cfg.Code != "" && len(cfg.Statements) > 0:
+		return errors.New("code and statements are mutually exclusive")
+	case strings.TrimSpace(cfg.Code) == "" && len(cfg.Statements) == 0:
+		return errors.New("either code or statements must be specified")
+	}
+	return validateFilterXStatements(cfg.Statements)
+}
+
+func validateFilterXStatements(statements []filter.FilterXStatement) (errs error) {
+	for i, statement := range statements {
+		if err := validateFilterXStatement(statement); err != nil {
+			errs = errors.Append(errs, errors.WrapIff(err, "statement %d", i))
+		}
+	}
+	return errs
+}
+
+func validateFilterXStatement(statement filter.FilterXStatement) error {
+	var kinds []string
+	if statement.Set != nil {
+		kinds = append(kinds, "set")
+	}
+	if statement.Unset != nil {
+		kinds = append(kinds, "unset")
+	}
+	if statement.Rename != nil {
+		kinds = append(kinds, "rename")
+	}
+	if statement.If != nil {
+		kinds = append(kinds, "if")
+	}
+	switch len(kinds) {
+	case 0:
+		return errors.New("no statement specified")
+	case 1:
+	default:
+		return errors.Errorf("multiple statements (%v) specified", kinds)
+	}
+
+	switch {
+	case statement.Set != nil:
+		if statement.Set.Value != "" && statement.Set.Expr != "" {
+			return errors.New("value and expr of set are mutually exclusive")
+		}
+		return validateFilterXField(statement.Set.FieldName)
+	case statement.Unset != nil:
+		if len(statement.Unset.Fields) == 0 {
+			return errors.New("no fields specified on unset")
+		}
+		var errs error
+		for _, field := range statement.Unset.Fields {
+			errs = errors.Append(errs, validateFilterXField(field))
+		}
+		return errs
+	case statement.Rename != nil:
+		return errors.Append(validateFilterXField(statement.Rename.OldName), validateFilterXField(statement.Rename.NewName))
+	default:
+		if strings.TrimSpace(statement.If.Condition) == "" {
+			return errors.New("no condition specified on if")
+		}
+		return errors.Append(validateFilterXStatements(statement.If.Then), validateFilterXStatements(statement.If.Else))
+	}
+}
+
+func validateFilterXField(name string) error {
+	if name == "" || strings.ContainsAny(name, "{}\n") {
+		return errors.Errorf("invalid field name %q", name)
+	}
+	return nil
+}
+
+// filterXField returns the reference of the name-value pair of the field
+func filterXField(name string) string {
+	return fmt.Sprintf("${%s}", name)
+}
+
+// filterXString returns the value as a FilterX string literal
+func filterXString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`).Replace(value) + `"`
+}
+
+// filterXCodeLines returns the lines of the code without the trailing empty lines
+func filterXCodeLines(code string) []string {
+	return strings.Split(strings.TrimRight(code, "\n\t "), "\n")
+}
@@ -56,23 +56,23 @@ func validateClusterOutputs(clusterOutputRefs map[string]clusterOutputInfo, flow
 	})
 }
 
-func renderClusterFlow(logging string, clusterOutputRefs map[string]clusterOutputInfo, sourceName string, f v1beta1.SyslogNGClusterFlow, secretLoaderFactory SecretLoaderFactory) render.Renderer {
+func renderClusterFlow(logging string, clusterOutputRefs map[string]clusterOutputInfo, sourceName string, keyDelim string, f v1beta1.SyslogNGClusterFlow, secretLoaderFactory SecretLoaderFactory) render.Renderer {
 	baseName := fmt.Sprintf("clusterflow_%s_%s", f.Namespace, f.Name)
 	matchName := fmt.Sprintf("%s_match", baseName)
 	filterDefs := seqs.MapWithIndex(seqs.FromSlice(f.Spec.Filters), func(idx int, flt v1beta1.SyslogNGFilter) render.Renderer {
-		return renderFlowFilter(flt, &f, idx, baseName, secretLoaderFactory.SecretLoaderForNamespace(f.Namespace))
+		return renderFlowFilter(flt, &f, idx, baseName, keyDelim, secretLoaderFactory.SecretLoaderForNamespace(f.Namespace))
 	})
 	return render.AllOf(
-		renderFlowMatch(matchName, f.Spec.Match),
+		renderFlowMatch(matchName, f.Spec.Match, keyDelim),
 		render.AllFrom(filterDefs),
 		logDefStmt(
-			[]string{sourceName},
+			flowSourceNames(sourceName, f.Spec.SourceRefs),
 			seqs.ToSlice(seqs.Concat(
 				seqs.FromValues(
 					render.If(!f.Spec.Match.IsEmpty(), filterRefStmt(matchName)),
 				),
 				seqs.MapWithIndex(seqs.FromSlice(f.Spec.Filters), func(idx int, flt v1beta1.SyslogNGFilter) render.Renderer {
-					return parenDefStmt(filterKind(flt), render.Literal(filterID(flt, idx, baseName)))
+					return renderFlowFilterRef(flt, idx, baseName)
 				}),
 			)),
 			seqs.ToSlice(seqs.Map(seqs.FromSlice(f.Spec.GlobalOutputRefs), func(ref string) destination {
@@ -94,7 +94,7 @@ func renderFlow(logging string, clusterOutputRefs map[string]clusterOutputInfo,
 	matchName := fmt.Sprintf("%s_match", baseName)
 	nsFilterName := fmt.Sprintf("%s_ns_filter", baseName)
 	filterDefs := render.AllFrom(seqs.MapWithIndex(seqs.FromSlice(f.Spec.Filters), func(idx int, flt v1beta1.SyslogNGFilter) render.Renderer {
-		return renderFlowFilter(flt, &f, idx, baseName, secretLoaderFactory.SecretLoaderForNamespace(f.Namespace))
+		return renderFlowFilter(flt, &f, idx, baseName, keyDelim, secretLoaderFactory.SecretLoaderForNamespace(f.Namespace))
 	}))
 	return render.AllOf(
 		filterDefStmt(nsFilterName, filterExprStmt(model.NewFilterExpr(model.FilterExprMatch{
@@ -102,7 +102,7 @@ func renderFlow(logging string, clusterOutputRefs map[string]clusterOutputInfo,
 			Scope:   model.NewFilterExprMatchScope(model.FilterExprMatchScopeValue(strings.Join([]string{"json", "kubernetes", "namespace_name"}, keyDelim))),
 			Type:    "string",
 		}))),
-		renderFlowMatch(matchName, f.Spec.Match),
+		renderFlowMatch(matchName, f.Spec.Match, keyDelim),
 		filterDefs,
 		logDefStmt(
 			[]string{sourceName},
@@ -112,7 +112,7 @@ func renderFlow(logging string, clusterOutputRefs map[string]clusterOutputInfo,
 					render.If(!f.Spec.Match.IsEmpty(), filterRefStmt(matchName)),
 				),
 				seqs.MapWithIndex(seqs.FromSlice(f.Spec.Filters), func(idx int, flt v1beta1.SyslogNGFilter) render.Renderer {
-					return parenDefStmt(filterKind(flt), render.Literal(filterID(flt, idx, baseName)))
+					return renderFlowFilterRef(flt, idx, baseName)
 				}),
 			)),
 			seqs.ToSlice(seqs.Concat(
@@ -141,14 +141,14 @@ func renderFlow(logging string, clusterOutputRefs map[string]clusterOutputInfo,
 	)
 }
 
-func renderFlowMatch(name string, m *v1beta1.SyslogNGMatch) render.Renderer {
+func renderFlowMatch(name string, m *v1beta1.SyslogNGMatch, keyDelim string) render.Renderer {
 	if m.IsEmpty() {
 		return nil
 	}
-	return filterDefStmt(name, renderMatchExpr(filter.MatchExpr(*m)))
+	return filterDefStmt(name, renderMatchExpr(filter.MatchExpr(*m), keyDelim))
 }
 
-func renderFlowFilter(flt v1beta1.SyslogNGFilter, flow metav1.Object, index int, baseName string, secretLoader secret.SecretLoader) render.Renderer {
+func renderFlowFilter(flt v1beta1.SyslogNGFilter, flow metav1.Object, index int, baseName string, keyDelim string, secretLoader secret.SecretLoader) render.Renderer {
 	filterID := filterID(flt, index, baseName)
 
 	xformFields := seqs.ToSlice(seqs.Filter(seqs.FromSlice(fieldsOf(reflect.ValueOf(flt))), isActiveTransform))
@@ -164,7 +164,7 @@ func renderFlowFilter(flt v1beta1.SyslogNGFilter, flow metav1.Object, index int,
 			if !val.CanConvert(matchExprType) {
 				return render.Error(fmt.Errorf("value of type %s is not a valid filter expression", xformField.Value.Type()))
 			}
-			return filterDefStmt(filterID, filterExprStmt(filterExprFromMatchExpr(val.Convert(matchExprType).Interface().(filter.MatchExpr))))
+			return filterDefStmt(filterID, filterExprStmt(filterExprFromMatchExpr(val.Convert(matchExprType).Interface().(filter.MatchExpr), keyDelim)))
 		case "parser":
 			driverFields := seqs.ToSlice(seqs.Filter(seqs.FromSlice(fieldsOf(xformField.Value)), isActiveParserDriver))
 			switch len(driverFields) {
@@ -182,6 +182,13 @@ func renderFlowFilter(flt v1beta1.SyslogNGFilter, flow metav1.Object, index int,
 					xformField.KeyOrEmpty(), filterID, flow.GetNamespace(), flow.GetName(),
 				))
 			}
+		case "filterx":
+			// the block is rendered in the log path by renderFlowFilterRef
+			return nil
+		case "sample":
+			return renderSampleDefs(derefAll(xformField.Value).Interface().(filter.SampleConfig), xformField.KeyOrEmpty(), filterID, keyDelim, flow)
+		case "redact":
+			return renderRedactDefs(derefAll(xformField.Value).Interface().(filter.RedactConfig), xformField.KeyOrEmpty(), filterID, flow, secretLoader)
 		case "rewrite":
 			switch xformField.Value.Kind() {
 			case reflect.Array, reflect.Slice:
@@ -228,18 +235,25 @@ func renderRewriteDriver(value reflect.Value, key string, filter string, flow me
 	}
 }
 
-func renderMatchExpr(expr filter.MatchExpr) render.Renderer {
-	return filterExprStmt(filterExprFromMatchExpr(expr))
+func renderMatchExpr(expr filter.MatchExpr, keyDelim string) render.Renderer {
+	return filterExprStmt(filterExprFromMatchExpr(expr, keyDelim))
 }
 
-func filterExprFromMatchExpr(expr filter.MatchExpr) model.FilterExpr {
+// filterExprFromMatchExpr converts the match expression to a filter expression,
+// the selectors match the fields of the record parsed with the key delimiter.
+func filterExprFromMatchExpr(expr filter.MatchExpr, keyDelim string) model.FilterExpr {
+	convert := func(expr filter.MatchExpr) model.FilterExpr {
+		return filterExprFromMatchExpr(expr, keyDelim)
+	}
 	switch {
 	case len(expr.And) > 0:
-		return model.NewFilterExpr(model.FilterExprAnd(seqs.ToSlice(seqs.Map(seqs.FromSlice(expr.And), filterExprFromMatchExpr))))
+		return model.NewFilterExpr(model.FilterExprAnd(seqs.ToSlice(seqs.Map(seqs.FromSlice(expr.And), convert))))
 	case expr.Not != nil:
-		return model.NewFilterExpr(model.FilterExprNot{Expr: filterExprFromMatchExpr(filter.MatchExpr(*expr.Not))})
+		return model.NewFilterExpr(model.FilterExprNot{Expr: convert(filter.MatchExpr(*expr.Not))})
 	case len(expr.Or) > 0:
-		return model.NewFilterExpr(model.FilterExprOr(seqs.ToSlice(seqs.Map(seqs.FromSlice(expr.Or), filterExprFromMatchExpr))))
+		return model.NewFilterExpr(model.FilterExprOr(seqs.ToSlice(seqs.Map(seqs.FromSlice(expr.Or), convert))))
+	case expr.Selector != nil:
+		return selectorFilterExpr(*expr.Selector, keyDelim)
 	case expr.Regexp != nil:
 		m := model.FilterExprMatch{
 			Pattern: expr.Regexp.Pattern,
@@ -258,6 +272,19 @@ func filterExprFromMatchExpr(expr filter.MatchExpr) model.FilterExpr {
 	}
 }
 
+// renderFlowFilterRef returns the references of the definitions of a filter in the log path
+func renderFlowFilterRef(flt v1beta1.SyslogNGFilter, index int, baseName string) render.Renderer {
+	switch filterKind(flt) {
+	case "sample":
+		return renderSampleRefs(filterID(flt, index, baseName))
+	case "filterx":
+		return filterXBlockStmt(*flt.FilterX, filterID(flt, index, baseName))
+	case "redact":
+		return renderRedactRefs(*flt.Redact, filterID(flt, index, baseName))
+	}
+	return parenDefStmt(filterKind(flt), render.Literal(filterID(flt, index, baseName)))
+}
+
 func filterID(filter v1beta1.SyslogNGFilter, index int, baseName string) string {
 	filterID := filter.ID
 	if filterID == "" {
@@ -18,8 +18,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/cisco-open/operator-tools/pkg/secret"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
@@ -94,6 +96,227 @@ log {
 source("test_input");
 parser("clusterflow_test_ns_test_clusterflow_filters_0");
 };
+`),
+		},
+		"selector": {
+			clusterFlow: v1beta1.SyslogNGClusterFlow{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "test_clusterflow",
+					Namespace: "test_ns",
+				},
+				Spec: v1beta1.SyslogNGClusterFlowSpec{
+					Match: &v1beta1.SyslogNGMatch{
+						Selector: &filter.SelectorMatchExpr{
+							Annotations: map[string]string{"audit": "true"},
+							LabelExpressions: []filter.LabelExpr{
+								{Key: "app", Operator: "In", Values: []string{"apache", "nginx"}},
+								{Key: "tier", Operator: "DoesNotExist"},
+								{Key: "version", Operator: "Regex", Values: []string{"^v2"}},
+							},
+						},
+					},
+				},
+			},
+			expected: Untab(`filter "clusterflow_test_ns_test_clusterflow_match" {
+(match("true" value("json.kubernetes.annotations.audit") type("string")) and (match("apache" value("json.kubernetes.labels.app") type("string")) or match("nginx" value("json.kubernetes.labels.app") type("string"))) and (not match("." value("json.kubernetes.labels.tier"))) and (match("." value("json.kubernetes.labels.version")) and match("^v2" value("json.kubernetes.labels.version"))));
+};
+log {
+source("test_input");
+filter("clusterflow_test_ns_test_clusterflow_match");
+};
+`),
+		},
+		"redact": {
+			clusterFlow: v1beta1.SyslogNGClusterFlow{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "test_clusterflow",
+					Namespace: "test_ns",
+				},
+				Spec: v1beta1.SyslogNGClusterFlowSpec{
+					Filters: []v1beta1.SyslogNGFilter{
+						{
+							Redact: &filter.RedactConfig{
+								Fields:    []string{"json.message"},
+								Detectors: []string{"aws_access_key"},
+								Patterns:  []string{"id-\\d+"},
+							},
+						},
+						{
+							Redact: &filter.RedactConfig{
+								Fields:   []string{"json.token"},
+								Patterns: []string{"secret"},
+								Action:   "drop",
+								Scope:    "field",
+							},
+						},
+					},
+				},
+			},
+			expected: Untab(`rewrite "clusterflow_test_ns_test_clusterflow_filters_0" {
+subst("(?:\\b(?:AKIA|ASIA)[A-Z0-9]{16}\\b)|(?:id-\\d+)" "[REDACTED]" value("json.message") flags("global"));
+};
+rewrite "clusterflow_test_ns_test_clusterflow_filters_1" {
+unset(value("json.token") condition(match("(?:secret)" value("json.token"))));
+};
+log {
+source("test_input");
+rewrite("clusterflow_test_ns_test_clusterflow_filters_0");
+rewrite("clusterflow_test_ns_test_clusterflow_filters_1");
+};
+`),
+		},
+		"sample": {
+			clusterFlow: v1beta1.SyslogNGClusterFlow{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "test_clusterflow",
+					Namespace: "test_ns",
+				},
+				Spec: v1beta1.SyslogNGClusterFlowSpec{
+					Filters: []v1beta1.SyslogNGFilter{
+						{
+							Sample: &filter.SampleConfig{
+								Percentage: 50,
+								Key:        "json.trace_id",
+								AlwaysKeep: []filter.SampleRule{{Field: "json.level", Pattern: "^ERROR$"}},
+							},
+						},
+					},
+				},
+			},
+			expected: Untab(`rewrite "clusterflow_test_ns_test_clusterflow_filters_0" {
+set("$(sha1 --length 4 $(if (\"${json.trace_id}\" ne \"\") \"${json.trace_id}\" \"$(uuid)\"))" value(".sample.bucket"));
+set("dropped" value(".sample.decision"));
+set("kept" value(".sample.decision") condition((match("^(?:[0-7][0-9a-f]{3})$" value(".sample.bucket")) or match("^ERROR$" value("json.level")))));
+};
+parser "clusterflow_test_ns_test_clusterflow_filters_0_metrics" {
+metrics-probe(key("sampled_records") labels(
+"decision" => "${.sample.decision}"
+"namespace" => "${json.kubernetes.namespace_name}"
+"sampler" => "clusterflow_test_ns_test_clusterflow_filters_0"
+));
+};
+filter "clusterflow_test_ns_test_clusterflow_filters_0_keep" {
+match("^kept$" value(".sample.decision"));
+};
+log {
+source("test_input");
+rewrite("clusterflow_test_ns_test_clusterflow_filters_0");
+parser("clusterflow_test_ns_test_clusterflow_filters_0_metrics");
+filter("clusterflow_test_ns_test_clusterflow_filters_0_keep");
+};
+`),
+		},
+		"structured parsers": {
+			clusterFlow: v1beta1.SyslogNGClusterFlow{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "test_clusterflow",
+					Namespace: "test_ns",
+				},
+				Spec: v1beta1.SyslogNGClusterFlowSpec{
+					Filters: []v1beta1.SyslogNGFilter{
+						{
+							Parser: &filter.ParserConfig{
+								JSONParser: &filter.JSONParser{Template: "${json.message}", Prefix: "json.app."},
+							},
+						},
+						{
+							Parser: &filter.ParserConfig{
+								KVParser: &filter.KVParser{Template: "${json.message}", Prefix: "json.logfmt.", ValueSeparator: "=", PairSeparator: " "},
+							},
+						},
+						{
+							Parser: &filter.ParserConfig{
+								CSVParser: &filter.CSVParser{
+									Template:   "${json.message}",
+									Prefix:     "json.access.",
+									Columns:    []string{"client", "time", "path"},
+									Delimiters: " ",
+									QuotePairs: "[]",
+									Flags:      []string{"greedy"},
+								},
+							},
+						},
+						{
+							Parser: &filter.ParserConfig{
+								DateParser: &filter.DateParser{Template: "${json.access.time}", Format: []string{"%d/%b/%Y:%H:%M:%S %z"}},
+							},
+						},
+					},
+				},
+			},
+			expected: Untab(`parser "clusterflow_test_ns_test_clusterflow_filters_0" {
+json-parser(prefix("json.app.") template("${json.message}"));
+};
+parser "clusterflow_test_ns_test_clusterflow_filters_1" {
+kv-parser(prefix("json.logfmt.") template("${json.message}") value-separator("=") pair-separator(" "));
+};
+parser "clusterflow_test_ns_test_clusterflow_filters_2" {
+csv-parser(columns("client" "time" "path") prefix("json.access.") template("${json.message}") delimiters(" ") quote-pairs("[]") flags("greedy"));
+};
+parser "clusterflow_test_ns_test_clusterflow_filters_3" {
+date-parser(format("%d/%b/%Y:%H:%M:%S %z") template("${json.access.time}"));
+};
+log {
+source("test_input");
+parser("clusterflow_test_ns_test_clusterflow_filters_0");
+parser("clusterflow_test_ns_test_clusterflow_filters_1");
+parser("clusterflow_test_ns_test_clusterflow_filters_2");
+parser("clusterflow_test_ns_test_clusterflow_filters_3");
+};
+`),
+		},
+		"filterx": {
+			clusterFlow: v1beta1.SyslogNGClusterFlow{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "test_clusterflow",
+					Namespace: "test_ns",
+				},
+				Spec: v1beta1.SyslogNGClusterFlowSpec{
+					Filters: []v1beta1.SyslogNGFilter{
+						{
+							FilterX: &filter.FilterXConfig{
+								Statements: []filter.FilterXStatement{
+									{Set: &filter.FilterXSetConfig{FieldName: "json.kubernetes.cluster", Value: `prod "us"`}},
+									{Rename: &filter.FilterXRenameConfig{OldName: "json.log", NewName: "json.message"}},
+									{If: &filter.FilterXConditionalConfig{
+										Condition: `${json.level} == "debug"`,
+										Then: []filter.FilterXStatement{
+											{Unset: &filter.FilterXUnsetConfig{Fields: []string{"json.kubernetes.annotations", "json.kubernetes.labels"}}},
+										},
+										Else: []filter.FilterXStatement{
+											{Set: &filter.FilterXSetConfig{FieldName: "json.level", Expr: "upper(${json.level})"}},
+										},
+									}},
+								},
+							},
+						},
+						{
+							FilterX: &filter.FilterXConfig{
+								Code: "${json.level} = upper(${json.level});\n${json.level} != \"DEBUG\";\n",
+							},
+						},
+					},
+				},
+			},
+			expected: Untab(`log {
+source("test_input");
+filterx {
+${json.kubernetes.cluster} = "prod \"us\"";
+if (isset(${json.log})) {
+${json.message} = ${json.log};
+unset(${json.log});
+};
+if (${json.level} == "debug") {
+unset(${json.kubernetes.annotations}, ${json.kubernetes.labels});
+} else {
+${json.level} = upper(${json.level});
+};
+};
+filterx {
+${json.level} = upper(${json.level});
+${json.level} != "DEBUG";
+};
+};
 `),
 		},
 	}
@@ -101,10 +324,133 @@ parser("clusterflow_test_ns_test_clusterflow_filters_0");
 		testCase := testCase
 		t.Run(name, func(t *testing.T) {
 			out := strings.Builder{}
-			require.NoError(t, renderClusterFlow("", nil, "test_input", testCase.clusterFlow, &TestSecretLoaderFactory{})(render.RenderContext{
+			require.NoError(t, renderClusterFlow("", nil, "test_input", ".", testCase.clusterFlow, &TestSecretLoaderFactory{})(render.RenderContext{
 				Out: &out,
 			}))
 			assert.Equal(t, testCase.expected, out.String())
 		})
 	}
 }
+
+func TestRenderClusterFlowFilterXProblems(t *testing.T) {
+	for name, testCase := range map[string]struct {
+		filterx filter.FilterXConfig
+		err     string
+	}{
+		"empty": {
+			err: "either code or statements must be specified",
+		},
+		"code and statements": {
+			filterx: filter.FilterXConfig{
+				Code:       "${json.level} != \"debug\";",
+				Statements: []filter.FilterXStatement{{Unset: &filter.FilterXUnsetConfig{Fields: []string{"json.level"}}}},
+			},
+			err: "code and statements are mutually exclusive",
+		},
+		"multiple statements": {
+			filterx: filter.FilterXConfig{
+				Statements: []filter.FilterXStatement{{
+					Set:   &filter.FilterXSetConfig{FieldName: "json.level", Value: "info"},
+					Unset: &filter.FilterXUnsetConfig{Fields: []string{"json.level"}},
+				}},
+			},
+			err: "statement 0: multiple statements ([set unset]) specified",
+		},
+		"nested invalid field": {
+			filterx: filter.FilterXConfig{
+				Statements: []filter.FilterXStatement{{If: &filter.FilterXConditionalConfig{
+					Condition: "isset(${json.level})",
+					Then:      []filter.FilterXStatement{{Rename: &filter.FilterXRenameConfig{OldName: "json.level", NewName: "json}"}}},
+				}}},
+			},
+			err: `statement 0: statement 0: invalid field name "json}"`,
+		},
+	} {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			clusterFlow := v1beta1.SyslogNGClusterFlow{
+				ObjectMeta: v1.ObjectMeta{Name: "test_clusterflow", Namespace: "test_ns"},
+				Spec: v1beta1.SyslogNGClusterFlowSpec{
+					Filters: []v1beta1.SyslogNGFilter{{FilterX: &testCase.filterx}},
+				},
+			}
+			out := strings.Builder{}
+			err := renderClusterFlow("", nil, "test_input", ".", clusterFlow, &TestSecretLoaderFactory{})(render.RenderContext{Out: &out})
+			assert.EqualError(t, err, "invalid filterx filter clusterflow_test_ns_test_clusterflow_filters_0: "+testCase.err)
+		})
+	}
+}
+
+func TestRenderClusterFlowRedactCreditCard(t *testing.T) {
+	testCases := map[string]struct {
+		redact   filter.RedactConfig
+		expected string
+	}{
+		"with other detectors": {
+			redact: filter.RedactConfig{Detectors: []string{"email", "credit_card"}},
+			expected: Untab(`parser "clusterflow_test_ns_test_clusterflow_filters_0_credit_card" {
+	python(class("RedactCreditCard") options("action" "mask" "fields" "[\"MESSAGE\"]" "marker" ".redact.credit_card." "mask" "[REDACTED]" "pattern" "\\b(?:\\d[ -]?){12,18}\\d\\b" "salt" "" "scope" "substring"));
+};
+rewrite "clusterflow_test_ns_test_clusterflow_filters_0" {
+	subst("(?:[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\\.[A-Za-z]{2,})" "[REDACTED]" value("MESSAGE") flags("global"));
+};
+log {
+	source("test_input");
+	parser("clusterflow_test_ns_test_clusterflow_filters_0_credit_card");
+	rewrite("clusterflow_test_ns_test_clusterflow_filters_0");
+};
+`),
+		},
+		"dropping fields": {
+			redact: filter.RedactConfig{Detectors: []string{"credit_card"}, Fields: []string{"json.message", "json.card"}, Action: "drop", Scope: "field"},
+			expected: Untab(`parser "clusterflow_test_ns_test_clusterflow_filters_0_credit_card" {
+	python(class("RedactCreditCard") options("action" "drop" "fields" "[\"json.message\",\"json.card\"]" "marker" ".redact.credit_card." "mask" "[REDACTED]" "pattern" "\\b(?:\\d[ -]?){12,18}\\d\\b" "salt" "" "scope" "field"));
+};
+rewrite "clusterflow_test_ns_test_clusterflow_filters_0" {
+	unset(value("json.message") condition(match("^1$" value(".redact.credit_card.json.message"))));
+	unset(value(".redact.credit_card.json.message"));
+	unset(value("json.card") condition(match("^1$" value(".redact.credit_card.json.card"))));
+	unset(value(".redact.credit_card.json.card"));
+};
+log {
+	source("test_input");
+	parser("clusterflow_test_ns_test_clusterflow_filters_0_credit_card");
+	rewrite("clusterflow_test_ns_test_clusterflow_filters_0");
+};
+`),
+		},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			clusterFlow := v1beta1.SyslogNGClusterFlow{
+				ObjectMeta: v1.ObjectMeta{Name: "test_clusterflow", Namespace: "test_ns"},
+				Spec: v1beta1.SyslogNGClusterFlowSpec{
+					Filters: []v1beta1.SyslogNGFilter{{Redact: &testCase.redact}},
+				},
+			}
+			out := strings.Builder{}
+			require.NoError(t, renderClusterFlow("", nil, "test_input", ".", clusterFlow, &TestSecretLoaderFactory{})(render.RenderContext{Out: &out}))
+			assert.Equal(t, testCase.expected, out.String())
+		})
+	}
+}
+
+func TestRenderClusterFlowRedactMountedHashSalt(t *testing.T) {
+	clusterFlow := v1beta1.SyslogNGClusterFlow{
+		ObjectMeta: v1.ObjectMeta{Name: "test_clusterflow", Namespace: "test_ns"},
+		Spec: v1beta1.SyslogNGClusterFlowSpec{
+			Filters: []v1beta1.SyslogNGFilter{{Redact: &filter.RedactConfig{
+				Detectors: []string{"email"},
+				Action:    "hash",
+				HashSalt: &secret.Secret{MountFrom: &secret.ValueFrom{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "redact"},
+					Key:                  "salt",
+				}}},
+			}}},
+		},
+	}
+	out := strings.Builder{}
+	err := renderClusterFlow("", nil, "test_input", ".", clusterFlow, &TestSecretLoaderFactory{})(render.RenderContext{Out: &out})
+	assert.ErrorContains(t, err, "hash_salt can't be mounted from a secret")
+}
@@ -58,6 +58,11 @@ func renderOutputSpec(spec v1beta1.SyslogNGOutputSpec, destName string, output m
 	case 1:
 		driverField := driverFields[0]
 		defaultPersistName(driverField.Value, destName) // HACK: defaulting should be done properly
+		if v, _ := driverField.Value.Interface().(interface{ Validate() error }); v != nil {
+			if err := v.Validate(); err != nil {
+				return render.Error(fmt.Errorf("invalid %s destination on output %s/%s: %w", driverField.Meta.Name, output.GetNamespace(), output.GetName(), err))
+			}
+		}
 		if br, _ := driverField.Value.Interface().(interface{ BeforeRender() }); br != nil {
 			br.BeforeRender()
 		}
@@ -0,0 +1,160 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/cisco-open/operator-tools/pkg/utils"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/output"
+)
+
+func TestClickHouseOutput(t *testing.T) {
+	expectedConfig := config.Untab(`@version: current
+
+@include "scl.conf"
+
+source "main_input" {
+	channel {
+		source {
+			network(flags("no-parse") port(601) transport("tcp"));
+		};
+		parser {
+			json-parser(prefix("json."));
+		};
+	};
+};
+
+destination "output_default_test-clickhouse-out" {
+	http(url("http://clickhouse:8123?async_insert=1&date_time_input_format=best_effort&query=INSERT+INTO+logs.kubernetes+FORMAT+JSONEachRow") batch-lines(1000) batch-timeout(5000) body("$(format-json timestamp=${ISODATE} app=${json.kubernetes.labels.app} message=${json.message})") method("POST") user("logger") password("V2tsn88GhdNTKxaS") persist_name("output_default_test-clickhouse-out"));
+};
+`)
+
+	testCaseInput := config.Input{
+		Namespace:      "config-test",
+		Name:           "test",
+		SyslogNGSpec:   &v1beta1.SyslogNGSpec{},
+		ClusterOutputs: []v1beta1.SyslogNGClusterOutput{},
+		ClusterFlows:   []v1beta1.SyslogNGClusterFlow{},
+		Flows:          []v1beta1.SyslogNGFlow{},
+		SourcePort:     601,
+		SecretLoaderFactory: &config.TestSecretLoaderFactory{
+			Reader: config.SecretReader{
+				Secrets: []corev1.Secret{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "clickhouse",
+						},
+						Data: map[string][]byte{
+							"password": []byte("V2tsn88GhdNTKxaS"),
+						},
+					},
+				},
+			},
+			MountPath: "/etc/syslog-ng/secret",
+		},
+		Outputs: []v1beta1.SyslogNGOutput{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "test-clickhouse-out",
+				},
+				Spec: v1beta1.SyslogNGOutputSpec{
+					ClickHouse: &output.ClickHouseOutput{
+						HTTPOutput: output.HTTPOutput{
+							URL:  "http://clickhouse:8123",
+							User: "logger",
+							Password: secret.Secret{
+								ValueFrom: &secret.ValueFrom{
+									SecretKeyRef: &corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: "clickhouse",
+										},
+										Key: "password",
+									},
+								}},
+							Batch: output.Batch{
+								BatchLines:   1000,
+								BatchTimeout: 5000,
+							},
+						},
+						Database: "logs",
+						Table:    "kubernetes",
+						Columns: map[string]string{
+							"message": "${json.message}",
+							"app":     "${json.kubernetes.labels.app}",
+						},
+						AsyncInsert: utils.BoolPointer(true),
+					},
+				},
+			},
+		},
+	}
+
+	var buf strings.Builder
+	err := config.RenderConfigInto(testCaseInput, &buf)
+	config.CheckError(t, false, err)
+	require.Equal(t, expectedConfig, buf.String())
+}
+
+func TestClickHouseOutputProblems(t *testing.T) {
+	for name, testCase := range map[string]struct {
+		clickhouse output.ClickHouseOutput
+		err        string
+	}{
+		"missing table": {
+			clickhouse: output.ClickHouseOutput{HTTPOutput: output.HTTPOutput{URL: "http://clickhouse:8123"}},
+			err:        "table is required",
+		},
+		"invalid table": {
+			clickhouse: output.ClickHouseOutput{HTTPOutput: output.HTTPOutput{URL: "http://clickhouse:8123"}, Table: "logs; DROP TABLE logs"},
+			err:        `invalid database or table name "logs; DROP TABLE logs"`,
+		},
+		"invalid database": {
+			clickhouse: output.ClickHouseOutput{HTTPOutput: output.HTTPOutput{URL: "http://clickhouse:8123"}, Database: "1logs", Table: "logs"},
+			err:        `invalid database or table name "1logs"`,
+		},
+		"invalid url": {
+			clickhouse: output.ClickHouseOutput{HTTPOutput: output.HTTPOutput{URL: "http://clickhouse:port"}, Table: "logs"},
+			err:        "invalid url",
+		},
+	} {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			input := config.Input{
+				Name:                "test",
+				SyslogNGSpec:        &v1beta1.SyslogNGSpec{},
+				SourcePort:          601,
+				SecretLoaderFactory: &config.TestSecretLoaderFactory{},
+				Outputs: []v1beta1.SyslogNGOutput{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-clickhouse-out"},
+					Spec:       v1beta1.SyslogNGOutputSpec{ClickHouse: &testCase.clickhouse},
+				}},
+			}
+			var buf strings.Builder
+			err := config.RenderConfigInto(input, &buf)
+			require.ErrorContains(t, err, "invalid ClickHouse destination on output default/test-clickhouse-out: "+testCase.err)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/output"
+)
+
+func TestKafkaOutput(t *testing.T) {
+	config.CheckConfigForOutput(t,
+		v1beta1.SyslogNGOutput{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-kafka-out",
+			},
+			Spec: v1beta1.SyslogNGOutputSpec{
+				Kafka: &output.KafkaOutput{
+					BootstrapServers: "kafka-0:9092,kafka-1:9092",
+					Topic:            "logs-${json.kubernetes.namespace_name}",
+					FallbackTopic:    "logs",
+					Key:              "${json.kubernetes.pod_name}",
+					Message:          "$(format-json --subkeys json.)",
+					Config: map[string]string{
+						"compression.type": "zstd",
+						"linger.ms":        "100",
+					},
+					SyncSend: config.NewTrue(),
+					Workers:  2,
+					DiskBuffer: &output.DiskBuffer{
+						DiskBufSize: 512000000,
+						Reliable:    true,
+					},
+				},
+			},
+		},
+		`
+destination "output_default_test-kafka-out" {
+	kafka-c(bootstrap-servers("kafka-0:9092,kafka-1:9092") topic("logs-${json.kubernetes.namespace_name}") fallback-topic("logs") key("${json.kubernetes.pod_name}") message("$(format-json --subkeys json.)") config(
+		"compression.type" => "zstd"
+		"linger.ms" => "100"
+	) sync-send(yes) workers(2) disk_buffer(disk_buf_size(512000000) reliable(yes)) persist_name("output_default_test-kafka-out"));
+};
+`,
+	)
+}
+
+func TestKafkaOutputWithSASLAndTLS(t *testing.T) {
+	expectedConfig := config.Untab(`@version: current
+
+@include "scl.conf"
+
+source "main_input" {
+	channel {
+		source {
+			network(flags("no-parse") port(601) transport("tcp"));
+		};
+		parser {
+			json-parser(prefix("json."));
+		};
+	};
+};
+
+destination "output_default_test-kafka-out" {
+	kafka-c(bootstrap-servers("kafka:9093") topic("logs") config(
+		"sasl.mechanism" => "SCRAM-SHA-512"
+		"sasl.password" => "kafka-pwd"
+		"sasl.username" => "logger"
+		"security.protocol" => "SSL"
+		"ssl.ca.location" => "/etc/syslog-ng/secret/default-kafka-tls-ca.crt"
+	) persist_name("output_default_test-kafka-out"));
+};
+`)
+
+	secretKeyRef := func(name, key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: name,
+			},
+			Key: key,
+		}
+	}
+	testCaseInput := config.Input{
+		SyslogNGSpec:   &v1beta1.SyslogNGSpec{},
+		Namespace:      "config-test",
+		Name:           "test",
+		ClusterOutputs: []v1beta1.SyslogNGClusterOutput{},
+		ClusterFlows:   []v1beta1.SyslogNGClusterFlow{},
+		Flows:          []v1beta1.SyslogNGFlow{},
+		SourcePort:     601,
+		SecretLoaderFactory: &config.TestSecretLoaderFactory{
+			Reader: config.SecretReader{
+				Secrets: []corev1.Secret{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "kafka",
+						},
+						Data: map[string][]byte{
+							"username": []byte("logger"),
+							"password": []byte("kafka-pwd"),
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "kafka-tls",
+						},
+						Data: map[string][]byte{
+							"ca.crt": []byte("ca"),
+						},
+					},
+				},
+			},
+			MountPath: "/etc/syslog-ng/secret",
+		},
+		Outputs: []v1beta1.SyslogNGOutput{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "test-kafka-out",
+				},
+				Spec: v1beta1.SyslogNGOutputSpec{
+					Kafka: &output.KafkaOutput{
+						BootstrapServers: "kafka:9093",
+						Topic:            "logs",
+						// explicit properties take precedence over the derived ones
+						Config: map[string]string{
+							"security.protocol": "SSL",
+						},
+						SASL: &output.KafkaSASL{
+							Mechanism: "SCRAM-SHA-512",
+							Username:  &secret.Secret{ValueFrom: &secret.ValueFrom{SecretKeyRef: secretKeyRef("kafka", "username")}},
+							Password:  &secret.Secret{ValueFrom: &secret.ValueFrom{SecretKeyRef: secretKeyRef("kafka", "password")}},
+						},
+						TLS: &output.KafkaTLS{
+							CaFile: &secret.Secret{MountFrom: &secret.ValueFrom{SecretKeyRef: secretKeyRef("kafka-tls", "ca.crt")}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var buf strings.Builder
+	err := config.RenderConfigInto(testCaseInput, &buf)
+	config.CheckError(t, false, err)
+	require.Equal(t, expectedConfig, buf.String())
+}
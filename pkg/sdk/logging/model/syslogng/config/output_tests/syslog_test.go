@@ -0,0 +1,110 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/output"
+)
+
+func TestSyslogOutputWithFailover(t *testing.T) {
+	config.CheckConfigForOutput(t,
+		v1beta1.SyslogNGOutput{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-syslog-out",
+			},
+			Spec: v1beta1.SyslogNGOutputSpec{
+				Syslog: &output.SyslogOutput{
+					Host:      "siem-active",
+					Port:      601,
+					Transport: "tcp",
+					Failover: &output.Failover{
+						Servers: []string{"siem-standby", "siem-backup"},
+						Failback: &output.Failback{
+							TCPProbeInterval:         30,
+							SuccessfulProbesRequired: 3,
+						},
+					},
+					DiskBuffer: &output.DiskBuffer{
+						DiskBufSize: 512000000,
+						Reliable:    true,
+					},
+				},
+			},
+		},
+		`
+destination "output_default_test-syslog-out" {
+	syslog("siem-active" port(601) transport("tcp") failover(servers("siem-standby" "siem-backup") failback(tcp-probe-interval(30) successful-probes-required(3))) disk_buffer(disk_buf_size(512000000) reliable(yes)) persist_name("output_default_test-syslog-out"));
+};
+`,
+	)
+}
+
+func TestSyslogOutputWithFailoverWithoutFailback(t *testing.T) {
+	config.CheckConfigForOutput(t,
+		v1beta1.SyslogNGOutput{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-syslog-out",
+			},
+			Spec: v1beta1.SyslogNGOutputSpec{
+				Syslog: &output.SyslogOutput{
+					Host:      "siem-active",
+					Transport: "tls",
+					Failover: &output.Failover{
+						Servers: []string{"siem-standby"},
+					},
+				},
+			},
+		},
+		`
+destination "output_default_test-syslog-out" {
+	syslog("siem-active" transport("tls") failover(servers("siem-standby")) persist_name("output_default_test-syslog-out"));
+};
+`,
+	)
+}
+
+func TestSyslogOutputWithFailoverOverUDP(t *testing.T) {
+	input := config.Input{
+		Name:                "test",
+		SyslogNGSpec:        &v1beta1.SyslogNGSpec{},
+		SourcePort:          601,
+		SecretLoaderFactory: &config.TestSecretLoaderFactory{},
+		Outputs: []v1beta1.SyslogNGOutput{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-syslog-out"},
+			Spec: v1beta1.SyslogNGOutputSpec{
+				Syslog: &output.SyslogOutput{
+					Host:      "siem-active",
+					Transport: "udp",
+					Failover: &output.Failover{
+						Servers: []string{"siem-standby"},
+					},
+				},
+			},
+		}},
+	}
+	var buf strings.Builder
+	err := config.RenderConfigInto(input, &buf)
+	require.ErrorContains(t, err, "invalid Syslog destination on output default/test-syslog-out: failover is not supported with the udp transport")
+}
@@ -0,0 +1,96 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/siliconbrain/go-seqs/seqs"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config/model"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config/render"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/filter"
+)
+
+const (
+	// QuotaRecordsMetric counts the records of the namespaces limited by a quota
+	QuotaRecordsMetric = "syslogng_quota_records_total"
+	// QuotaAcceptedRecordsMetric counts the records of the namespaces that are within the quota
+	QuotaAcceptedRecordsMetric = "syslogng_quota_accepted_records_total"
+)
+
+// Quota limits the rate of the records each of its namespaces can send
+type Quota struct {
+	Name             string
+	Namespaces       []string
+	RecordsPerSecond int64
+}
+
+// quotaTransforms returns the statements enforcing the quotas on the records of the source.
+// Records of the namespaces are counted before and after the limits when metrics are enabled.
+func quotaTransforms(quotas []Quota, keyDelim string, metrics bool, logging string) []render.Renderer {
+	quotas = seqs.ToSlice(seqs.Filter(seqs.FromSlice(quotas), func(q Quota) bool {
+		return len(q.Namespaces) > 0 && q.RecordsPerSecond > 0
+	}))
+	if len(quotas) == 0 {
+		return nil
+	}
+
+	namespaceKey := strings.Join([]string{"json", "kubernetes", "namespace_name"}, keyDelim)
+	probe := func(metric string) render.Renderer {
+		if !metrics {
+			return nil
+		}
+		return parserDefStmt("", renderDriver(Field{
+			Value: reflect.ValueOf(filter.MetricsProbe{
+				Key: strings.TrimPrefix(metric, "syslogng_"),
+				Labels: filter.ArrowMap{
+					"logging":   logging,
+					"namespace": fmt.Sprintf("${%s}", namespaceKey),
+				},
+			}),
+		}, nil))
+	}
+
+	transforms := []render.Renderer{probe(QuotaRecordsMetric)}
+	for _, quota := range quotas {
+		transforms = append(transforms, filterDefStmt("", quotaFilterStmt(quota, namespaceKey)))
+	}
+	transforms = append(transforms, probe(QuotaAcceptedRecordsMetric))
+	return seqs.ToSlice(seqs.Filter(seqs.FromSlice(transforms), func(rnd render.Renderer) bool { return rnd != nil }))
+}
+
+// quotaFilterStmt lets the records of other namespaces through and rate limits the records of the quota's namespaces
+func quotaFilterStmt(quota Quota, namespaceKey string) render.Renderer {
+	var namespaces model.FilterExprOr
+	for _, ns := range quota.Namespaces {
+		namespaces = append(namespaces, model.NewFilterExpr(model.FilterExprMatch{
+			Pattern: ns,
+			Scope:   model.NewFilterExprMatchScope(model.FilterExprMatchScopeValue(namespaceKey)),
+			Type:    "string",
+		}))
+	}
+	return render.Line(render.AllOf(
+		filterExpr(model.NewFilterExpr(model.FilterExprNot{Expr: model.NewFilterExpr(namespaces)})),
+		render.String(" or "),
+		optionExpr("rate-limit",
+			optionExpr("template", render.Quoted(fmt.Sprintf("${%s}", namespaceKey))),
+			optionExpr("rate", render.Literal(int(quota.RecordsPerSecond))),
+		),
+		render.String(";"),
+	))
+}
@@ -0,0 +1,297 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"github.com/siliconbrain/go-seqs/seqs"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/redact"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config/render"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/filter"
+)
+
+const (
+	// redactCreditCardClass is the python parser redacting the credit card numbers that pass the Luhn check
+	redactCreditCardClass = "RedactCreditCard"
+	// redactDropMarker is prepended to the fields the python parser found a credit card number in, when they have to be dropped.
+	// Dropping happens in the rewrite rules, the dot-prefixed name-value pairs are not part of the formatted records.
+	redactDropMarker = ".redact.credit_card."
+)
+
+// redactCreditCardPython is the code of the python parser redacting the credit card numbers.
+// It is rendered without braces, since the block ends at the first closing brace of the configuration.
+const redactCreditCardPython = `import hashlib
+import json
+import re
+
+
+class RedactCreditCard(object):
+    def init(self, options):
+        self.pattern = re.compile(options["pattern"])
+        self.fields = json.loads(options["fields"])
+        self.action = options["action"]
+        self.scope = options["scope"]
+        self.mask = options["mask"]
+        self.salt = options["salt"]
+        self.marker = options["marker"]
+        return True
+
+    def deinit(self):
+        pass
+
+    @staticmethod
+    def luhn(number):
+        total = 0
+        for i, digit in enumerate(int(d) for d in reversed(number) if d.isdigit()):
+            if i % 2 == 1:
+                digit *= 2
+                if digit > 9:
+                    digit -= 9
+            total += digit
+        return total % 10 == 0
+
+    def replace(self, value):
+        if self.action == "hash":
+            return hashlib.sha256((self.salt + value).encode("utf-8")).hexdigest()
+        if self.action == "drop":
+            return ""
+        return self.mask
+
+    def redact(self, match):
+        number = match.group(0)
+        return self.replace(number) if self.luhn(number) else number
+
+    def parse(self, msg):
+        for field in self.fields:
+            try:
+                value = msg[field]
+            except KeyError:
+                continue
+            if isinstance(value, bytes):
+                value = value.decode("utf-8", "replace")
+            if not isinstance(value, str):
+                continue
+            if self.scope == "field":
+                if any(self.luhn(m.group(0)) for m in self.pattern.finditer(value)):
+                    if self.action == "drop":
+                        msg[self.marker + field] = "1"
+                    else:
+                        msg[field] = self.replace(value)
+                continue
+            redacted = self.pattern.sub(self.redact, value)
+            if redacted != value:
+                msg[field] = redacted
+        return True
+`
+
+// renderRedactDefs returns the python parser redacting the credit card numbers and the rewrite redacting everything else.
+// The credit card numbers are validated with the Luhn algorithm, which can't be expressed as a rewrite rule.
+func renderRedactDefs(cfg filter.RedactConfig, key string, filterID string, flow metav1.Object, secretLoader secret.SecretLoader) render.Renderer {
+	if err := redact.Validate(cfg.Detectors, cfg.Patterns, cfg.Action, cfg.Scope); err != nil {
+		return render.Error(errors.WrapIff(err, "invalid redact filter %s of flow %s/%s", filterID, flow.GetNamespace(), flow.GetName()))
+	}
+	salt, err := redact.LoadHashSalt(cfg.HashSalt, secretLoader)
+	if err != nil {
+		return render.Error(errors.WrapIff(err, "filter %s of flow %s/%s", filterID, flow.GetNamespace(), flow.GetName()))
+	}
+
+	var defs []render.Renderer
+	if redactsCreditCards(cfg) {
+		pattern, err := redact.Pattern(redact.CreditCard)
+		if err != nil {
+			return render.Error(err)
+		}
+		fields, err := json.Marshal(redactFields(cfg))
+		if err != nil {
+			return render.Error(errors.WrapIf(err, "failed to marshal the fields of the redact filter"))
+		}
+		options := [][2]string{
+			{"action", redactAction(cfg)},
+			{"fields", string(fields)},
+			{"marker", redactDropMarker},
+			{"mask", redactMask(cfg)},
+			{"pattern", pattern},
+			{"salt", salt},
+			{"scope", redactScope(cfg)},
+		}
+		var optionArgs []render.Renderer
+		for _, option := range options {
+			optionArgs = append(optionArgs, render.Quoted(option[0]), render.Quoted(option[1]))
+		}
+		defs = append(defs, parserDefStmt(redactCreditCardParserID(filterID), parenDefStmt("python",
+			optionExpr("class", render.Quoted(redactCreditCardClass)),
+			optionExpr("options", optionArgs...),
+		)))
+	}
+	if rules := redactRewriteRules(cfg, salt); len(rules) > 0 {
+		defs = append(defs, rewriteDefStmt(filterID, render.AllFrom(seqs.Map(seqs.FromSlice(rules), func(rule filter.RewriteConfig) render.Renderer {
+			return renderRewriteDriver(reflect.ValueOf(rule), key, filterID, flow, secretLoader)
+		}))))
+	}
+	return render.AllOf(defs...)
+}
+
+// renderRedactRefs returns the references of the definitions of the redact filter in the log path
+func renderRedactRefs(cfg filter.RedactConfig, filterID string) render.Renderer {
+	return render.AllOf(
+		render.If(redactsCreditCards(cfg), parenDefStmt("parser", render.Literal(redactCreditCardParserID(filterID)))),
+		render.If(len(redactRewritePatterns(cfg)) > 0 || redactDropsFields(cfg) && redactsCreditCards(cfg), parenDefStmt("rewrite", render.Literal(filterID))),
+	)
+}
+
+func redactCreditCardParserID(filterID string) string {
+	return filterID + "_credit_card"
+}
+
+// redactRewriteRules returns the rewrite rules redacting the fields of the record,
+// and dropping the fields the python parser found a credit card number in
+func redactRewriteRules(cfg filter.RedactConfig, salt string) []filter.RewriteConfig {
+	// hash returns the template of the salted hash of the value
+	hash := func(value string) string {
+		return fmt.Sprintf(`$(sha256 "%s%s")`, strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", "$$").Replace(salt), value)
+	}
+	patterns := redactRewritePatterns(cfg)
+	pattern := redact.Alternation(patterns)
+
+	var rules []filter.RewriteConfig
+	for _, field := range redactFields(cfg) {
+		if redactDropsFields(cfg) && redactsCreditCards(cfg) {
+			marker := redactDropMarker + field
+			rules = append(rules,
+				filter.RewriteConfig{Unset: &filter.UnsetConfig{FieldName: field, Condition: &filter.MatchExpr{Regexp: &filter.RegexpMatchExpr{Pattern: "^1$", Value: marker}}}},
+				filter.RewriteConfig{Unset: &filter.UnsetConfig{FieldName: marker}},
+			)
+		}
+		if len(patterns) == 0 {
+			continue
+		}
+
+		if cfg.Scope == redact.ScopeField {
+			condition := &filter.MatchExpr{Regexp: &filter.RegexpMatchExpr{Pattern: pattern, Value: field}}
+			switch cfg.Action {
+			case redact.ActionDrop:
+				rules = append(rules, filter.RewriteConfig{Unset: &filter.UnsetConfig{FieldName: field, Condition: condition}})
+			case redact.ActionHash:
+				rules = append(rules, filter.RewriteConfig{Set: &filter.SetConfig{FieldName: field, Value: hash(fmt.Sprintf("${%s}", field)), Condition: condition}})
+			default:
+				rules = append(rules, filter.RewriteConfig{Set: &filter.SetConfig{FieldName: field, Value: templateEscape(redactMask(cfg)), Condition: condition}})
+			}
+			continue
+		}
+
+		var replacement string
+		switch cfg.Action {
+		case redact.ActionDrop:
+		case redact.ActionHash:
+			replacement = hash("$0")
+		default:
+			replacement = templateEscape(redactMask(cfg))
+		}
+		rules = append(rules, filter.RewriteConfig{Substitute: &filter.SubstituteConfig{
+			Pattern:     pattern,
+			Replacement: replacement,
+			FieldName:   field,
+			Flags:       []string{"global"},
+		}})
+	}
+	return rules
+}
+
+// redactRewritePatterns returns the patterns redacted by the rewrite rules, every detector except the credit cards and the custom patterns
+func redactRewritePatterns(cfg filter.RedactConfig) []string {
+	var patterns []string
+	for _, d := range cfg.Detectors {
+		if d == redact.CreditCard {
+			continue
+		}
+		// the detectors are validated already
+		pattern, _ := redact.Pattern(d)
+		patterns = append(patterns, pattern)
+	}
+	return append(patterns, cfg.Patterns...)
+}
+
+func redactsCreditCards(cfg filter.RedactConfig) bool {
+	return slices.Contains(cfg.Detectors, redact.CreditCard)
+}
+
+func redactDropsFields(cfg filter.RedactConfig) bool {
+	return cfg.Scope == redact.ScopeField && cfg.Action == redact.ActionDrop
+}
+
+func redactFields(cfg filter.RedactConfig) []string {
+	if len(cfg.Fields) == 0 {
+		return []string{"MESSAGE"}
+	}
+	return cfg.Fields
+}
+
+func redactAction(cfg filter.RedactConfig) string {
+	if cfg.Action == "" {
+		return redact.ActionMask
+	}
+	return cfg.Action
+}
+
+func redactScope(cfg filter.RedactConfig) string {
+	if cfg.Scope == "" {
+		return redact.ScopeSubstring
+	}
+	return cfg.Scope
+}
+
+func redactMask(cfg filter.RedactConfig) string {
+	if cfg.Mask == "" {
+		return redact.DefaultMask
+	}
+	return cfg.Mask
+}
+
+// pythonDefStmt returns a python block, the code is not indented, since indentation is significant in python
+func pythonDefStmt(code string) render.Renderer {
+	return render.AllOf(
+		render.Line(render.String("python {")),
+		render.AllFrom(seqs.Map(seqs.FromSlice(strings.Split(strings.TrimSuffix(code, "\n"), "\n")), func(line string) render.Renderer {
+			return render.AllOf(render.String(line), render.NewLine)
+		})),
+		render.Line(render.String("};")),
+	)
+}
+
+// redactsCreditCardsInFlows returns true if a filter of the flows redacts credit card numbers, so the python parser is needed
+func redactsCreditCardsInFlows(clusterFlows []v1beta1.SyslogNGClusterFlow, flows []v1beta1.SyslogNGFlow) bool {
+	filters := func(filters []v1beta1.SyslogNGFilter) bool {
+		return slices.ContainsFunc(filters, func(flt v1beta1.SyslogNGFilter) bool {
+			return flt.Redact != nil && redactsCreditCards(*flt.Redact)
+		})
+	}
+	return slices.ContainsFunc(clusterFlows, func(f v1beta1.SyslogNGClusterFlow) bool { return filters(f.Spec.Filters) }) ||
+		slices.ContainsFunc(flows, func(f v1beta1.SyslogNGFlow) bool { return filters(f.Spec.Filters) })
+}
+
+// templateEscape escapes the macro references of a literal value used as a template
+func templateEscape(s string) string {
+	return strings.ReplaceAll(s, "$", "$$")
+}
@@ -0,0 +1,102 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/siliconbrain/go-seqs/seqs"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/sample"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config/render"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/filter"
+)
+
+const (
+	// the dot-prefixed name-value pairs are not part of the formatted records
+	sampleBucketField   = ".sample.bucket"
+	sampleDecisionField = ".sample.decision"
+	sampledRecordsKey   = "sampled_records"
+)
+
+// renderSampleDefs returns the rewrite deciding, the parser counting and the filter applying the sampling decision.
+// The decision is stored, because the random bucket must be evaluated only once.
+func renderSampleDefs(cfg filter.SampleConfig, key string, filterID string, keyDelim string, flow metav1.Object) render.Renderer {
+	if err := validateSample(cfg); err != nil {
+		return render.Error(errors.WrapIff(err, "invalid sample filter %s", filterID))
+	}
+
+	bucket := "$(uuid)"
+	if cfg.Key != "" {
+		bucket = fmt.Sprintf(`$(if ("${%[1]s}" ne "") "${%[1]s}" "$(uuid)")`, cfg.Key)
+	}
+	keep := filter.MatchExpr{Regexp: &filter.RegexpMatchExpr{Pattern: sample.BucketPattern(cfg.Percentage), Value: sampleBucketField}}
+	if len(cfg.AlwaysKeep) > 0 {
+		keep = filter.MatchExpr{Or: []filter.MatchExpr{keep}}
+		for _, rule := range cfg.AlwaysKeep {
+			keep.Or = append(keep.Or, filter.MatchExpr{Regexp: &filter.RegexpMatchExpr{Pattern: rule.Pattern, Value: rule.Field}})
+		}
+	}
+	rules := []filter.RewriteConfig{
+		{Set: &filter.SetConfig{FieldName: sampleBucketField, Value: fmt.Sprintf("$(sha1 --length %d %s)", sample.BucketDigits, bucket)}},
+		{Set: &filter.SetConfig{FieldName: sampleDecisionField, Value: sample.DecisionDropped}},
+		{Set: &filter.SetConfig{FieldName: sampleDecisionField, Value: sample.DecisionKept, Condition: &keep}},
+	}
+	probe := filter.MetricsProbe{
+		Key: sampledRecordsKey,
+		Labels: filter.ArrowMap{
+			"decision":  fmt.Sprintf("${%s}", sampleDecisionField),
+			"namespace": fmt.Sprintf("${%s}", strings.Join([]string{"json", "kubernetes", "namespace_name"}, keyDelim)),
+			"sampler":   filterID,
+		},
+	}
+
+	return render.AllOf(
+		rewriteDefStmt(filterID, render.AllFrom(seqs.Map(seqs.FromSlice(rules), func(rule filter.RewriteConfig) render.Renderer {
+			return renderRewriteDriver(reflect.ValueOf(rule), key, filterID, flow, nil)
+		}))),
+		parserDefStmt(filterID+"_metrics", renderDriver(Field{Value: reflect.ValueOf(probe)}, nil)),
+		filterDefStmt(filterID+"_keep", renderMatchExpr(filter.MatchExpr{Regexp: &filter.RegexpMatchExpr{
+			Pattern: fmt.Sprintf("^%s$", sample.DecisionKept),
+			Value:   sampleDecisionField,
+		}}, keyDelim)),
+	)
+}
+
+// renderSampleRefs returns the references of the sampling definitions in the log path
+func renderSampleRefs(filterID string) render.Renderer {
+	return render.AllOf(
+		parenDefStmt("rewrite", render.Literal(filterID)),
+		parenDefStmt("parser", render.Literal(filterID+"_metrics")),
+		filterRefStmt(filterID+"_keep"),
+	)
+}
+
+func validateSample(cfg filter.SampleConfig) error {
+	errs := sample.Validate(cfg.Percentage)
+	for _, rule := range cfg.AlwaysKeep {
+		if rule.Field == "" {
+			errs = errors.Append(errs, errors.New("alwaysKeep rule without field"))
+		}
+		if rule.Pattern == "" {
+			errs = errors.Append(errs, errors.Errorf("alwaysKeep rule on %q without pattern", rule.Field))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,93 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/maps/mapstrstr"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config/model"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/filter"
+)
+
+// selectorFilterExpr returns the conditions of the selector on the Kubernetes metadata of the record, all of them must match
+func selectorFilterExpr(sel filter.SelectorMatchExpr, keyDelim string) model.FilterExpr {
+	field := func(kind string, key string) string {
+		return strings.Join([]string{"json", "kubernetes", kind, key}, keyDelim)
+	}
+	equals := func(field string, value string) model.FilterExpr {
+		return model.NewFilterExpr(model.FilterExprMatch{
+			Pattern: value,
+			Scope:   model.NewFilterExprMatchScope(model.FilterExprMatchScopeValue(field)),
+			Type:    "string",
+		})
+	}
+	matches := func(field string, pattern string) model.FilterExpr {
+		return model.NewFilterExpr(model.FilterExprMatch{
+			Pattern: pattern,
+			Scope:   model.NewFilterExprMatchScope(model.FilterExprMatchScopeValue(field)),
+		})
+	}
+	anyOf := func(field string, values []string, match func(string, string) model.FilterExpr) model.FilterExpr {
+		var exprs model.FilterExprOr
+		for _, v := range values {
+			exprs = append(exprs, match(field, v))
+		}
+		if len(exprs) == 1 {
+			return exprs[0]
+		}
+		return model.NewFilterExpr(exprs)
+	}
+
+	var exprs model.FilterExprAnd
+	for _, key := range sortedKeys(sel.Labels) {
+		exprs = append(exprs, equals(field("labels", key), sel.Labels[key]))
+	}
+	for _, key := range sortedKeys(sel.Annotations) {
+		exprs = append(exprs, equals(field("annotations", key), sel.Annotations[key]))
+	}
+	for _, expr := range sel.LabelExpressions {
+		label := field("labels", expr.Key)
+		// a missing label renders as an empty value
+		exists := matches(label, ".")
+		switch expr.Operator {
+		case "In":
+			exprs = append(exprs, anyOf(label, expr.Values, equals))
+		case "NotIn":
+			exprs = append(exprs, model.NewFilterExpr(model.FilterExprNot{Expr: anyOf(label, expr.Values, equals)}))
+		case "Exists":
+			exprs = append(exprs, exists)
+		case "DoesNotExist":
+			exprs = append(exprs, model.NewFilterExpr(model.FilterExprNot{Expr: exists}))
+		case "Regex":
+			exprs = append(exprs, model.NewFilterExpr(model.FilterExprAnd{exists, anyOf(label, expr.Values, matches)}))
+		}
+	}
+	switch len(exprs) {
+	case 0:
+		return nil
+	case 1:
+		return exprs[0]
+	default:
+		return model.NewFilterExpr(exprs)
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := mapstrstr.Keys(m)
+	sort.Strings(keys)
+	return keys
+}
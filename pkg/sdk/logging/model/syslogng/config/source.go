@@ -15,19 +15,229 @@
 package config
 
 import (
+	"reflect"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/siliconbrain/go-seqs/seqs"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/syslogng/config/render"
 )
 
 type NetworkSourceDriver struct {
-	__meta         struct{} `syslog-ng:"name=network"` //lint:ignore U1000 field used for adding tag to the type
-	Flags          []string `syslog-ng:"name=flags,optional"`
-	IP             string   `syslog-ng:"name=ip,optional"`
-	Port           uint16   `syslog-ng:"name=port,optional"`
-	Transport      string   `syslog-ng:"name=transport,optional"`
-	MaxConnections int      `syslog-ng:"name=max-connections,optional"`
-	LogIWSize      int      `syslog-ng:"name=log-iw-size,optional"`
+	__meta         struct{}   `syslog-ng:"name=network"` //lint:ignore U1000 field used for adding tag to the type
+	Flags          []string   `syslog-ng:"name=flags,optional"`
+	IP             string     `syslog-ng:"name=ip,optional"`
+	Port           uint16     `syslog-ng:"name=port,optional"`
+	Transport      string     `syslog-ng:"name=transport,optional"`
+	MaxConnections int        `syslog-ng:"name=max-connections,optional"`
+	LogIWSize      int        `syslog-ng:"name=log-iw-size,optional"`
+	TLS            *SourceTLS `syslog-ng:"name=tls,optional"`
+}
+
+type SyslogSourceDriver struct {
+	__meta         struct{}   `syslog-ng:"name=syslog"` //lint:ignore U1000 field used for adding tag to the type
+	Flags          []string   `syslog-ng:"name=flags,optional"`
+	Port           uint16     `syslog-ng:"name=port,optional"`
+	Transport      string     `syslog-ng:"name=transport,optional"`
+	MaxConnections int        `syslog-ng:"name=max-connections,optional"`
+	TLS            *SourceTLS `syslog-ng:"name=tls,optional"`
+}
+
+type OpenTelemetrySourceDriver struct {
+	__meta             struct{}  `syslog-ng:"name=opentelemetry"` //lint:ignore U1000 field used for adding tag to the type
+	Port               uint16    `syslog-ng:"name=port,optional"`
+	ConcurrentRequests int       `syslog-ng:"name=concurrent-requests,optional"`
+	Auth               *GRPCAuth `syslog-ng:"name=auth,optional"`
+}
+
+type GRPCAuth struct {
+	TLS *SourceTLS `syslog-ng:"name=tls,optional"`
 }
 
+type WebhookSourceDriver struct {
+	__meta        struct{} `syslog-ng:"name=webhook"` //lint:ignore U1000 field used for adding tag to the type
+	Port          uint16   `syslog-ng:"name=port,optional"`
+	TLSKeyFile    string   `syslog-ng:"name=tls_key_file,optional"`
+	TLSCertFile   string   `syslog-ng:"name=tls_cert_file,optional"`
+	TLSCAFile     string   `syslog-ng:"name=tls_ca_file,optional"`
+	TLSPeerVerify bool     `syslog-ng:"name=tls_peer_verify,optional"`
+}
+
+type SourceTLS struct {
+	KeyFile    string `syslog-ng:"name=key-file,optional"`
+	CertFile   string `syslog-ng:"name=cert-file,optional"`
+	CAFile     string `syslog-ng:"name=ca-file,optional"`
+	PeerVerify string `syslog-ng:"name=peer-verify,optional"`
+}
+
+// sourceTLSDir is where the secret of the syslog-ng tls settings is mounted
+const sourceTLSDir = "/syslog-ng/tls/"
+
 func sourceDefStmt(name string, body render.Renderer) render.Renderer {
 	return braceDefStmt("source", name, body)
 }
+
+// sourceDefName returns the name of the definition of an additional source
+func sourceDefName(name string) string {
+	return name + "_input"
+}
+
+// flowSourceNames returns the definitions of the sources referenced by a cluster flow, the main source by default
+func flowSourceNames(mainSourceName string, sourceRefs []string) []string {
+	if len(sourceRefs) == 0 {
+		return []string{mainSourceName}
+	}
+	return seqs.ToSlice(seqs.Map(seqs.FromSlice(sourceRefs), func(ref string) string {
+		if ref == v1beta1.MainSourceName {
+			return mainSourceName
+		}
+		return sourceDefName(ref)
+	}))
+}
+
+func validateSourceRefs(spec *v1beta1.SyslogNGSpec, flow string, sourceRefs []string) (errs error) {
+	for _, ref := range sourceRefs {
+		if !spec.HasSource(ref) {
+			errs = errors.Append(errs, errors.Errorf("source reference %s for flow %s cannot be found", ref, flow))
+		}
+	}
+	return errs
+}
+
+// ValidSources returns the additional sources without errors, only their ports are exposed on the pods and the service
+func ValidSources(spec *v1beta1.SyslogNGSpec, mainPort int, reservedPorts map[int32]string) []v1beta1.SyslogNGSource {
+	var valid []v1beta1.SyslogNGSource
+	checkSources(spec, mainPort, reservedPorts, func(source v1beta1.SyslogNGSource, err error) {
+		if err == nil {
+			valid = append(valid, source)
+		}
+	})
+	return valid
+}
+
+func validateSources(spec *v1beta1.SyslogNGSpec, mainPort int, reservedPorts map[int32]string) (errs error) {
+	checkSources(spec, mainPort, reservedPorts, func(_ v1beta1.SyslogNGSource, err error) {
+		errs = errors.Append(errs, err)
+	})
+	return errs
+}
+
+// checkSources calls check with the errors of each additional source, the error is nil if the source is valid.
+// The reserved ports are used by something else than the sources in the syslog-ng pods.
+func checkSources(spec *v1beta1.SyslogNGSpec, mainPort int, reservedPorts map[int32]string, check func(source v1beta1.SyslogNGSource, err error)) {
+	names := make(map[string]bool, len(spec.Sources))
+	ports := map[int32]string{int32(mainPort): "source " + v1beta1.MainSourceName}
+	for port, user := range reservedPorts {
+		ports[port] = user
+	}
+	for _, source := range spec.Sources {
+		var errs error
+		if source.Name == v1beta1.MainSourceName || names[source.Name] {
+			errs = errors.Append(errs, errors.Errorf("source name %s is not unique", source.Name))
+		}
+		names[source.Name] = true
+		if user, ok := ports[source.Port]; ok {
+			errs = errors.Append(errs, errors.Errorf("port %d of source %s is already used by %s", source.Port, source.Name, user))
+		} else {
+			ports[source.Port] = "source " + source.Name
+		}
+
+		drivers := 0
+		var tls *v1beta1.SyslogNGSourceTLS
+		if source.Syslog != nil {
+			drivers++
+			tls = source.Syslog.TLS
+			if tls != nil && source.Syslog.Transport == "udp" {
+				errs = errors.Append(errs, errors.Errorf("source %s: tls is not supported over udp", source.Name))
+			}
+		}
+		if source.OpenTelemetry != nil {
+			drivers++
+			tls = source.OpenTelemetry.TLS
+		}
+		if source.HTTP != nil {
+			drivers++
+			tls = source.HTTP.TLS
+		}
+		if drivers != 1 {
+			errs = errors.Append(errs, errors.Errorf("source %s: exactly one of syslog, opentelemetry and http must be specified", source.Name))
+		}
+		if tls != nil && !spec.TLS.Enabled {
+			errs = errors.Append(errs, errors.Errorf("source %s uses tls, but tls is not enabled", source.Name))
+		}
+		check(source, errs)
+	}
+}
+
+// renderSources returns the definitions of the additional sources
+func renderSources(spec *v1beta1.SyslogNGSpec) []render.Renderer {
+	return seqs.ToSlice(seqs.Map(seqs.FromSlice(spec.Sources), func(source v1beta1.SyslogNGSource) render.Renderer {
+		return sourceDefStmt(sourceDefName(source.Name), renderDriver(Field{Value: reflect.ValueOf(sourceDriver(source))}, nil))
+	}))
+}
+
+func sourceDriver(source v1beta1.SyslogNGSource) any {
+	port := uint16(source.Port)
+	switch {
+	case source.Syslog != nil:
+		transport := source.Syslog.Transport
+		if transport == "" {
+			transport = "tcp"
+		}
+		tls := sourceTLS(source.Syslog.TLS)
+		if tls != nil {
+			transport = "tls"
+		}
+		// the network driver parses the BSD syslog format
+		if source.Syslog.Format == "rfc3164" {
+			return NetworkSourceDriver{
+				Flags:          source.Syslog.Flags,
+				Port:           port,
+				Transport:      transport,
+				MaxConnections: source.Syslog.MaxConnections,
+				TLS:            tls,
+			}
+		}
+		return SyslogSourceDriver{
+			Flags:          source.Syslog.Flags,
+			Port:           port,
+			Transport:      transport,
+			MaxConnections: source.Syslog.MaxConnections,
+			TLS:            tls,
+		}
+	case source.OpenTelemetry != nil:
+		driver := OpenTelemetrySourceDriver{
+			Port:               port,
+			ConcurrentRequests: source.OpenTelemetry.ConcurrentRequests,
+		}
+		if tls := sourceTLS(source.OpenTelemetry.TLS); tls != nil {
+			driver.Auth = &GRPCAuth{TLS: tls}
+		}
+		return driver
+	default:
+		driver := WebhookSourceDriver{Port: port}
+		if source.HTTP.TLS != nil {
+			driver.TLSKeyFile = sourceTLSDir + "tls.key"
+			driver.TLSCertFile = sourceTLSDir + "tls.crt"
+			if strings.HasPrefix(source.HTTP.TLS.PeerVerify, "required") {
+				driver.TLSCAFile = sourceTLSDir + "ca.crt"
+				driver.TLSPeerVerify = true
+			}
+		}
+		return driver
+	}
+}
+
+func sourceTLS(tls *v1beta1.SyslogNGSourceTLS) *SourceTLS {
+	if tls == nil {
+		return nil
+	}
+	return &SourceTLS{
+		KeyFile:    sourceTLSDir + "tls.key",
+		CertFile:   sourceTLSDir + "tls.crt",
+		CAFile:     sourceTLSDir + "ca.crt",
+		PeerVerify: tls.PeerVerify,
+	}
+}
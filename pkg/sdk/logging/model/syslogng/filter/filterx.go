@@ -0,0 +1,129 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+// +name:"FilterX"
+// +weight:"200"
+type _hugoFilterX interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+// +docName:"FilterX"
+/*
+Transform the records with a [FilterX](https://axoflow.com/docs/axosyslog-core/filterx/) block of AxoSyslog. FilterX can manipulate typed JSON values, dicts and lists, and evaluate conditions in a single block.
+
+The block is either raw FilterX `code`, or a list of typed `statements`:
+
+- `set` sets a field to a string `value`, or to the result of a FilterX expression in `expr`.
+- `unset` removes fields.
+- `rename` moves the value of a field to a new name, if the field exists.
+- `if` runs the `then` statements if the FilterX `condition` is true, and the `else` statements otherwise.
+
+The fields are referenced by their names, for example `json.kubernetes.labels.app`, like in the [rewrite filters](../rewrite/).
+
+{{< highlight yaml >}}
+  filters:
+  - filterx:
+      statements:
+      - set:
+          field: json.kubernetes.cluster
+          value: prod-us
+      - rename:
+          oldName: json.log
+          newName: json.message
+      - if:
+          condition: ${json.level} == "debug"
+          then:
+          - unset:
+              fields:
+              - json.kubernetes.annotations
+          else:
+          - set:
+              field: json.level
+              expr: upper(${json.level})
+{{</ highlight >}}
+
+{{< highlight yaml >}}
+  filters:
+  - filterx:
+      code: |
+        ${json.level} = upper(${json.level});
+        ${json.level} != "DEBUG";
+{{</ highlight >}}
+
+> Note: Every statement of a FilterX block is evaluated as a condition: if a statement of the `code`, or the `expr` of a `set` statement evaluates to false or fails, the record is dropped.
+*/
+type _docFilterX interface{} //nolint:deadcode,unused
+
+// +name:"Syslog-NG FilterX"
+// +url:"https://axoflow.com/docs/axosyslog-core/filterx/"
+// +version:"more info"
+// +description:"Transform records with FilterX"
+// +status:"Testing"
+type _metaFilterX interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+type FilterXConfig struct {
+	// Raw FilterX code of the block. Mutually exclusive with `statements`.
+	Code string `json:"code,omitempty"`
+	// Typed statements of the block, evaluated in order. Mutually exclusive with `code`.
+	Statements []FilterXStatement `json:"statements,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// FilterXStatement is a single statement of a FilterX block, exactly one of its fields must be set.
+type FilterXStatement struct {
+	Set    *FilterXSetConfig         `json:"set,omitempty"`
+	Unset  *FilterXUnsetConfig       `json:"unset,omitempty"`
+	Rename *FilterXRenameConfig      `json:"rename,omitempty"`
+	If     *FilterXConditionalConfig `json:"if,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+type FilterXSetConfig struct {
+	// Name of the field to set
+	FieldName string `json:"field"`
+	// String value of the field
+	Value string `json:"value,omitempty"`
+	// FilterX expression of the value of the field, for example `upper(${json.level})`. Mutually exclusive with `value`.
+	Expr string `json:"expr,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+type FilterXUnsetConfig struct {
+	// Names of the fields to remove
+	Fields []string `json:"fields"`
+}
+
+// +kubebuilder:object:generate=true
+type FilterXRenameConfig struct {
+	// Name of the field to rename
+	OldName string `json:"oldName"`
+	// New name of the field
+	NewName string `json:"newName"`
+}
+
+// +kubebuilder:object:generate=true
+type FilterXConditionalConfig struct {
+	// FilterX expression of the condition, for example `${json.level} == "debug"`
+	Condition string `json:"condition"`
+	// Statements evaluated if the condition is true
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Then []FilterXStatement `json:"then,omitempty"`
+	// Statements evaluated if the condition is false
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Else []FilterXStatement `json:"else,omitempty"`
+}
@@ -67,11 +67,48 @@ type MatchExpr struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	Or []MatchExpr `json:"or,omitempty"`
+	// +docLink:"Selector Directive,#Selector-Directive"
+	Selector *SelectorMatchExpr `json:"selector,omitempty"`
 }
 
 // IsEmpty returns true if the expression is not specified, i.e. empty.
 func (expr *MatchExpr) IsEmpty() bool {
-	return expr == nil || (len(expr.And) == 0 && expr.Not == nil && len(expr.Or) == 0 && expr.Regexp == nil)
+	return expr == nil || (len(expr.And) == 0 && expr.Not == nil && len(expr.Or) == 0 && expr.Regexp == nil && expr.Selector == nil)
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"Selector Directive"
+/*
+Select records by the Kubernetes metadata of the pod. Every condition that is set must match.
+
+{{< highlight yaml >}}
+match:
+  selector:
+    annotations:
+      audit.example.com/enabled: "true"
+    labelExpressions:
+    - key: app.kubernetes.io/name
+      operator: In
+      values: [apache, nginx]
+{{</ highlight >}}
+*/
+type SelectorMatchExpr struct {
+	// Pod labels that must match exactly
+	Labels map[string]string `json:"labels,omitempty"`
+	// Pod annotations that must match exactly
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Set-based and regular expression requirements on pod labels
+	LabelExpressions []LabelExpr `json:"labelExpressions,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// LabelExpr is a requirement on the value of a label, similar to the match expressions of a Kubernetes label selector.
+// In and NotIn compare the value to the values, Regex matches it against any of the regular expressions in values.
+type LabelExpr struct {
+	Key string `json:"key"`
+	// +kubebuilder:validation:Enum=In;NotIn;Exists;DoesNotExist;Regex
+	Operator string   `json:"operator"`
+	Values   []string `json:"values,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
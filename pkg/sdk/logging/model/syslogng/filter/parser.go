@@ -24,10 +24,77 @@ type _hugoParser interface{} //nolint:deadcode,unused
 /*
 Parser filters can be used to extract key-value pairs from message data. Logging operator currently supports the following parsers:
 
+- [csv-parser](#csv)
+- [date-parser](#date)
+- [json-parser](#json)
+- [kv-parser](#kv)
 - [metrics-probe](#metricsprobe)
 - [regexp](#regexp)
 - [syslog-parser](#syslog)
 
+The parsers parse the `${MESSAGE}` by default, which holds the whole record. Set the `template` of the parser to parse a field of the record instead, for example `${json.message}`.
+
+## JSON parser {#json}
+
+The JSON parser parses a field holding JSON, for example the nested JSON logged by an application into the message.
+
+{{< highlight yaml >}}
+  filters:
+  - parser:
+      json-parser:
+        template: ${json.message}
+        prefix: json.app.
+{{</ highlight >}}
+
+For details, see the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-parsers/parser-json/).
+
+## Key-value parser {#kv}
+
+The key-value parser parses key-value pairs, like logfmt lines.
+
+{{< highlight yaml >}}
+  filters:
+  - parser:
+      kv-parser:
+        template: ${json.message}
+        prefix: json.logfmt.
+        value-separator: "="
+        pair-separator: " "
+{{</ highlight >}}
+
+For details, see the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-parsers/parser-kv/).
+
+## CSV parser {#csv}
+
+The CSV parser splits a field into columns, for example an access log line.
+
+{{< highlight yaml >}}
+  filters:
+  - parser:
+      csv-parser:
+        template: ${json.message}
+        prefix: json.access.
+        columns: [client, method, path, status]
+        delimiters: " "
+        flags: [greedy, strip-whitespace]
+{{</ highlight >}}
+
+For details, see the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-parsers/parser-csv/).
+
+## Date parser {#date}
+
+The date parser sets the timestamp of the record from a field.
+
+{{< highlight yaml >}}
+  filters:
+  - parser:
+      date-parser:
+        template: ${json.access.time}
+        format: ["%d/%b/%Y:%H:%M:%S %z"]
+{{</ highlight >}}
+
+For details, see the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-parsers/date-parser/).
+
 ## Regexp parser {#regexp}
 
 The regexp parser can use regular expressions to parse fields from a message.
@@ -71,6 +138,14 @@ type ParserConfig struct {
 	SyslogParser *SyslogParser `json:"syslog-parser,omitempty," syslog-ng:"parser-drv,name=syslog-parser"`
 	// Counts the messages that pass through the flow, and creates labeled stats counters based on the fields of the passing messages. For details, see the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-parsers/metrics-probe/).
 	MetricsProbe *MetricsProbe `json:"metrics-probe,omitempty," syslog-ng:"parser-drv,name=metrics-probe"`
+	// Parse a field holding JSON into name-value pairs. For details, see the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-parsers/parser-json/).
+	JSONParser *JSONParser `json:"json-parser,omitempty" syslog-ng:"parser-drv,name=json-parser"`
+	// Parse key-value pairs, like logfmt, into name-value pairs. For details, see the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-parsers/parser-kv/).
+	KVParser *KVParser `json:"kv-parser,omitempty" syslog-ng:"parser-drv,name=kv-parser"`
+	// Split a field into columns. For details, see the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-parsers/parser-csv/).
+	CSVParser *CSVParser `json:"csv-parser,omitempty" syslog-ng:"parser-drv,name=csv-parser"`
+	// Set the timestamp of the record from a field. For details, see the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-parsers/date-parser/).
+	DateParser *DateParser `json:"date-parser,omitempty" syslog-ng:"parser-drv,name=date-parser"`
 }
 
 // +kubebuilder:object:generate=true
@@ -94,6 +169,71 @@ type SyslogParser struct {
 	Flags []string `json:"flags,omitempty"`
 }
 
+// +kubebuilder:object:generate=true
+// +docName:"[JSON parser](https://axoflow.com/docs/axosyslog-core/chapter-parsers/parser-json/)"
+type JSONParser struct {
+	// Insert a prefix before the names of the parsed name-value pairs, for example `json.app.`
+	Prefix string `json:"prefix,omitempty"`
+	// Template of the JSON to parse, for example `${json.message}`. (default: `${MESSAGE}`)
+	Template string `json:"template,omitempty"`
+	// Parse only the JSON value at this path, for example `payload.attributes`
+	ExtractPrefix string `json:"extract-prefix,omitempty"`
+	// Parse only the messages starting with this marker, the marker is removed before parsing
+	Marker string `json:"marker,omitempty"`
+	// Delimiter of the names of the nested fields. (default: .)
+	KeyDelimiter string `json:"key-delimiter,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"[Key-value parser](https://axoflow.com/docs/axosyslog-core/chapter-parsers/parser-kv/)"
+type KVParser struct {
+	// Insert a prefix before the names of the parsed name-value pairs, for example `json.logfmt.`
+	Prefix string `json:"prefix,omitempty"`
+	// Template of the key-value pairs to parse, for example `${json.message}`. (default: `${MESSAGE}`)
+	Template string `json:"template,omitempty"`
+	// Character separating the keys from the values. (default: =)
+	ValueSeparator string `json:"value-separator,omitempty"`
+	// String separating the key-value pairs. (default: ", ")
+	PairSeparator string `json:"pair-separator,omitempty"`
+	// Name of the field to store the words that are not part of a key-value pair in
+	ExtractStrayWordsInto string `json:"extract-stray-words-into,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"[CSV parser](https://axoflow.com/docs/axosyslog-core/chapter-parsers/parser-csv/)"
+type CSVParser struct {
+	// Names of the columns, the values are stored in the name-value pairs of the columns
+	Columns []string `json:"columns"`
+	// Insert a prefix before the names of the columns, for example `json.access.`
+	Prefix string `json:"prefix,omitempty"`
+	// Template of the line to parse, for example `${json.message}`. (default: `${MESSAGE}`)
+	Template string `json:"template,omitempty"`
+	// Characters separating the columns, every character is a delimiter. (default: space)
+	Delimiters string `json:"delimiters,omitempty"`
+	// Pairs of the characters quoting the values, for example `[]` to quote the values with brackets. (default: double and single quotes)
+	QuotePairs string `json:"quote-pairs,omitempty"`
+	// Escaping of the quote characters inside the quoted values. [escape-none, escape-backslash, escape-double-char] (default: escape-none)
+	// +kubebuilder:validation:Enum=escape-none;escape-backslash;escape-double-char
+	Dialect string `json:"dialect,omitempty"`
+	// Value of a column that is stored as an empty value
+	Null string `json:"null,omitempty"`
+	// Flags of the parser, for example `greedy` to store the rest of the line in the last column, `strip-whitespace` or `drop-invalid`.
+	Flags []string `json:"flags,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+// +docName:"[Date parser](https://axoflow.com/docs/axosyslog-core/chapter-parsers/date-parser/)"
+type DateParser struct {
+	// Formats of the date, in the `strptime` syntax. The first matching format is used. (default: `%FT%T%z`)
+	Format []string `json:"format,omitempty"`
+	// Template of the date to parse, for example `${json.time}`. (default: `${MESSAGE}`)
+	Template string `json:"template,omitempty"`
+	// Time zone of the dates without a time zone, for example `Europe/Budapest`
+	TimeZone string `json:"time-zone,omitempty"`
+	// Flags of the parser, for example `guess-timezone`
+	Flags []string `json:"flags,omitempty"`
+}
+
 // +kubebuilder:object:generate=true
 // +docName:"[Metrics Probe](https://axoflow.com/docs/axosyslog-core/chapter-parsers/metrics-probe/)
 /*
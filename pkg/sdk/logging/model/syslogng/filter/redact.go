@@ -0,0 +1,83 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import "github.com/cisco-open/operator-tools/pkg/secret"
+
+// +name:"Redact"
+// +weight:"200"
+type _hugoRedact interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+// +docName:"Redact"
+/*
+Redact sensitive data in the fields of the records. The filter is rendered as a rewrite rule of `subst`, `set` and `unset` functions,
+and a python parser for the credit card numbers.
+
+Built-in detectors:
+
+- `email`: email addresses
+- `credit_card`: credit card numbers, validated with the Luhn algorithm by a python parser
+- `jwt`: JSON Web Tokens
+- `bearer_token`: bearer tokens of HTTP authorization headers
+- `aws_access_key`: AWS access key IDs
+- `ipv4`, `ipv6`: IP addresses
+
+{{< highlight yaml >}}
+  filters:
+  - redact:
+      fields: ["json.message"]
+      detectors: ["email", "jwt"]
+      patterns: ["customer-\\d+"]
+      action: hash
+      hashSalt:
+        valueFrom:
+          secretKeyRef:
+            name: redact
+            key: salt
+{{</ highlight >}}
+
+Hashing uses the `$(sha256)` template function of the cryptofuncs module. The python parser hashes the credit card numbers the same way.
+*/
+type _docRedact interface{} //nolint:deadcode,unused
+
+// +name:"Syslog-NG Redact"
+// +url:"https://axoflow.com/docs/axosyslog-core/chapter-manipulating-messages/modifying-messages/rewrite-replace/"
+// +version:"more info"
+// +description:"Redact sensitive data in the records"
+// +status:"Testing"
+type _metaRedact interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+type RedactConfig struct {
+	// Fields of the record to redact (default: MESSAGE)
+	Fields []string `json:"fields,omitempty"`
+	// Built-in detectors to use: email, credit_card, jwt, bearer_token, aws_access_key, ipv4, ipv6
+	// +kubebuilder:validation:items:Enum=email;credit_card;jwt;bearer_token;aws_access_key;ipv4;ipv6
+	Detectors []string `json:"detectors,omitempty"`
+	// Additional regular expressions (PCRE syntax) of the sensitive data
+	Patterns []string `json:"patterns,omitempty"`
+	// What to do with the sensitive data: mask, hash or drop (default: mask)
+	// +kubebuilder:validation:Enum=mask;hash;drop
+	Action string `json:"action,omitempty"`
+	// Redact only the matching substrings, or the whole field if any part of it matches: substring or field (default: substring).
+	// Dropping a field unsets it.
+	// +kubebuilder:validation:Enum=substring;field
+	Scope string `json:"scope,omitempty"`
+	// Replacement of the sensitive data for the mask action (default: [REDACTED])
+	Mask string `json:"mask,omitempty"`
+	// Salt prepended to the sensitive data before hashing it. It is rendered into the configuration, so it can't be mounted with `mountFrom`.
+	HashSalt *secret.Secret `json:"hashSalt,omitempty"`
+}
@@ -0,0 +1,71 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+// +name:"Sample"
+// +weight:"200"
+type _hugoSample interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+// +docName:"Sample"
+/*
+Keep a representative sample of the records.
+
+- Without a `key`, every record is kept with the given percentage.
+- With a `key`, the decision is consistent for the value of the key, so for example every line of a trace is either kept or dropped together.
+  Records without the key are sampled randomly. The fluentd sample filter keeps the same records of the same key.
+- Records matching any of the `alwaysKeep` rules are never sampled out.
+
+{{< highlight yaml >}}
+  filters:
+  - sample:
+      percentage: 10
+      key: json.trace_id
+      alwaysKeep:
+      - field: json.level
+        pattern: "^(ERROR|FATAL)$"
+{{</ highlight >}}
+
+The decisions are counted by the `syslogng_sampled_records` metrics probe with the `decision` (`kept` or `dropped`), `namespace` and `sampler` labels.
+Hashing uses the `$(sha1)` template function of the cryptofuncs module.
+*/
+type _docSample interface{} //nolint:deadcode,unused
+
+// +name:"Syslog-NG Sample"
+// +url:"https://axoflow.com/docs/axosyslog-core/chapter-parsers/metrics-probe/"
+// +version:"more info"
+// +description:"Keep a representative sample of the records"
+// +status:"Testing"
+type _metaSample interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+type SampleConfig struct {
+	// Percentage of the records to keep
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage int `json:"percentage"`
+	// Field of the record to sample consistently, for example `json.trace_id`
+	Key string `json:"key,omitempty"`
+	// Records matching any of the rules are always kept
+	AlwaysKeep []SampleRule `json:"alwaysKeep,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+type SampleRule struct {
+	// Field of the record, for example `json.level`
+	Field string `json:"field"`
+	// Regular expression (PCRE syntax) of the value to keep
+	Pattern string `json:"pattern"`
+}
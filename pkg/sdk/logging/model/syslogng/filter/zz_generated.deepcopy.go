@@ -18,7 +18,195 @@
 
 package filter
 
-import ()
+import (
+	"github.com/cisco-open/operator-tools/pkg/secret"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSVParser) DeepCopyInto(out *CSVParser) {
+	*out = *in
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSVParser.
+func (in *CSVParser) DeepCopy() *CSVParser {
+	if in == nil {
+		return nil
+	}
+	out := new(CSVParser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DateParser) DeepCopyInto(out *DateParser) {
+	*out = *in
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DateParser.
+func (in *DateParser) DeepCopy() *DateParser {
+	if in == nil {
+		return nil
+	}
+	out := new(DateParser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterXConditionalConfig) DeepCopyInto(out *FilterXConditionalConfig) {
+	*out = *in
+	if in.Then != nil {
+		in, out := &in.Then, &out.Then
+		*out = make([]FilterXStatement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Else != nil {
+		in, out := &in.Else, &out.Else
+		*out = make([]FilterXStatement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterXConditionalConfig.
+func (in *FilterXConditionalConfig) DeepCopy() *FilterXConditionalConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterXConditionalConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterXConfig) DeepCopyInto(out *FilterXConfig) {
+	*out = *in
+	if in.Statements != nil {
+		in, out := &in.Statements, &out.Statements
+		*out = make([]FilterXStatement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterXConfig.
+func (in *FilterXConfig) DeepCopy() *FilterXConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterXConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterXRenameConfig) DeepCopyInto(out *FilterXRenameConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterXRenameConfig.
+func (in *FilterXRenameConfig) DeepCopy() *FilterXRenameConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterXRenameConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterXSetConfig) DeepCopyInto(out *FilterXSetConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterXSetConfig.
+func (in *FilterXSetConfig) DeepCopy() *FilterXSetConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterXSetConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterXStatement) DeepCopyInto(out *FilterXStatement) {
+	*out = *in
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = new(FilterXSetConfig)
+		**out = **in
+	}
+	if in.Unset != nil {
+		in, out := &in.Unset, &out.Unset
+		*out = new(FilterXUnsetConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rename != nil {
+		in, out := &in.Rename, &out.Rename
+		*out = new(FilterXRenameConfig)
+		**out = **in
+	}
+	if in.If != nil {
+		in, out := &in.If, &out.If
+		*out = new(FilterXConditionalConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterXStatement.
+func (in *FilterXStatement) DeepCopy() *FilterXStatement {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterXStatement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterXUnsetConfig) DeepCopyInto(out *FilterXUnsetConfig) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterXUnsetConfig.
+func (in *FilterXUnsetConfig) DeepCopy() *FilterXUnsetConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterXUnsetConfig)
+	in.DeepCopyInto(out)
+	return out
+}
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroupUnsetConfig) DeepCopyInto(out *GroupUnsetConfig) {
@@ -40,6 +228,56 @@ func (in *GroupUnsetConfig) DeepCopy() *GroupUnsetConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONParser) DeepCopyInto(out *JSONParser) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONParser.
+func (in *JSONParser) DeepCopy() *JSONParser {
+	if in == nil {
+		return nil
+	}
+	out := new(JSONParser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KVParser) DeepCopyInto(out *KVParser) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KVParser.
+func (in *KVParser) DeepCopy() *KVParser {
+	if in == nil {
+		return nil
+	}
+	out := new(KVParser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LabelExpr) DeepCopyInto(out *LabelExpr) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelExpr.
+func (in *LabelExpr) DeepCopy() *LabelExpr {
+	if in == nil {
+		return nil
+	}
+	out := new(LabelExpr)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchConfig) DeepCopyInto(out *MatchConfig) {
 	*out = *in
@@ -67,6 +305,11 @@ func (in *MatchConfig) DeepCopyInto(out *MatchConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(SelectorMatchExpr)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchConfig.
@@ -106,6 +349,11 @@ func (in *MatchExpr) DeepCopyInto(out *MatchExpr) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(SelectorMatchExpr)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchExpr.
@@ -159,6 +407,26 @@ func (in *ParserConfig) DeepCopyInto(out *ParserConfig) {
 		*out = new(MetricsProbe)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.JSONParser != nil {
+		in, out := &in.JSONParser, &out.JSONParser
+		*out = new(JSONParser)
+		**out = **in
+	}
+	if in.KVParser != nil {
+		in, out := &in.KVParser, &out.KVParser
+		*out = new(KVParser)
+		**out = **in
+	}
+	if in.CSVParser != nil {
+		in, out := &in.CSVParser, &out.CSVParser
+		*out = new(CSVParser)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DateParser != nil {
+		in, out := &in.DateParser, &out.DateParser
+		*out = new(DateParser)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParserConfig.
@@ -171,6 +439,41 @@ func (in *ParserConfig) DeepCopy() *ParserConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactConfig) DeepCopyInto(out *RedactConfig) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Detectors != nil {
+		in, out := &in.Detectors, &out.Detectors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HashSalt != nil {
+		in, out := &in.HashSalt, &out.HashSalt
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactConfig.
+func (in *RedactConfig) DeepCopy() *RedactConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegexpMatchExpr) DeepCopyInto(out *RegexpMatchExpr) {
 	*out = *in
@@ -276,6 +579,77 @@ func (in *RewriteConfig) DeepCopy() *RewriteConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleConfig) DeepCopyInto(out *SampleConfig) {
+	*out = *in
+	if in.AlwaysKeep != nil {
+		in, out := &in.AlwaysKeep, &out.AlwaysKeep
+		*out = make([]SampleRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleConfig.
+func (in *SampleConfig) DeepCopy() *SampleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SampleRule) DeepCopyInto(out *SampleRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SampleRule.
+func (in *SampleRule) DeepCopy() *SampleRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SampleRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorMatchExpr) DeepCopyInto(out *SelectorMatchExpr) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LabelExpressions != nil {
+		in, out := &in.LabelExpressions, &out.LabelExpressions
+		*out = make([]LabelExpr, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorMatchExpr.
+func (in *SelectorMatchExpr) DeepCopy() *SelectorMatchExpr {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorMatchExpr)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SetConfig) DeepCopyInto(out *SetConfig) {
 	*out = *in
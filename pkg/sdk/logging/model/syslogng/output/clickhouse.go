@@ -0,0 +1,195 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+// +name:"ClickHouse"
+// +weight:"200"
+type _hugoClickHouse interface{} //nolint:deadcode,unused
+
+// +docName:"Sending messages to ClickHouse"
+/*
+Inserts messages into a [ClickHouse](https://clickhouse.com/) table through the [HTTP interface](https://clickhouse.com/docs/en/interfaces/http) of ClickHouse, using the [HTTP destination](../http/) of syslog-ng.
+
+Every message becomes a row of the table in the `JSONEachRow` format:
+
+- The `${ISODATE}` of the message is written to the `timestamp_column` column.
+- The other columns are filled from the templates listed in `columns`, which are passed to the `$format-json()` template function. By default the message and the kubernetes metadata are mapped to the `message`, `namespace`, `pod`, `container`, `host` and `labels` columns.
+
+The size of the inserted batches is controlled by the `batch-lines` and `batch-timeout` options. Use `async_insert` to let ClickHouse batch small inserts on the server side.
+
+## Example
+
+{{< highlight yaml >}}
+apiVersion: logging.banzaicloud.io/v1beta1
+kind: SyslogNGOutput
+metadata:
+  name: clickhouse
+spec:
+  clickhouse:
+    url: https://clickhouse.clickhouse.svc:8443
+    database: logs
+    table: kubernetes
+    user: logger
+    password:
+      valueFrom:
+        secretKeyRef:
+          name: clickhouse
+          key: password
+    columns:
+      message: ${json.message}
+      namespace: ${json.kubernetes.namespace_name}
+      app: ${json.kubernetes.labels.app}
+    async_insert: true
+    batch-lines: 10000
+    batch-timeout: 5000
+{{</ highlight >}}
+*/
+type _docClickHouse interface{} //nolint:deadcode,unused
+
+// +name:"ClickHouse"
+// +url:"https://clickhouse.com/docs/en/interfaces/http"
+// +description:"Inserts messages into ClickHouse over its HTTP interface"
+// +status:"Testing"
+type _metaClickHouse interface{} //nolint:deadcode,unused
+
+// DefaultClickHouseColumns maps the message and the kubernetes metadata parsed by the main input to columns
+var DefaultClickHouseColumns = map[string]string{
+	"message":   "${json.message}",
+	"namespace": "${json.kubernetes.namespace_name}",
+	"pod":       "${json.kubernetes.pod_name}",
+	"container": "${json.kubernetes.container_name}",
+	"host":      "${json.kubernetes.host}",
+	"labels":    "literal($(format-flat-json --subkeys json.kubernetes.labels.))",
+}
+
+// clickHouseIdentifier matches the database and table names that can be used in the insert query unquoted
+var clickHouseIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// +kubebuilder:object:generate=true
+type ClickHouseOutput struct {
+	// Settings of the HTTP destination. Set `url` to the HTTP interface of ClickHouse, for example `http://clickhouse:8123`, the insert query and its settings are added to it.
+	HTTPOutput `json:",inline"`
+	// Database of the table. (default: default)
+	Database string `json:"database,omitempty" syslog-ng:"ignore"`
+	// Table the messages are inserted into
+	Table string `json:"table" syslog-ng:"ignore"`
+	// Column of the `${ISODATE}` of the message. (default: timestamp)
+	TimestampColumn string `json:"timestamp_column,omitempty" syslog-ng:"ignore"`
+	// Templates by column name, for example `${json.kubernetes.labels.app}`.
+	// Replaces the default mapping of the message and the kubernetes metadata. Ignored if `body` is set.
+	Columns map[string]string `json:"columns,omitempty" syslog-ng:"ignore"`
+	// Let ClickHouse collect the inserted rows in a buffer and write them to the table in batches. (default: false)
+	AsyncInsert *bool `json:"async_insert,omitempty" syslog-ng:"ignore"`
+	// Wait until the rows of an asynchronous insert are written to the table before responding. (default: true)
+	WaitForAsyncInsert *bool `json:"wait_for_async_insert,omitempty" syslog-ng:"ignore"`
+	// Additional ClickHouse settings of the insert query, for example `async_insert_busy_timeout_ms`.
+	// The `date_time_input_format` setting is `best_effort` by default to accept the timestamps.
+	Settings map[string]string `json:"settings,omitempty" syslog-ng:"ignore"`
+}
+
+// Validate checks the settings the insert query is built from
+func (c *ClickHouseOutput) Validate() error {
+	if c.URL == "" {
+		return errors.New("url is required")
+	}
+	if c.Table == "" {
+		return errors.New("table is required")
+	}
+	for _, identifier := range []string{c.database(), c.Table} {
+		if !clickHouseIdentifier.MatchString(identifier) {
+			return errors.Errorf("invalid database or table name %q", identifier)
+		}
+	}
+	if _, err := url.Parse(c.URL); err != nil {
+		return errors.WrapIf(err, "invalid url")
+	}
+	return nil
+}
+
+func (c *ClickHouseOutput) BeforeRender() {
+	database := c.database()
+	// the query is set on every render, so the URL stays the same when the output is rendered again
+	// the URL is checked by Validate
+	if u, err := url.Parse(c.URL); err == nil {
+		query := u.Query()
+		query.Set("date_time_input_format", "best_effort")
+		for name, value := range c.Settings {
+			query.Set(name, value)
+		}
+		if c.AsyncInsert != nil {
+			query.Set("async_insert", clickHouseBool(*c.AsyncInsert))
+		}
+		if c.WaitForAsyncInsert != nil {
+			query.Set("wait_for_async_insert", clickHouseBool(*c.WaitForAsyncInsert))
+		}
+		query.Set("query", fmt.Sprintf("INSERT INTO %s.%s FORMAT JSONEachRow", database, c.Table))
+		u.RawQuery = query.Encode()
+		c.URL = u.String()
+	}
+	if c.Method == "" {
+		c.Method = "POST"
+	}
+	if c.Body == "" {
+		c.Body = c.row()
+	}
+}
+
+func (c *ClickHouseOutput) database() string {
+	if c.Database == "" {
+		return "default"
+	}
+	return c.Database
+}
+
+// row returns the `$format-json()` template of the row of a message
+func (c *ClickHouseOutput) row() string {
+	timestampColumn := c.TimestampColumn
+	if timestampColumn == "" {
+		timestampColumn = "timestamp"
+	}
+	columns := c.Columns
+	if len(columns) == 0 {
+		columns = DefaultClickHouseColumns
+	}
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		if name != timestampColumn {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	pairs := []string{timestampColumn + "=${ISODATE}"}
+	for _, name := range names {
+		pairs = append(pairs, name+"="+columns[name])
+	}
+	return "$(format-json " + strings.Join(pairs, " ") + ")"
+}
+
+func clickHouseBool(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
@@ -32,7 +32,8 @@ type _metaDiskBuffer interface{} //nolint:deadcode,unused
 // +kubebuilder:object:generate=true
 // Documentation: https://axoflow.com/docs/axosyslog-core/chapter-routing-filters/concepts-diskbuffer/
 type DiskBuffer struct {
-	// This is a required option. The maximum size of the disk-buffer in bytes. The minimum value is 1048576 bytes.
+	// This is a required option, unless the buffer limits are enabled in the syslog-ng spec: then it is computed from the size of the buffer volume if it is not set. The output reports a problem if it is neither set nor computed. The maximum size of the disk-buffer in bytes. The minimum value is 1048576 bytes.
+	// +kubebuilder:validation:Optional
 	DiskBufSize int64 `json:"disk_buf_size"`
 	//  If set to yes, syslog-ng OSE cannot lose logs in case of reload/restart, unreachable destination or syslog-ng OSE crash. This solution provides a slower, but reliable disk-buffer option.
 	Reliable bool `json:"reliable"`
@@ -0,0 +1,190 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"github.com/cisco-open/operator-tools/pkg/secret"
+)
+
+// +name:"Kafka"
+// +weight:"200"
+type _hugoKafka interface{} //nolint:deadcode,unused
+
+// +docName:"Sending messages to Apache Kafka"
+/*
+Based on the [Kafka destination of AxoSyslog core](https://axoflow.com/docs/axosyslog-core/chapter-destinations/configuring-destinations-kafka-c/), which publishes messages to [Apache Kafka](https://kafka.apache.org/) using the librdkafka client.
+
+The properties of the client are set in `config` as [librdkafka configuration](https://github.com/confluentinc/librdkafka/blob/master/CONFIGURATION.md) pairs. The `sasl` and `tls` blocks are translated into librdkafka properties as well:
+
+- `security.protocol` is `SASL_SSL`, `SASL_PLAINTEXT` or `SSL`, depending on which of the blocks are set.
+- The SASL username and password are loaded from secrets.
+- The TLS files are mounted from secrets, so use `mountFrom` for them.
+
+Properties set explicitly in `config` take precedence over the ones derived from the `sasl` and `tls` blocks.
+
+## Example
+
+{{< highlight yaml >}}
+apiVersion: logging.banzaicloud.io/v1beta1
+kind: SyslogNGOutput
+metadata:
+  name: kafka
+  namespace: default
+spec:
+  kafka:
+    bootstrap-servers: kafka-0.kafka:9093,kafka-1.kafka:9093
+    topic: logs-${json.kubernetes.namespace_name}
+    fallback-topic: logs
+    key: ${json.kubernetes.pod_name}
+    message: $(format-json --subkeys json.)
+    config:
+      compression.type: zstd
+    sasl:
+      mechanism: SCRAM-SHA-512
+      username:
+        valueFrom:
+          secretKeyRef:
+            name: kafka
+            key: username
+      password:
+        valueFrom:
+          secretKeyRef:
+            name: kafka
+            key: password
+    tls:
+      ca_file:
+        mountFrom:
+          secretKeyRef:
+            name: kafka-tls
+            key: ca.crt
+    disk_buffer:
+      reliable: true
+      disk_buf_size: 512000000
+{{</ highlight >}}
+
+For details on the available options of the output, see the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-destinations/configuring-destinations-kafka-c/).
+*/
+type _docKafka interface{} //nolint:deadcode,unused
+
+// +name:"Kafka"
+// +url:"https://axoflow.com/docs/axosyslog-core/chapter-destinations/configuring-destinations-kafka-c/"
+// +description:"Sending messages to Apache Kafka"
+// +status:"Testing"
+type _metaKafka interface{} //nolint:deadcode,unused
+
+// +kubebuilder:object:generate=true
+type KafkaOutput struct {
+	// Comma-separated list of the Kafka brokers in `host:port` format, used to bootstrap the connection to the cluster.
+	BootstrapServers string `json:"bootstrap-servers"`
+	// The Kafka topic the messages are published to. It can be a template, for example `logs-${json.kubernetes.namespace_name}`.
+	Topic string `json:"topic"`
+	// The topic of the messages, if the topic resolved from the `topic` template is not a valid topic name.
+	FallbackTopic string `json:"fallback-topic,omitempty"`
+	// Template of the key of the messages, for example `${json.kubernetes.pod_name}`. Messages with the same key are published to the same partition. (default: empty key)
+	Key string `json:"key,omitempty"`
+	// Template of the message body. (default: "$ISODATE $HOST $MSGHDR$MSG")
+	Message string `json:"message,omitempty"`
+	// librdkafka configuration properties of the client, for example `compression.type: zstd`.
+	Config map[string]string `json:"config,omitempty" syslog-ng:"ignore"`
+	// SASL authentication of the client.
+	SASL *KafkaSASL `json:"sasl,omitempty" syslog-ng:"ignore"`
+	// TLS settings of the connection to the brokers.
+	TLS *KafkaTLS `json:"tls,omitempty" syslog-ng:"ignore"`
+	// Internal rendered form of the Config, SASL and TLS fields
+	RenderedConfig SecretArrowMap `json:"rendered_config,omitempty" syslog-ng:"name=config"`
+	// Wait for the acknowledgement of each message by the brokers before sending the next one. Slower, but a failed message is retried instead of dropped. (default: false)
+	SyncSend *bool `json:"sync-send,omitempty"`
+	// Specifies the number of worker threads (at least 1) that syslog-ng OSE uses to send messages to the brokers. (default: 1)
+	Workers int `json:"workers,omitempty"`
+	// Time in milliseconds to wait for the delivery reports of the brokers in a polling cycle. (default: 1000)
+	PollTimeout int `json:"poll-timeout,omitempty"`
+	// The number of messages that the output queue can store.
+	LogFIFOSize int `json:"log-fifo-size,omitempty"`
+	// This option enables putting outgoing messages into the disk buffer of the destination to avoid message loss in case of a system failure on the destination side. For details, see the [Syslog-ng DiskBuffer options](../disk_buffer/). (default: false)
+	DiskBuffer *DiskBuffer `json:"disk_buffer,omitempty"`
+	// Persistname
+	PersistName string `json:"persist_name,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+type KafkaSASL struct {
+	// SASL mechanism of the authentication. (default: PLAIN)
+	// +kubebuilder:validation:Enum=PLAIN;SCRAM-SHA-256;SCRAM-SHA-512
+	Mechanism string `json:"mechanism,omitempty"`
+	// Username of the authentication.
+	Username *secret.Secret `json:"username"`
+	// Password of the authentication.
+	Password *secret.Secret `json:"password"`
+}
+
+// +kubebuilder:object:generate=true
+type KafkaTLS struct {
+	// The CA certificate file used to verify the brokers.
+	CaFile *secret.Secret `json:"ca_file,omitempty"`
+	// The client certificate file, if the brokers authenticate the clients.
+	CertFile *secret.Secret `json:"cert_file,omitempty"`
+	// The private key file of the client certificate.
+	KeyFile *secret.Secret `json:"key_file,omitempty"`
+	// The password of the private key.
+	KeyPassword *secret.Secret `json:"key_password,omitempty"`
+	// Verification method of the certificates of the brokers. (default: true)
+	PeerVerify *bool `json:"peer_verify,omitempty"`
+}
+
+// SecretArrowMap is rendered as `"key" => "value"` pairs, with the values loaded from secrets
+type SecretArrowMap map[string]secret.Secret
+
+func (o *KafkaOutput) BeforeRender() {
+	cfg := SecretArrowMap{}
+	if o.SASL != nil {
+		mechanism := o.SASL.Mechanism
+		if mechanism == "" {
+			mechanism = "PLAIN"
+		}
+		cfg["sasl.mechanism"] = secret.Secret{Value: mechanism}
+		cfg.setSecret("sasl.username", o.SASL.Username)
+		cfg.setSecret("sasl.password", o.SASL.Password)
+	}
+	if o.TLS != nil {
+		cfg.setSecret("ssl.ca.location", o.TLS.CaFile)
+		cfg.setSecret("ssl.certificate.location", o.TLS.CertFile)
+		cfg.setSecret("ssl.key.location", o.TLS.KeyFile)
+		cfg.setSecret("ssl.key.password", o.TLS.KeyPassword)
+		if o.TLS.PeerVerify != nil && !*o.TLS.PeerVerify {
+			cfg["enable.ssl.certificate.verification"] = secret.Secret{Value: "false"}
+		}
+	}
+	switch {
+	case o.SASL != nil && o.TLS != nil:
+		cfg["security.protocol"] = secret.Secret{Value: "SASL_SSL"}
+	case o.SASL != nil:
+		cfg["security.protocol"] = secret.Secret{Value: "SASL_PLAINTEXT"}
+	case o.TLS != nil:
+		cfg["security.protocol"] = secret.Secret{Value: "SSL"}
+	}
+	for key, value := range o.Config {
+		cfg[key] = secret.Secret{Value: value}
+	}
+	o.RenderedConfig = nil
+	if len(cfg) > 0 {
+		o.RenderedConfig = cfg
+	}
+}
+
+func (m SecretArrowMap) setSecret(key string, s *secret.Secret) {
+	if s != nil {
+		m[key] = *s
+	}
+}
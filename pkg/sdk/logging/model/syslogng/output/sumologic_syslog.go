@@ -25,6 +25,10 @@ The `sumologic-syslog` output sends log records over HTTP to Sumo Logic. For det
 ## Prerequisites
 
 You need a Sumo Logic account to use this output. For details, see the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-destinations/destination-sumologic-intro/).
+
+## Failover
+
+The output doesn't support the `failover` option of the [syslog output](../syslog/), since the server it connects to is determined by the `deployment`. To fail over to your own collectors, use the syslog output instead.
 */
 type _docSumologicSyslog interface{} //nolint:deadcode,unused
 
@@ -14,6 +14,8 @@
 
 package output
 
+import "emperror.dev/errors"
+
 // +name:"Syslog (RFC5424) output"
 // +weight:"200"
 type _hugoSyslogOutput interface{} //nolint:deadcode,unused
@@ -89,6 +91,30 @@ spec:
     transport: tls
 {{</ highlight >}}
 
+The following example sends the records to an active/standby pair of collectors. When the connection to the `host` fails, the output switches to the next server of the `failover` list. The `failback` block makes it probe the `host` periodically, and return to it once it is reachable again. Failover is available only with the `tcp` and `tls` transports.
+
+{{< highlight yaml >}}
+apiVersion: logging.banzaicloud.io/v1beta1
+kind: SyslogNGOutput
+metadata:
+  name: siem
+  namespace: default
+spec:
+  syslog:
+    host: siem-active.example.com
+    port: 601
+    transport: tcp
+    failover:
+      servers:
+      - siem-standby.example.com
+      failback:
+        tcp-probe-interval: 30
+        successful-probes-required: 3
+    disk_buffer:
+      disk_buf_size: 512000000
+      reliable: true
+{{</ highlight >}}
+
 For details on the available options of the output, see the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-destinations/configuring-destinations-syslog/).
 */
 type _docSyslogOutput interface{} //nolint:deadcode,unused
@@ -111,6 +137,8 @@ type SyslogOutput struct {
 	Port int `json:"port,omitempty"`
 	// Specifies the protocol used to send messages to the destination server. For details, see the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-destinations/configuring-destinations-syslog/reference-destination-syslog-chapter/#transport).
 	Transport string `json:"transport,omitempty"`
+	// Secondary servers the destination switches to when the connection to the `host` fails. It is not supported with the `udp` transport. For details, see the [Failover options](#failover).
+	Failover *Failover `json:"failover,omitempty"`
 	// By default, syslog-ng OSE closes destination sockets if it receives any input from the socket (for example, a reply). If this option is set to no, syslog-ng OSE just ignores the input, but does not close the socket. For details, see the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-destinations/configuring-destinations-syslog/reference-destination-syslog-chapter/#close-on-input).
 	CloseOnInput *bool `json:"close_on_input,omitempty"`
 	// Flags influence the behavior of the destination driver. For details, see the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-destinations/configuring-destinations-syslog/reference-destination-syslog-chapter/#flags).
@@ -134,3 +162,29 @@ type SyslogOutput struct {
 	// Unique name for the syslog-ng driver. If you receive the following error message during syslog-ng startup, set the `persist-name()` option of the duplicate drivers: `Error checking the uniqueness of the persist names, please override it with persist-name option. Shutting down.` See the [documentation of the AxoSyslog syslog-ng distribution](https://axoflow.com/docs/axosyslog-core/chapter-destinations/configuring-destinations-http-nonjava/reference-destination-http-nonjava/#persist-name) for more information.
 	PersistName string `json:"persist_name,omitempty"`
 }
+
+// Validate checks that failover is used with a connection-oriented transport
+func (s *SyslogOutput) Validate() error {
+	if s.Failover != nil && s.Transport == "udp" {
+		return errors.New("failover is not supported with the udp transport, use tcp or tls")
+	}
+	return nil
+}
+
+// +kubebuilder:object:generate=true
+// Documentation: https://axoflow.com/docs/axosyslog-core/chapter-destinations/configuring-destinations-syslog/client-side-failover/
+type Failover struct {
+	// Addresses of the secondary servers, in the order they are tried. They are connected on the port of the destination.
+	// +kubebuilder:validation:MinItems=1
+	Servers []string `json:"servers"`
+	// Return to the primary `host` once it is reachable again. Without it, the destination stays on the secondary server until that fails as well.
+	Failback *Failback `json:"failback,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+type Failback struct {
+	// The time in seconds between the probes of the primary `host`. (default: 60)
+	TCPProbeInterval int `json:"tcp-probe-interval,omitempty"`
+	// The number of consecutive successful probes of the primary `host` needed to return to it. (default: 3)
+	SuccessfulProbesRequired int `json:"successful-probes-required,omitempty"`
+}
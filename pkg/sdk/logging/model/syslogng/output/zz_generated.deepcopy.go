@@ -108,6 +108,46 @@ func (in *Bulk) DeepCopy() *Bulk {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClickHouseOutput) DeepCopyInto(out *ClickHouseOutput) {
+	*out = *in
+	in.HTTPOutput.DeepCopyInto(&out.HTTPOutput)
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AsyncInsert != nil {
+		in, out := &in.AsyncInsert, &out.AsyncInsert
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WaitForAsyncInsert != nil {
+		in, out := &in.WaitForAsyncInsert, &out.WaitForAsyncInsert
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClickHouseOutput.
+func (in *ClickHouseOutput) DeepCopy() *ClickHouseOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(ClickHouseOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskBuffer) DeepCopyInto(out *DiskBuffer) {
 	*out = *in
@@ -190,6 +230,46 @@ func (in *ElasticsearchOutput) DeepCopy() *ElasticsearchOutput {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Failback) DeepCopyInto(out *Failback) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Failback.
+func (in *Failback) DeepCopy() *Failback {
+	if in == nil {
+		return nil
+	}
+	out := new(Failback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Failover) DeepCopyInto(out *Failover) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Failback != nil {
+		in, out := &in.Failback, &out.Failback
+		*out = new(Failback)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Failover.
+func (in *Failover) DeepCopy() *Failover {
+	if in == nil {
+		return nil
+	}
+	out := new(Failover)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileOutput) DeepCopyInto(out *FileOutput) {
 	*out = *in
@@ -279,6 +359,120 @@ func (in *HTTPOutput) DeepCopy() *HTTPOutput {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaOutput) DeepCopyInto(out *KafkaOutput) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SASL != nil {
+		in, out := &in.SASL, &out.SASL
+		*out = new(KafkaSASL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(KafkaTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RenderedConfig != nil {
+		in, out := &in.RenderedConfig, &out.RenderedConfig
+		*out = make(SecretArrowMap, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.SyncSend != nil {
+		in, out := &in.SyncSend, &out.SyncSend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DiskBuffer != nil {
+		in, out := &in.DiskBuffer, &out.DiskBuffer
+		*out = new(DiskBuffer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaOutput.
+func (in *KafkaOutput) DeepCopy() *KafkaOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSASL) DeepCopyInto(out *KafkaSASL) {
+	*out = *in
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSASL.
+func (in *KafkaSASL) DeepCopy() *KafkaSASL {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSASL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaTLS) DeepCopyInto(out *KafkaTLS) {
+	*out = *in
+	if in.CaFile != nil {
+		in, out := &in.CaFile, &out.CaFile
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertFile != nil {
+		in, out := &in.CertFile, &out.CertFile
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyFile != nil {
+		in, out := &in.KeyFile, &out.KeyFile
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyPassword != nil {
+		in, out := &in.KeyPassword, &out.KeyPassword
+		*out = new(secret.Secret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PeerVerify != nil {
+		in, out := &in.PeerVerify, &out.PeerVerify
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTLS.
+func (in *KafkaTLS) DeepCopy() *KafkaTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogScaleOutput) DeepCopyInto(out *LogScaleOutput) {
 	*out = *in
@@ -656,6 +850,11 @@ func (in *SyslogOutput) DeepCopyInto(out *SyslogOutput) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(Failover)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CloseOnInput != nil {
 		in, out := &in.CloseOnInput, &out.CloseOnInput
 		*out = new(bool)
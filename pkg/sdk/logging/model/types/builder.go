@@ -16,6 +16,7 @@ package types
 
 import (
 	"encoding/json"
+	"slices"
 
 	"emperror.dev/errors"
 )
@@ -24,6 +25,7 @@ type SystemBuilder struct {
 	input         Input
 	globalFilters []Filter
 	flows         []*Flow
+	quotas        []*Quota
 	router        *Router
 }
 
@@ -78,11 +80,39 @@ func (s *SystemBuilder) RegisterDefaultFlow(f *Flow) error {
 	return nil
 }
 
+// RegisterQuota routes the records of the quota's namespaces through the quota before the flows
+func (s *SystemBuilder) RegisterQuota(q *Quota) error {
+	for _, e := range s.flows {
+		if e.FlowLabel == q.Label {
+			return errors.Errorf("label %s of quota is already used by a flow", q.Label)
+		}
+	}
+	for _, e := range s.quotas {
+		if e.Label == q.Label {
+			return errors.New("Quota already exists")
+		}
+		for _, ns := range q.Namespaces {
+			if slices.Contains(e.Namespaces, ns) {
+				return errors.Errorf("namespace %s is already covered by quota %s", ns, e.Label)
+			}
+		}
+	}
+	if len(q.Namespaces) == 0 {
+		return nil
+	}
+	s.quotas = append(s.quotas, q)
+	return nil
+}
+
 func (s *SystemBuilder) Build() (*System, error) {
+	router, flows := s.router, s.flows
+	if len(s.quotas) > 0 {
+		router, flows = applyQuotas(s.router, s.flows, s.quotas)
+	}
 	return &System{
 		Input:         s.input,
 		GlobalFilters: s.globalFilters,
-		Router:        s.router,
-		Flows:         s.flows,
+		Router:        router,
+		Flows:         flows,
 	}, nil
 }
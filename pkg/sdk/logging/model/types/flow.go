@@ -48,10 +48,26 @@ func (s *System) GetDirectives() []Directive {
 	// Add Flows after router
 	for _, flow := range s.Flows {
 		directives = append(directives, flow)
+		for _, route := range flow.Routes {
+			directives = append(directives, route)
+		}
+		directives = append(directives, outputLabels(flow)...)
 	}
 	return directives
 }
 
+// outputLabels returns the labels of the relabelled outputs of the flow and its routes
+func outputLabels(flow *Flow) (labels []Directive) {
+	for _, f := range append([]*Flow{flow}, flow.Routes...) {
+		for _, output := range f.Outputs {
+			if relabelled, ok := output.(*RelabelledOutput); ok {
+				labels = append(labels, relabelled.Target)
+			}
+		}
+	}
+	return
+}
+
 type Flow struct {
 	PluginMeta
 
@@ -70,6 +86,12 @@ type Flow struct {
 
 	// Flag whether to add the label to the main event router
 	AddRoute bool
+
+	// Flows receiving the records of the flow instead of its outputs, each record is sent to one of them
+	Routes []*Flow `json:"routes,omitempty"`
+
+	// RouteTag is the tag prefix of the records routed to the flow, set for the routes of a flow
+	RouteTag string `json:"-"`
 }
 
 func (f *Flow) GetPluginMeta() *PluginMeta {
@@ -82,10 +104,16 @@ func (f *Flow) GetParams() Params {
 
 func (f *Flow) GetSections() []Directive {
 	var sections []Directive
+	if f.RouteTag != "" {
+		sections = append(sections, newRouteRestoreDirective(f))
+	}
+	sections = append(sections, newRouteRelabelDirectives(f.Routes)...)
 	for _, filter := range f.Filters {
 		sections = append(sections, filter)
 	}
-	if len(f.Outputs) > 1 {
+	if len(f.Routes) > 0 {
+		sections = append(sections, newRouteTagDirective(f.FlowID+":routes:tag"))
+	} else if len(f.Outputs) > 1 {
 		// We have to convert to General directive
 		sections = append(sections, NewCopyDirective(f.Outputs))
 	} else {
@@ -107,6 +135,11 @@ func (f *Flow) WithOutputs(output ...Output) *Flow {
 	return f
 }
 
+func (f *Flow) WithRoutes(route ...*Flow) *Flow {
+	f.Routes = append(f.Routes, route...)
+	return f
+}
+
 func NewFlow(matches []FlowMatch, id, name, namespace, flowLabel string, includeLabelInRouter *bool) (*Flow, error) {
 	addRoute := true
 	if flowLabel == "" {
@@ -0,0 +1,101 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"maps"
+	"strings"
+)
+
+// Quota sends the records of its namespaces through its filters before they reach the flows.
+// The main router hands the records of the namespaces to the quota label, and a copy of the main router
+// in the label routes what is left of them to the flows.
+type Quota struct {
+	// Fluentd label of the quota
+	Label string
+	// Namespaces the quota applies to
+	Namespaces []string
+	// Filters enforcing the quota
+	Filters []Filter
+}
+
+// applyQuotas returns the main router and the flows with the quotas routed in front of the flows
+func applyQuotas(router *Router, flows []*Flow, quotas []*Quota) (*Router, []*Flow) {
+	var quotaNamespaces []string
+	for _, quota := range quotas {
+		quotaNamespaces = append(quotaNamespaces, quota.Namespaces...)
+	}
+
+	mainRouter := &Router{
+		PluginMeta: router.PluginMeta,
+		Params:     router.Params,
+	}
+	for _, route := range router.Routes {
+		mainRouter.Routes = append(mainRouter.Routes, excludeNamespaces(route, quotaNamespaces))
+	}
+
+	var quotaFlows []*Flow
+	for _, quota := range quotas {
+		mainRouter.Routes = append(mainRouter.Routes, &FlowRoute{
+			PluginMeta: PluginMeta{
+				Directive: "route",
+				Label:     quota.Label,
+			},
+			Params:  Params{},
+			Matches: []Directive{FlowMatch{Namespaces: quota.Namespaces}},
+		})
+
+		params := maps.Clone(router.Params)
+		if params == nil {
+			params = Params{}
+		}
+		// the records are already counted by the main router
+		params["metrics"] = "false"
+		quotaRouter := &Router{
+			PluginMeta: router.PluginMeta,
+			Routes:     router.Routes,
+			Params:     params,
+		}
+		quotaRouter.Id = router.Id + ":" + strings.TrimPrefix(quota.Label, "@")
+
+		quotaFlows = append(quotaFlows, &Flow{
+			PluginMeta: PluginMeta{
+				Directive: "label",
+				Tag:       quota.Label,
+			},
+			FlowLabel: quota.Label,
+			Filters:   quota.Filters,
+			Outputs:   []Output{quotaRouter},
+		})
+	}
+
+	return mainRouter, append(quotaFlows, flows...)
+}
+
+// excludeNamespaces returns a copy of the route that doesn't match the namespaces
+func excludeNamespaces(route Directive, namespaces []string) Directive {
+	flowRoute, ok := route.(*FlowRoute)
+	if !ok {
+		return route
+	}
+	excluded := *flowRoute
+	// the first matching selector decides
+	excluded.Matches = append([]Directive{FlowMatch{Namespaces: namespaces, Negate: true}}, flowRoute.Matches...)
+	if len(flowRoute.Matches) == 0 {
+		// a route without selectors matches everything, keep it that way for the rest of the namespaces
+		excluded.Matches = append(excluded.Matches, FlowMatch{})
+	}
+	return &excluded
+}
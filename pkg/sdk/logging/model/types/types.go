@@ -162,6 +162,7 @@ func NewCopyDirective(directives []Output) Directive {
 				Type:      d.GetPluginMeta().Type,
 				Id:        d.GetPluginMeta().Id,
 				LogLevel:  d.GetPluginMeta().LogLevel,
+				Label:     d.GetPluginMeta().Label,
 				Directive: "store",
 			},
 			Params:        d.GetParams(),
@@ -171,3 +172,104 @@ func NewCopyDirective(directives []Output) Directive {
 	}
 	return directive
 }
+
+// FlowRouteKey holds the tag prefix of the route of the record, empty if no route matches
+const FlowRouteKey = "_flow_route"
+
+// newRouteRelabelDirectives relabel the records tagged with the prefix of a route to the label of the route.
+// They precede the filters of the flow, so the records re-emitted with the tag of their route skip them.
+func newRouteRelabelDirectives(routes []*Flow) (directives []Directive) {
+	for _, route := range routes {
+		directives = append(directives, &GenericDirective{
+			PluginMeta: PluginMeta{
+				Directive: "match",
+				Type:      "relabel",
+				Tag:       route.RouteTag + ".**",
+				Id:        route.FlowID + ":relabel",
+				Label:     route.FlowLabel,
+			},
+		})
+	}
+	return
+}
+
+// newRouteTagDirective re-emits each record with the tag prefix of its route, the records without a route are dropped
+func newRouteTagDirective(id string) Directive {
+	return &GenericDirective{
+		PluginMeta: PluginMeta{
+			Directive: "match",
+			Type:      "rewrite_tag_filter",
+			Tag:       "**",
+			Id:        id,
+		},
+		SubDirectives: []Directive{
+			&GenericDirective{
+				PluginMeta: PluginMeta{Directive: "rule"},
+				Params: Params{
+					"key":     FlowRouteKey,
+					"pattern": "/^(.+)$/",
+					"tag":     "$1.${tag}",
+				},
+			},
+		},
+	}
+}
+
+// newRouteRestoreDirective re-emits the records of a route with their original tag
+func newRouteRestoreDirective(route *Flow) Directive {
+	return &GenericDirective{
+		PluginMeta: PluginMeta{
+			Directive: "match",
+			Type:      "rewrite_tag_filter",
+			Tag:       route.RouteTag + ".**",
+			Id:        route.FlowID + ":restore",
+		},
+		Params: Params{
+			"remove_tag_prefix": route.RouteTag,
+		},
+		SubDirectives: []Directive{
+			&GenericDirective{
+				PluginMeta: PluginMeta{Directive: "rule"},
+				Params: Params{
+					"key":     FlowRouteKey,
+					"pattern": "/^.+$/",
+					"tag":     "${tag}",
+				},
+			},
+		},
+	}
+}
+
+// RelabelledOutput sends the records to a label of its own, where they are prepared for the actual output
+type RelabelledOutput struct {
+	GenericDirective
+	// Target is the label receiving the records, rendered after the flow of the output
+	Target *Flow `json:"target"`
+}
+
+// NewRelabelledOutput returns an output relabeling the records to the label with the filters and the output.
+// The output keeps its id, the relabel output gets the `:relabel` suffix.
+func NewRelabelledOutput(label string, filters []Filter, output Output) *RelabelledOutput {
+	id := output.GetPluginMeta().Id
+	return &RelabelledOutput{
+		GenericDirective: GenericDirective{
+			PluginMeta: PluginMeta{
+				Type:      "relabel",
+				Directive: "match",
+				Tag:       "**",
+				Id:        id + ":relabel",
+				Label:     label,
+			},
+		},
+		Target: &Flow{
+			PluginMeta: PluginMeta{
+				Directive: "label",
+				Tag:       label,
+			},
+			FlowID:    id,
+			FlowLabel: label,
+			Filters:   filters,
+			Outputs:   []Output{output},
+		},
+	}
+}
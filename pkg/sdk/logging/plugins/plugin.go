@@ -48,13 +48,46 @@ func CreateOutput(outputSpec v1beta1.OutputSpec, outputName string, secretLoader
 	}
 }
 
+// DirectivesConverter is implemented by the filters rendered as multiple directives
+type DirectivesConverter interface {
+	ToDirectives(secretLoader secret.SecretLoader, id string) ([]types.Directive, error)
+}
+
+type singleDirectiveConverter struct {
+	DirectiveConverter
+}
+
+func (c singleDirectiveConverter) ToDirectives(secretLoader secret.SecretLoader, id string) ([]types.Directive, error) {
+	directive, err := c.ToDirective(secretLoader, id)
+	if err != nil {
+		return nil, err
+	}
+	return []types.Directive{directive}, nil
+}
+
+// CreateFilter returns the directive of a filter rendered as a single directive
 func CreateFilter(filter v1beta1.Filter, id string, secretLoader secret.SecretLoader) (types.Directive, error) {
+	directives, err := CreateFilters(filter, id, secretLoader)
+	if err != nil {
+		return nil, err
+	}
+	if len(directives) != 1 {
+		return nil, errors.Errorf("filter is rendered as %d directives", len(directives))
+	}
+	return directives[0], nil
+}
+
+// CreateFilters returns the directives of a filter
+func CreateFilters(filter v1beta1.Filter, id string, secretLoader secret.SecretLoader) ([]types.Directive, error) {
 	v := reflect.ValueOf(filter)
-	var converters []DirectiveConverter
+	var converters []DirectivesConverter
 	for i := 0; i < v.NumField(); i++ {
 		if v.Field(i).Kind() == reflect.Ptr && !v.Field(i).IsNil() {
-			if converter, ok := v.Field(i).Interface().(DirectiveConverter); ok {
+			switch converter := v.Field(i).Interface().(type) {
+			case DirectivesConverter:
 				converters = append(converters, converter)
+			case DirectiveConverter:
+				converters = append(converters, singleDirectiveConverter{converter})
 			}
 		}
 	}
@@ -62,7 +95,7 @@ func CreateFilter(filter v1beta1.Filter, id string, secretLoader secret.SecretLo
 	case 0:
 		return nil, errors.New("no plugin config available for filter")
 	case 1:
-		return converters[0].ToDirective(secretLoader, id)
+		return converters[0].ToDirectives(secretLoader, id)
 	default:
 		return nil, errors.Errorf("more then one plugin config is not allowed for a filter")
 	}
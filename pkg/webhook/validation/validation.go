@@ -0,0 +1,311 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/cisco-open/operator-tools/pkg/secret"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kube-logging/logging-operator/pkg/resources/fluentd"
+	"github.com/kube-logging/logging-operator/pkg/resources/model"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+)
+
+// Mode controls how the validating webhook reports the problems it finds
+type Mode string
+
+const (
+	// ModeDisabled doesn't register the validating webhook
+	ModeDisabled Mode = ""
+	// ModeEnforce rejects objects with problems, except for missing references that are returned as warnings
+	ModeEnforce Mode = "enforce"
+	// ModeWarn admits objects with problems and returns the problems as admission warnings
+	ModeWarn Mode = "warn"
+)
+
+// ParseMode validates the mode set on the command line
+func ParseMode(mode string) (Mode, error) {
+	switch m := Mode(mode); m {
+	case ModeDisabled, ModeEnforce, ModeWarn:
+		return m, nil
+	default:
+		return ModeDisabled, errors.Errorf("invalid validating webhook mode %q, use one of %q or %q", mode, ModeEnforce, ModeWarn)
+	}
+}
+
+// SetupWithManager registers the validating webhooks for flows and outputs
+func SetupWithManager(mgr ctrl.Manager, mode Mode) error {
+	if mode == ModeDisabled {
+		return nil
+	}
+
+	v := validator{
+		client: mgr.GetClient(),
+		mode:   mode,
+	}
+	validators := map[runtime.Object]admission.CustomValidator{
+		&v1beta1.Flow{}:          &FlowValidator{v},
+		&v1beta1.ClusterFlow{}:   &ClusterFlowValidator{v},
+		&v1beta1.Output{}:        &OutputValidator{v},
+		&v1beta1.ClusterOutput{}: &ClusterOutputValidator{v},
+	}
+	for apiType, validator := range validators {
+		if err := v1beta1.SetupValidatingWebhookWithManager(mgr, apiType, validator); err != nil {
+			return errors.WrapIfWithDetails(err, "unable to create validating webhook", "type", fmt.Sprintf("%T", apiType))
+		}
+	}
+	return nil
+}
+
+type validator struct {
+	client client.Client
+	mode   Mode
+}
+
+// result turns the problems into an admission error or warnings depending on the mode.
+// Missing references are returned as warnings in both modes: the referenced objects may be created later by the same apply,
+// so rejecting them would make the result depend on the order of the manifests.
+func (v validator) result(kind string, name string, problems []model.Problem) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	var rejected []string
+	for _, problem := range problems {
+		if v.mode == ModeWarn || problem.Kind == model.ProblemMissingReference {
+			warnings = append(warnings, fmt.Sprintf("%s %s: %s", kind, name, problem.Message))
+			continue
+		}
+		rejected = append(rejected, problem.Message)
+	}
+	if len(rejected) > 0 {
+		return warnings, errors.Errorf("%s %s is invalid: %s", kind, name, strings.Join(rejected, "; "))
+	}
+	return warnings, nil
+}
+
+// loggingsFor returns the loggings that process resources with the logging ref in the namespace.
+// Cluster scoped resources are processed only by loggings that have the namespace as their control namespace.
+func (v validator) loggingsFor(ctx context.Context, loggingRef string, namespace string, clusterScoped bool) ([]v1beta1.Logging, error) {
+	var list v1beta1.LoggingList
+	if err := v.client.List(ctx, &list); err != nil {
+		return nil, errors.WrapIf(err, "listing loggings")
+	}
+
+	var res []v1beta1.Logging
+	for _, logging := range list.Items {
+		if logging.Spec.LoggingRef != loggingRef {
+			continue
+		}
+		if clusterScoped {
+			if logging.Spec.ControlNamespace == namespace {
+				res = append(res, logging)
+			}
+			continue
+		}
+		namespaces, err := model.UniqueWatchNamespaces(ctx, v.client, &logging)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(namespaces, namespace) {
+			res = append(res, logging)
+		}
+	}
+	return res, nil
+}
+
+func (v validator) validateOutputSpec(spec any, namespace string) []model.Problem {
+	return model.InvalidProblems(model.ValidateOutputSpec(spec, v.secretLoader(namespace))...)
+}
+
+func (v validator) secretLoader(namespace string) secret.SecretLoader {
+	return secret.NewSecretLoader(v.client, namespace, fluentd.OutputSecretPath, &secret.MountSecrets{})
+}
+
+// validateOutputSecondary checks the secondary of an output against every logging that processes it
+func (v validator) validateOutputSecondary(ctx context.Context, spec v1beta1.OutputSpec, name string, namespace string, clusterScoped bool) ([]model.Problem, error) {
+	if spec.Secondary == nil {
+		return nil, nil
+	}
+
+	loggings, err := v.loggingsFor(ctx, spec.LoggingRef, namespace, clusterScoped)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []model.Problem
+	repo := model.NewLoggingResourceRepository(v.client, ctrl.Log.WithName("validation"))
+	for _, logging := range loggings {
+		clusterOutputs, err := repo.ClusterOutputsFor(ctx, logging)
+		if err != nil {
+			return nil, err
+		}
+		var outputs []v1beta1.Output
+		if !clusterScoped {
+			if outputs, err = repo.OutputsInNamespaceFor(ctx, namespace, logging); err != nil {
+				return nil, err
+			}
+		}
+		problems = appendUnique(problems, model.OutputSecondaryProblems(spec, name, clusterScoped, clusterOutputs, outputs, namespace, v.secretLoader(namespace))...)
+	}
+	return problems, nil
+}
+
+// FlowValidator checks the match expression and the output references of a Flow against every logging that processes it
+type FlowValidator struct {
+	validator
+}
+
+func (v *FlowValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	flow, ok := obj.(*v1beta1.Flow)
+	if !ok {
+		return nil, errors.Errorf("expected a Flow, got %T", obj)
+	}
+
+	loggings, err := v.loggingsFor(ctx, flow.Spec.LoggingRef, flow.Namespace, false)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := model.InvalidProblems(append(model.FlowMatchProblems(flow), model.FlowRouteProblems(flow)...)...)
+	repo := model.NewLoggingResourceRepository(v.client, ctrl.Log.WithName("validation"))
+	for _, logging := range loggings {
+		clusterOutputs, err := repo.ClusterOutputsFor(ctx, logging)
+		if err != nil {
+			return nil, err
+		}
+		outputs, err := repo.OutputsInNamespaceFor(ctx, flow.Namespace, logging)
+		if err != nil {
+			return nil, err
+		}
+		refProblems, _, _ := model.FlowOutputRefProblems(flow, clusterOutputs, outputs)
+		problems = appendUnique(problems, refProblems...)
+	}
+	return v.result("Flow", flow.Name, problems)
+}
+
+func (v *FlowValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *FlowValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ClusterFlowValidator checks the match statements and output references of a ClusterFlow
+type ClusterFlowValidator struct {
+	validator
+}
+
+func (v *ClusterFlowValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	flow, ok := obj.(*v1beta1.ClusterFlow)
+	if !ok {
+		return nil, errors.Errorf("expected a ClusterFlow, got %T", obj)
+	}
+
+	problems := model.InvalidProblems(model.ClusterFlowMatchProblems(flow)...)
+
+	loggings, err := v.loggingsFor(ctx, flow.Spec.LoggingRef, flow.Namespace, true)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := model.NewLoggingResourceRepository(v.client, ctrl.Log.WithName("validation"))
+	for _, logging := range loggings {
+		clusterOutputs, err := repo.ClusterOutputsFor(ctx, logging)
+		if err != nil {
+			return nil, err
+		}
+		refProblems, valid := model.ClusterFlowOutputRefProblems(flow, clusterOutputs)
+		if len(valid) == 0 && len(refProblems) == 0 {
+			refProblems = model.InvalidProblems("flow has no valid outputs")
+		}
+		problems = appendUnique(problems, refProblems...)
+	}
+	return v.result("ClusterFlow", flow.Name, problems)
+}
+
+func (v *ClusterFlowValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *ClusterFlowValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// OutputValidator checks that an Output has exactly one plugin configured, that its secrets exist and that its secondary resolves
+type OutputValidator struct {
+	validator
+}
+
+func (v *OutputValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	output, ok := obj.(*v1beta1.Output)
+	if !ok {
+		return nil, errors.Errorf("expected an Output, got %T", obj)
+	}
+	secondaryProblems, err := v.validateOutputSecondary(ctx, output.Spec, output.Name, output.Namespace, false)
+	if err != nil {
+		return nil, err
+	}
+	return v.result("Output", output.Name, append(v.validateOutputSpec(output.Spec, output.Namespace), secondaryProblems...))
+}
+
+func (v *OutputValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *OutputValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ClusterOutputValidator checks that a ClusterOutput has exactly one plugin configured, that its secrets exist and that its secondary resolves
+type ClusterOutputValidator struct {
+	validator
+}
+
+func (v *ClusterOutputValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	output, ok := obj.(*v1beta1.ClusterOutput)
+	if !ok {
+		return nil, errors.Errorf("expected a ClusterOutput, got %T", obj)
+	}
+	secondaryProblems, err := v.validateOutputSecondary(ctx, output.Spec.OutputSpec, output.Name, output.Namespace, true)
+	if err != nil {
+		return nil, err
+	}
+	return v.result("ClusterOutput", output.Name, append(v.validateOutputSpec(output.Spec.OutputSpec, output.Namespace), secondaryProblems...))
+}
+
+func (v *ClusterOutputValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *ClusterOutputValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func appendUnique[T comparable](list []T, items ...T) []T {
+	for _, item := range items {
+		if !slices.Contains(list, item) {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,119 @@
+// Copyright © 2025 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
+	"github.com/kube-logging/logging-operator/pkg/sdk/logging/model/output"
+)
+
+func newClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func TestFlowValidator(t *testing.T) {
+	c := newClient(t,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+		&v1beta1.Logging{
+			ObjectMeta: metav1.ObjectMeta{Name: "logging"},
+			Spec:       v1beta1.LoggingSpec{ControlNamespace: "logging"},
+		},
+		&v1beta1.ClusterOutput{
+			ObjectMeta: metav1.ObjectMeta{Name: "protected", Namespace: "logging"},
+			Spec:       v1beta1.ClusterOutputSpec{Protected: true},
+		},
+	)
+	flow := &v1beta1.Flow{
+		ObjectMeta: metav1.ObjectMeta{Name: "flow", Namespace: "app"},
+		Spec: v1beta1.FlowSpec{
+			GlobalOutputRefs: []string{"protected"},
+			LocalOutputRefs:  []string{"missing"},
+		},
+	}
+
+	warnings, err := (&FlowValidator{validator{client: c, mode: ModeEnforce}}).ValidateCreate(context.Background(), flow)
+	assert.EqualError(t, err, "Flow flow is invalid: global output reference is protected: protected; flow has become dangling with no valid outputs")
+	assert.Equal(t, admission.Warnings{"Flow flow: dangling local output reference: missing"}, warnings)
+
+	// the output may be created later by the same apply, so a flow with dangling references is admitted
+	flow.Spec.GlobalOutputRefs = nil
+	warnings, err = (&FlowValidator{validator{client: c, mode: ModeEnforce}}).ValidateCreate(context.Background(), flow)
+	require.NoError(t, err)
+	assert.Equal(t, admission.Warnings{
+		"Flow flow: dangling local output reference: missing",
+		"Flow flow: flow has become dangling with no valid outputs",
+	}, warnings)
+
+	// a flow without output references has no outputs to wait for
+	flow.Spec.LocalOutputRefs = nil
+	_, err = (&FlowValidator{validator{client: c, mode: ModeEnforce}}).ValidateCreate(context.Background(), flow)
+	assert.EqualError(t, err, "Flow flow is invalid: flow has become dangling with no valid outputs")
+	flow.Spec.GlobalOutputRefs = []string{"protected"}
+	flow.Spec.LocalOutputRefs = []string{"missing"}
+
+	warnings, err = (&FlowValidator{validator{client: c, mode: ModeWarn}}).ValidateCreate(context.Background(), flow)
+	require.NoError(t, err)
+	assert.Contains(t, warnings, "Flow flow: dangling local output reference: missing")
+}
+
+func TestOutputValidator(t *testing.T) {
+	v := &OutputValidator{validator{client: newClient(t), mode: ModeEnforce}}
+
+	_, err := v.ValidateCreate(context.Background(), &v1beta1.Output{
+		ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "app"},
+	})
+	assert.EqualError(t, err, "Output empty is invalid: no output target configured")
+
+	_, err = v.ValidateCreate(context.Background(), &v1beta1.Output{
+		ObjectMeta: metav1.ObjectMeta{Name: "null", Namespace: "app"},
+		Spec:       v1beta1.OutputSpec{NullOutputConfig: &output.NullOutputConfig{}},
+	})
+	assert.NoError(t, err)
+}
+
+func TestClusterFlowValidatorNamespacesRegex(t *testing.T) {
+	v := &ClusterFlowValidator{validator{client: newClient(t), mode: ModeEnforce}}
+	_, err := v.ValidateCreate(context.Background(), &v1beta1.ClusterFlow{
+		ObjectMeta: metav1.ObjectMeta{Name: "flow", Namespace: "logging"},
+		Spec: v1beta1.ClusterFlowSpec{
+			Match: []v1beta1.ClusterMatch{{ClusterSelect: &v1beta1.ClusterSelect{NamespacesRegex: []string{"app-("}}}},
+		},
+	})
+	assert.ErrorContains(t, err, `invalid namespaces_regex "app-("`)
+}
+
+func TestParseMode(t *testing.T) {
+	mode, err := ParseMode("warn")
+	require.NoError(t, err)
+	assert.Equal(t, ModeWarn, mode)
+
+	_, err = ParseMode("strict")
+	assert.Error(t, err)
+}